@@ -0,0 +1,19 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_account" "current" {}
+
+locals {
+  cdn_enabled = contains(data.gcore_account.current.enabled_services, "CDN")
+}
+
+resource "gcore_cdn_resource" "static" {
+  count  = local.cdn_enabled ? 1 : 0
+  cname  = "cdn.example.com"
+  origin = "origin.example.com"
+}
+
+output "client_id" {
+  value = data.gcore_account.current.client_id
+}
@@ -0,0 +1,11 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_api_request" "regions" {
+  path = "/cloud/v1/regions"
+}
+
+output "region_names" {
+  value = nonsensitive([for r in jsondecode(data.gcore_api_request.regions.response_body).results : r.display_name])
+}
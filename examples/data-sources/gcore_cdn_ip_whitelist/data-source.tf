@@ -0,0 +1,23 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_cdn_ip_whitelist" "cdn" {}
+
+resource "gcore_securitygroup" "origin" {
+  name       = "allow-cdn-only"
+  region_id  = 1
+  project_id = 1
+
+  dynamic "security_group_rules" {
+    for_each = data.gcore_cdn_ip_whitelist.cdn.addresses
+    content {
+      direction        = "ingress"
+      ethertype        = "IPv4"
+      protocol         = "tcp"
+      port_range_min   = 443
+      port_range_max   = 443
+      remote_ip_prefix = security_group_rules.value
+    }
+  }
+}
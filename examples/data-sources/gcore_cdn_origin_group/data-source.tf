@@ -0,0 +1,17 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_cdn_origin_group" "legacy" {
+  name = "legacy-origins"
+}
+
+// fail the plan while a CDN resource or a rule still uses the origin group
+resource "terraform_data" "remove_legacy_origins" {
+  lifecycle {
+    precondition {
+      condition     = !data.gcore_cdn_origin_group.legacy.in_use
+      error_message = "Origin group ${data.gcore_cdn_origin_group.legacy.name} is used by ${join(", ", data.gcore_cdn_origin_group.legacy.resources[*].cname)}."
+    }
+  }
+}
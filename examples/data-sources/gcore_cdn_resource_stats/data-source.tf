@@ -0,0 +1,16 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_cdn_resource_stats" "last_week" {
+  resource_id = gcore_cdn_resource.cdn_example_com.id
+  from        = timeadd(plantimestamp(), "-168h")
+}
+
+output "origin_pull_bytes" {
+  value = data.gcore_cdn_resource_stats.last_week.upstream_bytes
+}
+
+output "cache_hit_ratio" {
+  value = data.gcore_cdn_resource_stats.last_week.cache_hit_traffic_ratio
+}
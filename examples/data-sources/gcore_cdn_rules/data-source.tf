@@ -0,0 +1,19 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_cdn_rules" "console" {
+  resource_id = 42
+}
+
+// import the rules configured in the console, then run
+// terraform plan -generate-config-out=rules.tf
+import {
+  for_each = { for r in data.gcore_cdn_rules.console.rules : r.name => r }
+  to       = gcore_cdn_rule.console[each.key]
+  id       = each.value.import_id
+}
+
+output "rules" {
+  value = data.gcore_cdn_rules.console.rules
+}
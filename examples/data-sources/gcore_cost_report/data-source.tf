@@ -0,0 +1,21 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_project" "staging" {
+  name = "staging"
+}
+
+data "gcore_cost_report" "staging" {
+  time_from   = "2024-01-01T00:00:00Z"
+  time_to     = "2024-02-01T00:00:00Z"
+  project_ids = [data.gcore_project.staging.id]
+  group_by    = ["type"]
+}
+
+check "staging_budget" {
+  assert {
+    condition     = data.gcore_cost_report.staging.total < 500
+    error_message = "Staging costs ${data.gcore_cost_report.staging.total} ${data.gcore_cost_report.staging.currency}, the budget is 500."
+  }
+}
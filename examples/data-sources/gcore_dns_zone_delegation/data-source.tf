@@ -0,0 +1,18 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+resource "gcore_dns_zone" "example" {
+  name = "example.com"
+}
+
+check "example_delegation" {
+  data "gcore_dns_zone_delegation" "example" {
+    zone = gcore_dns_zone.example.name
+  }
+
+  assert {
+    condition     = data.gcore_dns_zone_delegation.example.delegated
+    error_message = "Zone ${gcore_dns_zone.example.name} is not delegated to ${join(", ", data.gcore_dns_zone_delegation.example.nameservers)}, update the NS records at the registrar."
+  }
+}
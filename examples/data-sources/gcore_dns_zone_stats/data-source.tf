@@ -0,0 +1,16 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_dns_zone_stats" "example" {
+  zone        = "example.com"
+  from        = timeadd(plantimestamp(), "-24h")
+  granularity = "1h"
+}
+
+check "example_traffic" {
+  assert {
+    condition     = data.gcore_dns_zone_stats.example.peak < 10 * data.gcore_dns_zone_stats.example.total / max(length(data.gcore_dns_zone_stats.example.requests), 1)
+    error_message = "Zone example.com got ${data.gcore_dns_zone_stats.example.peak} requests in an hour, ten times more than the average."
+  }
+}
@@ -25,3 +25,10 @@ data "gcore_floatingip" "ip_by_fixed_ip" {
 output "view" {
   value = data.gcore_floatingip.ip
 }
+
+output "attached_to" {
+  value = {
+    port_id     = data.gcore_floatingip.ip.port_id
+    instance_id = data.gcore_floatingip.ip.instance_id
+  }
+}
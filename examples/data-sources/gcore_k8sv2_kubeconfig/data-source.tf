@@ -19,5 +19,14 @@ data "gcore_k8sv2_kubeconfig" "config" {
 // to store kubeconfig in a file pls use
 // terraform output -raw kubeconfig > config.yaml
 output "kubeconfig" {
-  value = data.gcore_k8sv2_kubeconfig.config.kubeconfig
+  value     = data.gcore_k8sv2_kubeconfig.config.kubeconfig
+  sensitive = true
+}
+
+// chain the kubernetes provider in the same plan
+provider "kubernetes" {
+  host                   = data.gcore_k8sv2_kubeconfig.config.host
+  cluster_ca_certificate = data.gcore_k8sv2_kubeconfig.config.cluster_ca_certificate
+  client_certificate     = data.gcore_k8sv2_kubeconfig.config.client_certificate
+  client_key             = data.gcore_k8sv2_kubeconfig.config.client_key
 }
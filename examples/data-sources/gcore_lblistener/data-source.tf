@@ -18,6 +18,14 @@ data "gcore_lblistener" "listener" {
   // loadbalancer_id = gcore.loadbalancer_v2.lb.id
 }
 
+data "gcore_lblistener" "https" {
+  region_id  = data.gcore_region.region.id
+  project_id = data.gcore_project.project.id
+
+  loadbalancer_id = "f4b3c1a0-7d2e-4a55-9c1b-2d3e4f5a6b7c"
+  protocol_port   = 443
+}
+
 output "view" {
   value = data.gcore_lblistener.listener
 }
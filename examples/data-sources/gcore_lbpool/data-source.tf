@@ -20,3 +20,40 @@ data "gcore_lbpool" "pool" {
 output "view" {
   value = data.gcore_lbpool.pool
 }
+
+output "member_addresses" {
+  value = [for m in data.gcore_lbpool.pool.members : "${m.address}:${m.protocol_port}"]
+}
+
+// attach a member to a pool of a load balancer managed outside of this configuration
+data "gcore_loadbalancerv2" "existing" {
+  region_id  = data.gcore_region.region.id
+  project_id = data.gcore_project.project.id
+
+  name = "existing-lb"
+}
+
+data "gcore_lblistener" "existing_http" {
+  region_id  = data.gcore_region.region.id
+  project_id = data.gcore_project.project.id
+
+  loadbalancer_id = data.gcore_loadbalancerv2.existing.id
+  protocol_port   = 80
+}
+
+data "gcore_lbpool" "existing_http" {
+  region_id  = data.gcore_region.region.id
+  project_id = data.gcore_project.project.id
+
+  listener_id = data.gcore_lblistener.existing_http.id
+  name        = "http-pool"
+}
+
+resource "gcore_lbmember" "backend" {
+  region_id  = data.gcore_region.region.id
+  project_id = data.gcore_project.project.id
+
+  pool_id       = data.gcore_lbpool.existing_http.id
+  address       = "10.0.0.21"
+  protocol_port = 8080
+}
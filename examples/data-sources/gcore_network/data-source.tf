@@ -19,3 +19,8 @@ data "gcore_network" "tnw" {
 output "view" {
   value = data.gcore_network.tnw
 }
+
+// subnets are available without the separate gcore_subnet lookups
+output "subnet_ids" {
+  value = { for s in data.gcore_network.tnw.subnets : s.cidr => s.id }
+}
@@ -16,6 +16,13 @@ data "gcore_reservedfixedip" "ip" {
   project_id       = data.gcore_project.pr.id
 }
 
+# first reserved fixed IP of the subnet that is not assigned yet
+data "gcore_reservedfixedip" "free" {
+  subnet_id  = "e3c6ee77-48cb-416b-b204-11b492cc776e"
+  region_id  = data.gcore_region.rg.id
+  project_id = data.gcore_project.pr.id
+}
+
 output "view" {
   value = data.gcore_reservedfixedip.ip
 }
@@ -16,6 +16,13 @@ data "gcore_secret" "lb_https" {
   project_id = data.gcore_project.pr.id
 }
 
+// the latest of the rotated certificates named like "lb_https-2024-06-01"
+data "gcore_secret" "lb_https_latest" {
+  name_prefix = "lb_https-"
+  region_id   = data.gcore_region.rg.id
+  project_id  = data.gcore_project.pr.id
+}
+
 output "view" {
   value = data.gcore_secret.lb_https
 }
@@ -0,0 +1,41 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_project" "project" {
+  name = "Default"
+}
+
+data "gcore_region" "region" {
+  name = "Luxembourg-2"
+}
+
+data "gcore_service_security_rules" "web" {
+  region_id  = data.gcore_region.region.id
+  project_id = data.gcore_project.project.id
+
+  cdn             = true
+  loadbalancer_id = "f4b3c1a0-7d2e-4a55-9c1b-2d3e4f5a6b7c"
+  protocol        = "tcp"
+  port_range_min  = 80
+  port_range_max  = 80
+}
+
+resource "gcore_securitygroup" "web" {
+  name       = "web-origin"
+  region_id  = data.gcore_region.region.id
+  project_id = data.gcore_project.project.id
+
+  dynamic "security_group_rules" {
+    for_each = data.gcore_service_security_rules.web.rules
+    content {
+      direction        = security_group_rules.value.direction
+      ethertype        = security_group_rules.value.ethertype
+      protocol         = security_group_rules.value.protocol
+      port_range_min   = security_group_rules.value.port_range_min
+      port_range_max   = security_group_rules.value.port_range_max
+      remote_ip_prefix = security_group_rules.value.remote_ip_prefix
+      description      = security_group_rules.value.description
+    }
+  }
+}
@@ -16,6 +16,13 @@ data "gcore_subnet" "tsn" {
   project_id = data.gcore_project.pr.id
 }
 
+data "gcore_subnet" "by_cidr" {
+  network_id = "b30d0de7-bca2-4c83-9c57-9e645bd2cc92"
+  cidr       = "192.168.10.0/24"
+  region_id  = data.gcore_region.rg.id
+  project_id = data.gcore_project.pr.id
+}
+
 output "view" {
   value = data.gcore_subnet.tsn
 }
@@ -0,0 +1,32 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_project" "pr" {
+  name = "test"
+}
+
+data "gcore_region" "rg" {
+  name = "ED-10 Preprod"
+}
+
+// the plan fails if a subnet of the network has less than 20 free IP addresses
+data "gcore_subnet_ip_availability" "network" {
+  network_id        = "b30d0de7-bca2-4c83-9c57-9e645bd2cc92"
+  min_available_ips = 20
+  region_id         = data.gcore_region.rg.id
+  project_id        = data.gcore_project.pr.id
+}
+
+data "gcore_subnet_ip_availability" "subnet" {
+  subnet_id  = "fd5cb2a3-1e7e-4a4c-8c7e-2e7a1c1f1a2b"
+  region_id  = data.gcore_region.rg.id
+  project_id = data.gcore_project.pr.id
+}
+
+check "subnet_capacity" {
+  assert {
+    condition     = data.gcore_subnet_ip_availability.subnet.subnets[0].used_percent < 80
+    error_message = "The subnet is more than 80% full."
+  }
+}
@@ -0,0 +1,22 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+data "gcore_project" "pr" {
+  name = "test"
+}
+
+data "gcore_region" "rg" {
+  name = "ED-10 Preprod"
+}
+
+data "gcore_volume_list" "service_disks" {
+  region_id   = data.gcore_region.rg.id
+  project_id  = data.gcore_project.pr.id
+  instance_id = "a2ff5b0c-8c5f-4ad0-9c1c-0b4d3e1c5a7e"
+  bootable    = false
+}
+
+output "view" {
+  value = [for v in data.gcore_volume_list.service_disks.volumes : v.id]
+}
@@ -0,0 +1,13 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+resource "gcore_api_request" "tag" {
+  method          = "POST"
+  path            = "/cloud/v1/instances/1/1/f7cfd7c3-c9bc-4d8e-a1a6-2e0a6e8b3d1a/metadata"
+  body            = jsonencode({ team = "platform" })
+  expected_status = [200, 204]
+
+  destroy_method = "DELETE"
+  destroy_path   = "/cloud/v1/instances/1/1/f7cfd7c3-c9bc-4d8e-a1a6-2e0a6e8b3d1a/metadata_item?key=team"
+}
@@ -0,0 +1,2 @@
+# import using <origin_group_id>
+terraform import gcore_cdn_origingroup.origin_group_1 1234
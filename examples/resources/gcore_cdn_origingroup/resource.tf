@@ -15,3 +15,18 @@ resource "gcore_cdn_origingroup" "origin_group_1" {
     backup  = true
   }
 }
+
+// origins served by the load balancer and the instance (through its floating IP) managed in the same configuration
+resource "gcore_cdn_origingroup" "origin_group_cloud" {
+  name     = "origin_group_cloud"
+  use_next = true
+  origin {
+    source  = "${gcore_loadbalancerv2.lb.vip_address}:8080"
+    enabled = true
+  }
+  origin {
+    source  = gcore_floatingip.backup.floating_ip_address
+    enabled = true
+    backup  = true
+  }
+}
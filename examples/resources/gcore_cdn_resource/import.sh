@@ -0,0 +1,2 @@
+# import using <resource_id>
+terraform import gcore_cdn_resource.cdn_example_com 123456
@@ -0,0 +1,26 @@
+// the streams delivered by the CDN resource are played only with the URLs signed by the key,
+// from the allowed countries and from the pages of the allowed domains
+variable "playback_key" {
+  type      = string
+  sensitive = true
+}
+
+resource "gcore_cdn_resource" "streams" {
+  cname        = "streams.example.com"
+  origin_group = gcore_cdn_origingroup.origin_group_1.id
+
+  options {
+    secure_key {
+      key  = var.playback_key
+      type = 2
+    }
+    country_acl {
+      policy_type     = "deny"
+      excepted_values = ["DE", "FR"]
+    }
+    referrer_acl {
+      policy_type     = "deny"
+      excepted_values = ["player.example.com", "*.example.com"]
+    }
+  }
+}
@@ -27,6 +27,9 @@ resource "gcore_cdn_resource" "cdn_example_com" {
     redirect_http_to_https {
       value = true
     }
+    http3_enabled {
+      value = true
+    }
     request_limiter {
       rate_unit = "r/s"
       rate = 5
@@ -35,6 +38,15 @@ resource "gcore_cdn_resource" "cdn_example_com" {
     gzip_on {
       value = true
     }
+    follow_origin_redirect {
+      codes = [301, 302]
+    }
+    proxy_connect_timeout {
+      value = "4s"
+    }
+    proxy_read_timeout {
+      value = "30s"
+    }
     cors {
       value = [
         "*"
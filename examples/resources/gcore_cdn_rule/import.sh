@@ -0,0 +1,2 @@
+# import using <resource_id>:<rule_id>
+terraform import gcore_cdn_rule.cdn_example_com_rule_1 123456:1234
@@ -3,5 +3,8 @@ provider gcore {
 }
 
 resource "gcore_dns_zone" "example_zone" {
-  name = "example_zone.com"
+  name    = "example_zone.com"
+  contact = "hostmaster@example_zone.com"
+  nx_ttl  = 300
+  dnssec  = true
 }
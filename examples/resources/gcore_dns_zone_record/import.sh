@@ -1,2 +1,6 @@
+# import using zone/domain/type format, the domain can be relative to the zone or '@' for the zone apex
+terraform import gcore_dns_zone_record.example_rrset0 example.com/domain.example.com/A
+terraform import gcore_dns_zone_record.example_rrset0 example.com/domain/A
+
 # import using zone:domain:type format
-terraform import gcore_dns_zone_record.example_rrset0 example.com:domain.example.com:A
\ No newline at end of file
+terraform import gcore_dns_zone_record.example_rrset0 example.com:domain.example.com:A
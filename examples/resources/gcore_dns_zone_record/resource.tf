@@ -131,3 +131,48 @@ resource "gcore_dns_zone_record" "examplezone_failover" {
     }
   }
 }
+
+//
+// example3: publishing addresses of the load balancers managed in the same configuration,
+// weighted_shuffle returns the first load balancer in 3 of 4 answers,
+// the drained third load balancer is kept in the RRSet but not returned until serve is true again
+//
+resource "gcore_dns_zone_record" "examplezone_lb" {
+  zone   = "examplezone.com"
+  domain = "www.examplezone.com"
+  type   = "A"
+  ttl    = 60
+
+  filter {
+    type   = "weighted_shuffle"
+    limit  = 1
+    strict = false
+  }
+
+  resource_record {
+    content = gcore_loadbalancerv2.lb_primary.vip_address
+    enabled = true
+
+    meta {
+      weight = 3
+    }
+  }
+
+  resource_record {
+    content = gcore_loadbalancerv2.lb_secondary.vip_address
+    enabled = true
+
+    meta {
+      weight = 1
+    }
+  }
+
+  resource_record {
+    content = gcore_loadbalancerv2.lb_maintenance.vip_address
+    enabled = true
+
+    meta {
+      serve = false
+    }
+  }
+}
@@ -7,6 +7,11 @@ resource "gcore_faas_key" "secret" {
   description = "Keys description"
   project_id = 1
   region_id = 1
+
+  # change any value to rotate the key secret
+  rotation_triggers = {
+    rotated_at = "2024-01-01"
+  }
 }
 
 # To get sensitive value use `terraform output secret`
@@ -138,5 +138,47 @@ resource "gcore_instance" "v" {
     fip_source      = "existing"
     existing_fip_id = gcore_floatingip.fip.id
     security_groups = ["ada84751-fcca-4491-9249-2dfceb321616"]
+
+    // keep the port with its IP address and security groups when the instance is replaced
+    preserve_port_on_destroy = true
+  }
+}
+
+//***
+// cloud-init user data combined from several parts
+//***
+resource "gcore_instance" "with_user_data_parts" {
+  project_id = 1
+  region_id  = 1
+  name       = "web"
+  flavor_id  = "g1-standard-1-2"
+
+  volume {
+    source     = "existing-volume"
+    volume_id  = gcore_volume.second_volume.id
+    boot_index = 0
+  }
+
+  interface {
+    type = "external"
+  }
+
+  user_data_part {
+    content_type = "text/cloud-config"
+    merge_type   = "list(append)+dict(recurse_array)+str()"
+    content      = <<-EOT
+      #cloud-config
+      packages:
+        - nginx
+    EOT
+  }
+
+  user_data_part {
+    content_type = "text/x-shellscript"
+    filename     = "start.sh"
+    content      = <<-EOT
+      #!/bin/sh
+      systemctl enable --now nginx
+    EOT
   }
 }
@@ -0,0 +1,23 @@
+resource "gcore_instance" "with_break_glass_keys" {
+  project_id   = 1
+  region_id    = 1
+  name         = "app"
+  flavor_id    = "g1-standard-1-2"
+  keypair_name = "deploy"
+
+  // the keys are managed centrally and authorized in addition to the keypair
+  ssh_authorized_keys = [
+    file("${path.module}/keys/break-glass.pub"),
+    "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIHk4u9Sxe4VtE0dQ2m2jW0JgC0f3I0r5dX1G1XQJk2bq oncall@example.com",
+  ]
+
+  volume {
+    source     = "existing-volume"
+    volume_id  = gcore_volume.first_volume.id
+    boot_index = 0
+  }
+
+  interface {
+    type = "external"
+  }
+}
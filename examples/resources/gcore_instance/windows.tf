@@ -0,0 +1,33 @@
+resource "gcore_volume" "windows_boot" {
+  name       = "windows boot volume"
+  type_name  = "ssd_hiiops"
+  size       = 50
+  image_id   = "a2ea6e42-8e52-4e2f-8f4b-6b1b4ddb2a64" // Windows Server 2022
+  region_id  = 1
+  project_id = 1
+}
+
+resource "gcore_instance" "windows" {
+  flavor_id      = "g1-standard-4-8"
+  name           = "windows"
+  license_type   = "spla"
+  wait_for_winrm = true
+  region_id      = 1
+  project_id     = 1
+
+  volume {
+    source     = "existing-volume"
+    volume_id  = gcore_volume.windows_boot.id
+    boot_index = 0
+  }
+
+  interface {
+    type = "external"
+  }
+}
+
+// the password of the Admin user is generated if it isn't set
+output "windows_admin_password" {
+  value     = gcore_instance.windows.password
+  sensitive = true
+}
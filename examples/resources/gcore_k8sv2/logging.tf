@@ -0,0 +1,27 @@
+// control plane and audit logs are delivered to LaaS from the cluster creation
+resource "gcore_k8sv2" "audited" {
+  project_id    = 1
+  region_id     = 1
+  name          = "audited"
+  fixed_network = "6bf878c1-1ce4-47c3-a39b-6b5f1d79bf25"
+  fixed_subnet  = "dc3a3ea9-86ae-47ad-a8e8-79df0ce04839"
+  keypair       = "test_key"
+  version       = "v1.26.7"
+  pool {
+    name           = "pool1"
+    flavor_id      = "g1-standard-1-2"
+    min_node_count = 1
+    max_node_count = 1
+  }
+
+  logging {
+    topic_name       = gcore_laas_topic.audit.name
+    retention_period = 90
+  }
+}
+
+resource "gcore_laas_topic" "audit" {
+  project_id = 1
+  region_id  = 1
+  name       = "k8s-audit"
+}
@@ -0,0 +1,27 @@
+resource "gcore_secret" "lb_https" {
+  project_id = data.gcore_project.project.id
+  region_id  = data.gcore_region.region.id
+
+  name              = "lb-https-${substr(sha1(file("tls.crt")), 0, 8)}"
+  private_key       = file("tls.key")
+  certificate       = file("tls.crt")
+  certificate_chain = file("chain.crt")
+  expiration        = "2025-12-28T19:14:44.213"
+
+  lifecycle {
+    # a new secret is created before the listener switches to it, the previous one is deleted afterwards
+    create_before_destroy = true
+  }
+}
+
+resource "gcore_lblistener" "https_443" {
+  project_id = data.gcore_project.project.id
+  region_id  = data.gcore_region.region.id
+
+  loadbalancer_id = gcore_loadbalancerv2.lb.id
+
+  name          = "https-443"
+  protocol      = "TERMINATED_HTTPS"
+  protocol_port = 443
+  secret_id     = gcore_secret.lb_https.id
+}
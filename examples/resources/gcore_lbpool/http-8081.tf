@@ -0,0 +1,32 @@
+resource "gcore_lblistener" "http_8081" {
+  project_id = data.gcore_project.project.id
+  region_id  = data.gcore_region.region.id
+
+  loadbalancer_id = gcore_loadbalancerv2.lb.id
+
+  name          = "My listener with inline members"
+  protocol      = "HTTP"
+  protocol_port = 8081
+}
+
+resource "gcore_lbpool" "http_8081" {
+  project_id = data.gcore_project.project.id
+  region_id  = data.gcore_region.region.id
+
+  loadbalancer_id = gcore_loadbalancerv2.lb.id
+  listener_id     = gcore_lblistener.http_8081.id
+
+  name         = "My pool with inline members"
+  protocol     = "HTTP"
+  lb_algorithm = "ROUND_ROBIN"
+
+  # all members are applied with a single pool update,
+  # don't manage members of this pool with gcore_lbmember
+  dynamic "member" {
+    for_each = ["10.0.0.11", "10.0.0.12", "10.0.0.13"]
+    content {
+      address       = member.value
+      protocol_port = 8080
+    }
+  }
+}
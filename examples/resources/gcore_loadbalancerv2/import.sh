@@ -1,2 +1,3 @@
 # import using <project_id>:<region_id>:<loadbalancer_id> format
-terraform import gcore_loadbalancerv2.loadbalancer1 1:6:447d2959-8ae0-4ca0-8d47-9f050a3637d7
\ No newline at end of file
+terraform import gcore_loadbalancerv2.loadbalancer1 1:6:447d2959-8ae0-4ca0-8d47-9f050a3637d7
+# the <project_id>:<region_id>:<loadbalancer_id>:<listener_id> ID of gcore_loadbalancer is accepted too, the listener is ignored
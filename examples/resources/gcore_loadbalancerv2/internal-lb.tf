@@ -0,0 +1,10 @@
+resource "gcore_loadbalancerv2" "internal_lb" {
+  project_id = data.gcore_project.project.id
+  region_id  = data.gcore_region.region.id
+
+  name           = "My internal load balancer"
+  flavor         = "lb1-1-2"
+  internal       = true
+  vip_network_id = gcore_network.private_network.id
+  vip_subnet_id  = gcore_subnet.private_subnet.id
+}
@@ -3,6 +3,13 @@ resource "gcore_loadbalancerv2" "public_lb" {
   region_id  = data.gcore_region.region.id
   name       = "My first public load balancer"
   flavor     = "lb1-1-2"
+
+  lifecycle {
+    postcondition {
+      condition     = self.provisioning_status == "ACTIVE"
+      error_message = "Load balancer is not active."
+    }
+  }
 }
 
 output "public_lb_ip" {
@@ -0,0 +1,2 @@
+# import using <project_id>:<region_id>:<volume_id>:<instance_id> format
+terraform import gcore_volume_attachment.shared 1:6:447d2959-8ae0-4ca0-8d47-9f050a3637d7:5f3b6b1e-5d0c-4a5e-9e43-3c1b1e3f4a01
\ No newline at end of file
@@ -0,0 +1,28 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+// the volume of a multiattach type is shared by the instances of the cluster
+resource "gcore_volume" "shared" {
+  name       = "cluster-shared"
+  type_name  = "ssd_hiiops"
+  size       = 100
+  region_id  = 1
+  project_id = 1
+}
+
+resource "gcore_volume_attachment" "shared" {
+  for_each    = toset(var.cluster_instance_ids)
+  volume_id   = gcore_volume.shared.id
+  instance_id = each.value
+  region_id   = 1
+  project_id  = 1
+}
+
+variable "cluster_instance_ids" {
+  type = list(string)
+}
+
+output "devices" {
+  value = { for id, a in gcore_volume_attachment.shared : id => a.device }
+}
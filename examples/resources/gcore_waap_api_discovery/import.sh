@@ -0,0 +1,2 @@
+# import using the domain ID
+terraform import gcore_waap_api_discovery.shop 42
@@ -0,0 +1,13 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+resource "gcore_waap_api_discovery" "shop" {
+  domain_id                   = gcore_waap_domain.shop.domain_id
+  traffic_scan_enabled        = true
+  traffic_scan_interval_hours = 24
+
+  // the OpenAPI description is uploaded again whenever the file changes
+  openapi_spec      = file("${path.module}/openapi.yaml")
+  openapi_file_name = "openapi.yaml"
+}
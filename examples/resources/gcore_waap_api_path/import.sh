@@ -0,0 +1,2 @@
+# import using <domain_id>:<api_path_id> format
+terraform import gcore_waap_api_path.get_user 42:6a1c2f50-3b0e-4d3e-9f5a-0c2d7e1b9a11
@@ -0,0 +1,12 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+resource "gcore_waap_api_path" "get_user" {
+  domain_id   = gcore_waap_domain.shop.domain_id
+  path        = "/api/v1/users/{user_id}"
+  method      = "GET"
+  api_version = "v1"
+  api_groups  = ["users"]
+  tags        = ["pii"]
+}
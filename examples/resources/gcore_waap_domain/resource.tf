@@ -0,0 +1,10 @@
+provider gcore {
+  permanent_api_token = "251$d3361.............1b35f26d8"
+}
+
+// the WAAP domain is created by enabling WAAP on the CDN resource
+resource "gcore_waap_domain" "shop" {
+  domain_id = 42
+  mode      = "monitor"
+  api_urls  = ["/api/v1", "/api/v2"]
+}
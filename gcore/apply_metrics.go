@@ -0,0 +1,337 @@
+package gcore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	ProviderOptApplyMetricsPath = "apply_metrics_path"
+
+	// applyMetricsSlowest is the number of the slowest operations listed in the summary one by one.
+	applyMetricsSlowest = 20
+)
+
+var (
+	// applyMetricsRecorders keeps a recorder per summary path, the provider aliases writing to the same path share it.
+	applyMetricsRecorders sync.Map
+
+	uuidPathSegment   = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	numberPathSegment = regexp.MustCompile(`^[0-9]+$`)
+)
+
+type applyOperationKey struct{}
+
+// applyMetrics records the durations of the resource operations, of their API requests and tasks
+// and rewrites the summary at the path after every operation, so the file holds the summary of the whole run
+// when terraform stops the provider.
+type applyMetrics struct {
+	mu         sync.Mutex
+	path       string
+	startedAt  time.Time
+	operations map[string]*applyMetricsStats
+	requests   map[string]*applyMetricsStats
+	tasks      map[string]*applyMetricsStats
+	seenTasks  map[string]bool
+	slowest    []*applyOperation
+}
+
+// applyOperation is a create, read, update or delete of a resource with its API requests and tasks.
+type applyOperation struct {
+	Resource  string               `json:"resource"`
+	Operation string               `json:"operation"`
+	ID        string               `json:"id,omitempty"`
+	Duration  applyMetricsDuration `json:"duration_ms"`
+	Failed    bool                 `json:"failed,omitempty"`
+	Requests  int                  `json:"api_requests"`
+	APITime   applyMetricsDuration `json:"api_ms"`
+	Tasks     []applyTask          `json:"tasks,omitempty"`
+
+	mu      sync.Mutex
+	metrics *applyMetrics
+}
+
+type applyTask struct {
+	ID       string               `json:"id"`
+	Type     string               `json:"type"`
+	State    string               `json:"state"`
+	Duration applyMetricsDuration `json:"duration_ms"`
+}
+
+// applyMetricsStats aggregates the durations of the operations, requests or tasks of the same kind.
+type applyMetricsStats struct {
+	Name   string               `json:"name"`
+	Count  int                  `json:"count"`
+	Errors int                  `json:"errors"`
+	Total  applyMetricsDuration `json:"total_ms"`
+	Max    applyMetricsDuration `json:"max_ms"`
+}
+
+// applyMetricsDuration is written to the summary in milliseconds.
+type applyMetricsDuration time.Duration
+
+func (d applyMetricsDuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).Milliseconds())
+}
+
+type applyMetricsSummary struct {
+	StartedAt  time.Time            `json:"started_at"`
+	UpdatedAt  time.Time            `json:"updated_at"`
+	Operations []*applyMetricsStats `json:"operations"`
+	Requests   []*applyMetricsStats `json:"api_requests"`
+	Tasks      []*applyMetricsStats `json:"tasks"`
+	Slowest    []*applyOperation    `json:"slowest_operations"`
+}
+
+// getApplyMetrics returns the recorder of the path, nil if the path is empty and the metrics are not recorded.
+func getApplyMetrics(path string) *applyMetrics {
+	if path == "" {
+		return nil
+	}
+	m, _ := applyMetricsRecorders.LoadOrStore(path, &applyMetrics{
+		path:       path,
+		startedAt:  time.Now().UTC(),
+		operations: map[string]*applyMetricsStats{},
+		requests:   map[string]*applyMetricsStats{},
+		tasks:      map[string]*applyMetricsStats{},
+		seenTasks:  map[string]bool{},
+	})
+	return m.(*applyMetrics)
+}
+
+func (s *applyMetricsStats) add(d time.Duration, failed bool) {
+	s.Count++
+	if failed {
+		s.Errors++
+	}
+	s.Total += applyMetricsDuration(d)
+	if applyMetricsDuration(d) > s.Max {
+		s.Max = applyMetricsDuration(d)
+	}
+}
+
+func applyMetricsStatsOf(stats map[string]*applyMetricsStats, name string) *applyMetricsStats {
+	s, ok := stats[name]
+	if !ok {
+		s = &applyMetricsStats{Name: name}
+		stats[name] = s
+	}
+	return s
+}
+
+// finish records the finished operation and writes the summary.
+func (m *applyMetrics) finish(op *applyOperation) {
+	m.mu.Lock()
+	applyMetricsStatsOf(m.operations, op.Resource+" "+op.Operation).add(time.Duration(op.Duration), op.Failed)
+	m.slowest = append(m.slowest, op)
+	sort.SliceStable(m.slowest, func(i, j int) bool { return m.slowest[i].Duration > m.slowest[j].Duration })
+	if len(m.slowest) > applyMetricsSlowest {
+		m.slowest = m.slowest[:applyMetricsSlowest]
+	}
+	defer m.mu.Unlock()
+
+	// the summary is written under the lock, so a summary of the earlier operations can't replace a later one
+	if err := writeApplyMetrics(m.path, m.summary()); err != nil {
+		log.Printf("[WARN] Cannot write the apply metrics to %s: %s", m.path, err)
+	}
+}
+
+// request records the API request of the operation.
+func (m *applyMetrics) request(op *applyOperation, req *http.Request, d time.Duration, failed bool) {
+	op.mu.Lock()
+	op.Requests++
+	op.APITime += applyMetricsDuration(d)
+	op.mu.Unlock()
+
+	m.mu.Lock()
+	applyMetricsStatsOf(m.requests, req.Method+" "+applyMetricsPath(req.URL.Path)).add(d, failed)
+	m.mu.Unlock()
+}
+
+// task records the finished task of the operation, the task is polled until it finishes, so it is recorded once.
+func (m *applyMetrics) task(op *applyOperation, task applyTask) {
+	m.mu.Lock()
+	if m.seenTasks[task.ID] {
+		m.mu.Unlock()
+		return
+	}
+	m.seenTasks[task.ID] = true
+	applyMetricsStatsOf(m.tasks, task.Type).add(time.Duration(task.Duration), task.State == string(tasks.TaskStateError))
+	m.mu.Unlock()
+
+	op.mu.Lock()
+	op.Tasks = append(op.Tasks, task)
+	op.mu.Unlock()
+}
+
+// summary returns the aggregates ordered by the total duration, the longest first.
+func (m *applyMetrics) summary() applyMetricsSummary {
+	sorted := func(stats map[string]*applyMetricsStats) []*applyMetricsStats {
+		list := make([]*applyMetricsStats, 0, len(stats))
+		for _, s := range stats {
+			c := *s
+			list = append(list, &c)
+		}
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].Total != list[j].Total {
+				return list[i].Total > list[j].Total
+			}
+			return list[i].Name < list[j].Name
+		})
+		return list
+	}
+	return applyMetricsSummary{
+		StartedAt:  m.startedAt,
+		UpdatedAt:  time.Now().UTC(),
+		Operations: sorted(m.operations),
+		Requests:   sorted(m.requests),
+		Tasks:      sorted(m.tasks),
+		Slowest:    append([]*applyOperation(nil), m.slowest...),
+	}
+}
+
+// writeApplyMetrics replaces the summary file, so a reader never sees it half-written.
+func writeApplyMetrics(path string, summary applyMetricsSummary) error {
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// applyMetricsPath replaces the IDs in the path of the request, so the requests to the same endpoint are aggregated.
+func applyMetricsPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		switch {
+		case uuidPathSegment.MatchString(s):
+			segments[i] = "{id}"
+		case numberPathSegment.MatchString(s):
+			segments[i] = "{n}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// withApplyMetrics records the durations of the operations of the resource when the provider writes the apply metrics.
+// The data sources are recorded as data.<name>.
+func withApplyMetrics(name string, r *schema.Resource) {
+	wrap := func(operation string, f schema.CreateContextFunc) schema.CreateContextFunc {
+		if f == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			config, ok := m.(*Config)
+			if !ok || config.ApplyMetrics == nil {
+				return f(ctx, d, m)
+			}
+
+			op := &applyOperation{Resource: name, Operation: operation, ID: d.Id(), metrics: config.ApplyMetrics}
+			start := time.Now()
+			diags := f(context.WithValue(ctx, applyOperationKey{}, op), d, m)
+			op.Duration = applyMetricsDuration(time.Since(start))
+			op.Failed = diags.HasError()
+			if op.ID == "" {
+				op.ID = d.Id()
+			}
+			config.ApplyMetrics.finish(op)
+			return diags
+		}
+	}
+
+	r.CreateContext = wrap("create", r.CreateContext)
+	r.ReadContext = schema.ReadContextFunc(wrap("read", schema.CreateContextFunc(r.ReadContext)))
+	r.UpdateContext = schema.UpdateContextFunc(wrap("update", schema.CreateContextFunc(r.UpdateContext)))
+	r.DeleteContext = schema.DeleteContextFunc(wrap("delete", schema.CreateContextFunc(r.DeleteContext)))
+}
+
+// metricsTransport times the requests sent in a recorded operation, including the retries and the rate limit waits,
+// and picks the durations of the finished tasks from the task polling. The other requests pass through.
+type metricsTransport struct {
+	next http.RoundTripper
+}
+
+func newMetricsTransport(next http.RoundTripper) http.RoundTripper {
+	return &metricsTransport{next: next}
+}
+
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	op, ok := req.Context().Value(applyOperationKey{}).(*applyOperation)
+	if !ok {
+		return t.next.RoundTrip(req)
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	op.metrics.request(op, req, time.Since(start), err != nil || resp.StatusCode >= 400)
+	if err == nil && resp.StatusCode == http.StatusOK && req.Method == http.MethodGet && isTaskPath(req.URL.Path) {
+		if task, ok := readFinishedTask(resp); ok {
+			op.metrics.task(op, task)
+		}
+	}
+	return resp, err
+}
+
+func isTaskPath(path string) bool {
+	segments := strings.Split(strings.TrimSuffix(path, "/"), "/")
+	n := len(segments)
+	return n >= 2 && segments[n-2] == "tasks" && uuidPathSegment.MatchString(segments[n-1])
+}
+
+// readFinishedTask returns the finished task of the response, the body is kept for the caller.
+func readFinishedTask(resp *http.Response) (applyTask, bool) {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return applyTask{}, false
+	}
+
+	var task struct {
+		ID         string                     `json:"id"`
+		TaskType   string                     `json:"task_type"`
+		State      tasks.TaskState            `json:"state"`
+		CreatedOn  gcorecloud.JSONRFC3339NoZ  `json:"created_on"`
+		FinishedOn *gcorecloud.JSONRFC3339NoZ `json:"finished_on"`
+	}
+	if err := json.Unmarshal(body, &task); err != nil || task.FinishedOn == nil {
+		return applyTask{}, false
+	}
+	if task.State != tasks.TaskStateFinished && task.State != tasks.TaskStateError {
+		return applyTask{}, false
+	}
+	return applyTask{
+		ID:       task.ID,
+		Type:     task.TaskType,
+		State:    string(task.State),
+		Duration: applyMetricsDuration(task.FinishedOn.Sub(task.CreatedOn.Time)),
+	}, true
+}
@@ -0,0 +1,128 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestApplyMetrics(t *testing.T) {
+	const taskID = "b6c8f8b4-3a7e-4c59-9a4d-6f5c3ad0f1e2"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/tasks/" + taskID:
+			fmt.Fprintf(w, `{"id":%q,"task_type":"create_vm","state":"FINISHED","created_on":"2024-01-01T10:00:00","finished_on":"2024-01-01T10:01:30"}`, taskID)
+		default:
+			fmt.Fprint(w, `{"tasks":[]}`)
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newMetricsTransport(http.DefaultTransport)}
+	get := func(ctx context.Context, path string) error {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+path, nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{"name": {Type: schema.TypeString, Optional: true}},
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			for _, path := range []string{"/v1/instances/1/2", "/v1/tasks/" + taskID, "/v1/tasks/" + taskID} {
+				if err := get(ctx, path); err != nil {
+					return diag.FromErr(err)
+				}
+			}
+			d.SetId("instance")
+			return nil
+		},
+	}
+	withApplyMetrics("gcore_instance", r)
+
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	config := &Config{ApplyMetrics: getApplyMetrics(path)}
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	if diags := r.CreateContext(context.Background(), d, config); diags.HasError() {
+		t.Fatal(diags)
+	}
+	// the requests outside of the operations are not recorded
+	if err := get(context.Background(), "/v1/instances/1/2"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var summary struct {
+		Operations []applyMetricsStats `json:"operations"`
+		Requests   []applyMetricsStats `json:"api_requests"`
+		Tasks      []struct {
+			Name  string `json:"name"`
+			Count int    `json:"count"`
+			Total int64  `json:"total_ms"`
+		} `json:"tasks"`
+		Slowest []struct {
+			Resource  string `json:"resource"`
+			Operation string `json:"operation"`
+			ID        string `json:"id"`
+			Requests  int    `json:"api_requests"`
+			Tasks     []struct {
+				ID string `json:"id"`
+			} `json:"tasks"`
+		} `json:"slowest_operations"`
+	}
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(summary.Operations) != 1 || summary.Operations[0].Name != "gcore_instance create" || summary.Operations[0].Count != 1 {
+		t.Errorf("operations = %+v, want one gcore_instance create", summary.Operations)
+	}
+	requests := map[string]int{}
+	for _, r := range summary.Requests {
+		requests[r.Name] = r.Count
+	}
+	if want := map[string]int{"GET /v1/instances/{n}/{n}": 1, "GET /v1/tasks/{id}": 2}; fmt.Sprint(requests) != fmt.Sprint(want) {
+		t.Errorf("api_requests = %v, want %v", requests, want)
+	}
+	if len(summary.Tasks) != 1 || summary.Tasks[0].Name != "create_vm" || summary.Tasks[0].Count != 1 || summary.Tasks[0].Total != 90000 {
+		t.Errorf("tasks = %+v, want the create_vm task of 90s recorded once", summary.Tasks)
+	}
+	if len(summary.Slowest) != 1 || summary.Slowest[0].ID != "instance" || summary.Slowest[0].Requests != 3 ||
+		len(summary.Slowest[0].Tasks) != 1 || summary.Slowest[0].Tasks[0].ID != taskID {
+		t.Errorf("slowest_operations = %+v, want the create of the instance with its requests and task", summary.Slowest)
+	}
+}
+
+func TestApplyMetricsDisabled(t *testing.T) {
+	if getApplyMetrics("") != nil {
+		t.Error("getApplyMetrics() records the metrics without the path")
+	}
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	if getApplyMetrics(path) != getApplyMetrics(path) {
+		t.Error("getApplyMetrics() returns different recorders for the same path")
+	}
+}
+
+func TestApplyMetricsPath(t *testing.T) {
+	got := applyMetricsPath("/cloud/v1/instances/1/2/5f1b1d3c-6c9e-4a6e-8a43-2b4f7d9c0e11/interfaces")
+	if want := "/cloud/v1/instances/{n}/{n}/{id}/interfaces"; got != want {
+		t.Errorf("applyMetricsPath() = %q, want %q", got, want)
+	}
+}
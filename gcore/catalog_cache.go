@@ -0,0 +1,88 @@
+package gcore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"sync"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+)
+
+// catalogCache keeps responses of the catalogs that don't change during a terraform operation
+// (projects, regions, images), so big workspaces don't repeat the same list requests for every resource.
+// The entries are keyed by the credentials, so provider aliases of different accounts can share the cache.
+type catalogCache struct {
+	mu      sync.Mutex
+	entries map[string]*catalogEntry
+}
+
+type catalogEntry struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+var catalog = &catalogCache{}
+
+// providerClients keeps the authenticated cloud clients keyed by providerClientKey,
+// provider aliases with the same credentials authenticate once and share the client.
+var providerClients = &catalogCache{}
+
+func (c *catalogCache) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = nil
+}
+
+// get returns the cached value of the key or calls load to get it.
+// Concurrent calls with the same key wait for a single load. Errors are not cached.
+func (c *catalogCache) get(key string, load func() (interface{}, error)) (interface{}, error) {
+	c.mu.Lock()
+	if c.entries == nil {
+		c.entries = map[string]*catalogEntry{}
+	}
+	if e, ok := c.entries[key]; ok {
+		c.mu.Unlock()
+		<-e.done
+		return e.value, e.err
+	}
+	e := &catalogEntry{done: make(chan struct{})}
+	c.entries[key] = e
+	c.mu.Unlock()
+
+	e.value, e.err = load()
+	if e.err != nil {
+		c.mu.Lock()
+		if c.entries[key] == e {
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+	} else {
+		// the keys carry no credentials, see catalogKey and providerClientKey
+		log.Printf("[DEBUG] Cached catalog %s", key)
+	}
+	close(e.done)
+	return e.value, e.err
+}
+
+// catalogKey builds a cache key of the request, the credentials are part of the key
+// because the catalogs depend on the account. They are hashed to keep them out of the logs.
+func catalogKey(client *gcorecloud.ServiceClient, query string) string {
+	return "catalog|" + hashKeyParts(client.APIToken, client.AccessToken()) + "|" + client.ResourceBaseURL() + query
+}
+
+// providerClientKey builds a cache key of the provider credentials and endpoints.
+// The credentials are hashed to keep them out of the logs.
+func providerClientKey(parts ...string) string {
+	return "client|" + hashKeyParts(parts...)
+}
+
+func hashKeyParts(parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		h.Write([]byte(p))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
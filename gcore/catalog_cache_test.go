@@ -0,0 +1,88 @@
+package gcore
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+)
+
+func TestCatalogCache(t *testing.T) {
+	cache := &catalogCache{}
+
+	var loads int32
+	load := func() (interface{}, error) {
+		atomic.AddInt32(&loads, 1)
+		return []string{"ED-10"}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := cache.get("regions", load); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if loads != 1 {
+		t.Errorf("get() loaded the catalog %d times, want 1", loads)
+	}
+
+	cache.reset()
+	if _, err := cache.get("regions", load); err != nil {
+		t.Fatal(err)
+	}
+	if loads != 2 {
+		t.Errorf("get() after reset loaded the catalog %d times, want 2", loads)
+	}
+
+	fail := func() (interface{}, error) { return nil, errors.New("unavailable") }
+	if _, err := cache.get("projects", fail); err == nil {
+		t.Errorf("get() expected error")
+	}
+	got, err := cache.get("projects", func() (interface{}, error) { return 1, nil })
+	if err != nil || got != 1 {
+		t.Errorf("get() = %v, %v after failed load, want 1, nil", got, err)
+	}
+}
+
+func TestProviderClientKey(t *testing.T) {
+	a := providerClientKey("https://api.gcore.com/cloud", "https://api.gcore.com/iam", "token-a", "", "", "")
+	if a != providerClientKey("https://api.gcore.com/cloud", "https://api.gcore.com/iam", "token-a", "", "", "") {
+		t.Error("providerClientKey() differs for the same credentials")
+	}
+	if a == providerClientKey("https://api.gcore.com/cloud", "https://api.gcore.com/iam", "token-b", "", "", "") {
+		t.Error("providerClientKey() is the same for different tokens")
+	}
+	if providerClientKey("ab", "c") == providerClientKey("a", "bc") {
+		t.Error("providerClientKey() is the same for different parts")
+	}
+	if strings.Contains(a, "token-a") {
+		t.Error("providerClientKey() contains the token")
+	}
+}
+
+func TestCatalogKey(t *testing.T) {
+	client := func(token string) *gcorecloud.ServiceClient {
+		return &gcorecloud.ServiceClient{
+			ProviderClient: &gcorecloud.ProviderClient{APIToken: token},
+			Endpoint:       "https://api.gcore.com/cloud/v1/images/1/2/",
+		}
+	}
+	a := catalogKey(client("token-a"), "?visibility=public")
+	if a != catalogKey(client("token-a"), "?visibility=public") {
+		t.Error("catalogKey() differs for the same request")
+	}
+	if a == catalogKey(client("token-b"), "?visibility=public") || a == catalogKey(client("token-a"), "") {
+		t.Error("catalogKey() is the same for different requests")
+	}
+	if strings.Contains(a, "token-a") {
+		t.Error("catalogKey() contains the token")
+	}
+}
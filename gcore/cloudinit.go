@@ -0,0 +1,138 @@
+package gcore
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"regexp"
+	"strings"
+)
+
+// sshPublicKeyRegexp matches the SSH public keys in the authorized_keys format, the trailing newline of file() is allowed
+var sshPublicKeyRegexp = regexp.MustCompile(`^(ssh-(rsa|dss|ed25519)|ecdsa-sha2-nistp(256|384|521)|sk-(ssh-ed25519|ecdsa-sha2-nistp256)@openssh\.com) [A-Za-z0-9+/]+=*( [^\n]*)?\n?$`)
+
+var cloudInitContentTypes = []string{
+	"text/cloud-boothook",
+	"text/cloud-config",
+	"text/cloud-config-archive",
+	"text/jinja2",
+	"text/part-handler",
+	"text/upstart-job",
+	"text/x-include-once-url",
+	"text/x-include-url",
+	"text/x-shellscript",
+}
+
+type cloudInitPart struct {
+	ContentType string
+	Content     string
+	Filename    string
+	MergeType   string
+}
+
+// buildCloudInitMultipart renders the parts as a MIME multi-part user data encoded with base64.
+// The boundary is derived from the parts checksum, so the same parts always render the same user data
+// and the plan changes only when a part changes.
+func buildCloudInitMultipart(parts []cloudInitPart) (string, error) {
+	h := sha256.New()
+	for _, p := range parts {
+		fmt.Fprintf(h, "%s\x00%s\x00%s\x00%s\x00", p.ContentType, p.Filename, p.MergeType, p.Content)
+	}
+	boundary := "MIMEBOUNDARY-" + hex.EncodeToString(h.Sum(nil))[:32]
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	if err := w.SetBoundary(boundary); err != nil {
+		return "", err
+	}
+	for i, p := range parts {
+		filename := p.Filename
+		if filename == "" {
+			filename = fmt.Sprintf("part-%03d", i+1)
+		}
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", fmt.Sprintf("%s; charset=\"utf-8\"", p.ContentType))
+		header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		header.Set("MIME-Version", "1.0")
+		if p.MergeType != "" {
+			header.Set("X-Merge-Type", p.MergeType)
+		}
+		pw, err := w.CreatePart(header)
+		if err != nil {
+			return "", fmt.Errorf("part %d: %w", i, err)
+		}
+		if _, err := pw.Write([]byte(p.Content)); err != nil {
+			return "", fmt.Errorf("part %d: %w", i, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	var userData bytes.Buffer
+	fmt.Fprintf(&userData, "Content-Type: multipart/mixed; boundary=%q\r\nMIME-Version: 1.0\r\n\r\n", boundary)
+	userData.Write(body.Bytes())
+	return base64.StdEncoding.EncodeToString(userData.Bytes()), nil
+}
+
+// cloudInitSSHKeysMergeType appends the keys to the ssh_authorized_keys of the other cloud-config parts
+const cloudInitSSHKeysMergeType = "list(append)+dict(recurse_array)+str()"
+
+// cloudInitTypePrefixes maps the first line of the user data to its content type like cloud-init does,
+// the longer prefixes go first.
+var cloudInitTypePrefixes = []struct {
+	prefix      string
+	contentType string
+}{
+	{"#cloud-config-archive", "text/cloud-config-archive"},
+	{"#cloud-config", "text/cloud-config"},
+	{"#cloud-boothook", "text/cloud-boothook"},
+	{"#include-once", "text/x-include-once-url"},
+	{"#include", "text/x-include-url"},
+	{"#part-handler", "text/part-handler"},
+	{"#upstart-job", "text/upstart-job"},
+	{"## template: jinja", "text/jinja2"},
+	{"#!", "text/x-shellscript"},
+}
+
+// cloudInitSSHKeysPart returns the cloud-config part that authorizes the SSH keys for the default user.
+func cloudInitSSHKeysPart(keys []string) cloudInitPart {
+	var content strings.Builder
+	content.WriteString("#cloud-config\nssh_authorized_keys:\n")
+	for _, key := range keys {
+		// the double quoted scalars of YAML accept the Go quoted strings of the ASCII keys
+		fmt.Fprintf(&content, "  - %q\n", strings.TrimSpace(key))
+	}
+	return cloudInitPart{
+		ContentType: "text/cloud-config",
+		Content:     content.String(),
+		Filename:    "ssh-authorized-keys.cfg",
+		MergeType:   cloudInitSSHKeysMergeType,
+	}
+}
+
+// cloudInitWithSSHKeys returns the base64 user data that runs the user data or the parts and authorizes the SSH keys.
+// The user data may be encoded with base64 or not, the MIME multi-part user data isn't combined.
+func cloudInitWithSSHKeys(userData string, parts []cloudInitPart, keys []string) (string, error) {
+	if userData == "" {
+		return buildCloudInitMultipart(append(append([]cloudInitPart{}, parts...), cloudInitSSHKeysPart(keys)))
+	}
+
+	content, err := base64.StdEncoding.DecodeString(userData)
+	if err != nil {
+		content = []byte(userData)
+	}
+	for _, t := range cloudInitTypePrefixes {
+		if strings.HasPrefix(string(content), t.prefix) {
+			return buildCloudInitMultipart([]cloudInitPart{
+				{ContentType: t.contentType, Content: string(content)},
+				cloudInitSSHKeysPart(keys),
+			})
+		}
+	}
+	return "", fmt.Errorf("unknown type of the user data, use user_data_part to combine it with the SSH keys")
+}
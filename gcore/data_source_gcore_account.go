@@ -0,0 +1,213 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type accountServiceStatus struct {
+	Enabled bool   `json:"enabled"`
+	Status  string `json:"status"`
+}
+
+type accountFeature struct {
+	Name string `json:"name"`
+}
+
+type account struct {
+	ID              int                             `json:"id"`
+	Reseller        int                             `json:"reseller"`
+	Name            string                          `json:"name"`
+	CompanyName     string                          `json:"companyName"`
+	Email           string                          `json:"email"`
+	Status          string                          `json:"status"`
+	ServiceStatuses map[string]accountServiceStatus `json:"serviceStatuses"`
+	PaidFeatures    map[string][]accountFeature     `json:"paidFeatures"`
+	FreeFeatures    map[string][]accountFeature     `json:"freeFeatures"`
+}
+
+func dataSourceAccount() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAccountRead,
+		Description: "Represent the account of the provider credentials: the client and reseller IDs, the products and the features enabled for it, " +
+			"e.g. to create the resources of the enabled products only instead of failing on apply.",
+		Schema: map[string]*schema.Schema{
+			"client_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the client (account).",
+			},
+			"reseller_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the reseller the client belongs to.",
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"company_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"email": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the account, e.g. active or trial.",
+			},
+			"services": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Products of the account and their statuses, sorted by name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the product, e.g. CDN, CLOUD, DNS, STORAGE, STREAMING.",
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Status of the product, e.g. new, trial, active, paused.",
+						},
+					},
+				},
+			},
+			"enabled_services": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Names of the enabled products, sorted.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"features": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Features available to the account, sorted by product and name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"paid": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAccountRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start Account reading")
+	config := m.(*Config)
+
+	acc, err := getAccount(config)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.Itoa(acc.ID))
+	d.Set("client_id", acc.ID)
+	d.Set("reseller_id", acc.Reseller)
+	d.Set("name", acc.Name)
+	d.Set("company_name", acc.CompanyName)
+	d.Set("email", acc.Email)
+	d.Set("status", acc.Status)
+
+	services, enabled := flattenAccountServices(acc.ServiceStatuses)
+	if err := d.Set("services", services); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("enabled_services", enabled); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("features", flattenAccountFeatures(acc.PaidFeatures, acc.FreeFeatures)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Finish Account reading (%d)", acc.ID)
+	return nil
+}
+
+// getAccount reads the account of the provider credentials from the platform API.
+func getAccount(config *Config) (*account, error) {
+	url := strings.TrimRight(config.PlatformAPI, "/") + "/clients/me"
+	var acc account
+	_, err := config.Provider.Request("GET", url, &gcorecloud.RequestOpts{JSONResponse: &acc, OkCodes: []int{200}})
+	if err != nil {
+		return nil, fmt.Errorf("get account: %w", err)
+	}
+	return &acc, nil
+}
+
+func flattenAccountServices(statuses map[string]accountServiceStatus) ([]map[string]interface{}, []string) {
+	names := make([]string, 0, len(statuses))
+	for name := range statuses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	services := make([]map[string]interface{}, len(names))
+	enabled := make([]string, 0, len(names))
+	for i, name := range names {
+		status := statuses[name]
+		services[i] = map[string]interface{}{
+			"name":    name,
+			"enabled": status.Enabled,
+			"status":  status.Status,
+		}
+		if status.Enabled {
+			enabled = append(enabled, name)
+		}
+	}
+	return services, enabled
+}
+
+func flattenAccountFeatures(paid, free map[string][]accountFeature) []map[string]interface{} {
+	var features []map[string]interface{}
+	for _, group := range []struct {
+		features map[string][]accountFeature
+		paid     bool
+	}{{paid, true}, {free, false}} {
+		for service, list := range group.features {
+			for _, feature := range list {
+				features = append(features, map[string]interface{}{
+					"service": service,
+					"name":    feature.Name,
+					"paid":    group.paid,
+				})
+			}
+		}
+	}
+	sort.Slice(features, func(i, j int) bool {
+		if features[i]["service"] != features[j]["service"] {
+			return features[i]["service"].(string) < features[j]["service"].(string)
+		}
+		return features[i]["name"].(string) < features[j]["name"].(string)
+	})
+	return features
+}
@@ -0,0 +1,55 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+)
+
+func TestGetAccount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/iam/clients/me" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":42,"reseller":7,"name":"Jane","companyName":"Example","status":"active",
+			"serviceStatuses":{"CDN":{"enabled":true,"status":"active"},"STORAGE":{"enabled":false,"status":"new"},"CLOUD":{"enabled":true,"status":"trial"}},
+			"paidFeatures":{"CDN":[{"feature_id":1,"name":"Image Stack"}]},
+			"freeFeatures":{"CDN":[{"free_feature_id":2,"name":"Certificates"}],"CLOUD":[{"name":"Preemptible"}]}}`)
+	}))
+	defer server.Close()
+
+	config := &Config{Provider: &gcorecloud.ProviderClient{}, PlatformAPI: server.URL + "/iam/"}
+	acc, err := getAccount(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acc.ID != 42 || acc.Reseller != 7 || acc.CompanyName != "Example" {
+		t.Errorf("getAccount() = %+v", acc)
+	}
+
+	services, enabled := flattenAccountServices(acc.ServiceStatuses)
+	if !reflect.DeepEqual(enabled, []string{"CDN", "CLOUD"}) {
+		t.Errorf("enabled services = %v, want [CDN CLOUD]", enabled)
+	}
+	if len(services) != 3 || services[2]["name"] != "STORAGE" || services[2]["status"] != "new" {
+		t.Errorf("services = %v", services)
+	}
+
+	features := flattenAccountFeatures(acc.PaidFeatures, acc.FreeFeatures)
+	want := []map[string]interface{}{
+		{"service": "CDN", "name": "Certificates", "paid": false},
+		{"service": "CDN", "name": "Image Stack", "paid": true},
+		{"service": "CLOUD", "name": "Preemptible", "paid": false},
+	}
+	if !reflect.DeepEqual(features, want) {
+		t.Errorf("features = %v, want %v", features, want)
+	}
+}
@@ -0,0 +1,65 @@
+package gcore
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceAPIRequest() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAPIRequestRead,
+		Description: "Send a GET request to the Gcore API for the features the typed data sources don't cover yet. The request is sent on every read.",
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:         schema.TypeString,
+				Description:  "Path of the request relative to the `api_endpoint` of the provider, with the query string if any, e.g. `/cloud/v1/projects`.",
+				Required:     true,
+				ValidateFunc: apiRequestPathValidate,
+			},
+			"expected_status": {
+				Type:        schema.TypeSet,
+				Description: "HTTP status codes of the successful response, any 2xx code by default.",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(100, 599),
+				},
+			},
+			"status_code": {
+				Type:        schema.TypeInt,
+				Description: "HTTP status code of the response.",
+				Computed:    true,
+			},
+			"response_body": {
+				Type:        schema.TypeString,
+				Description: "Body of the response, use `jsondecode` to access its fields. It is sensitive as the responses may contain secrets, use `nonsensitive` for the fields that are safe to show.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func dataSourceAPIRequestRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start API request reading")
+	config := m.(*Config)
+
+	path := d.Get("path").(string)
+	status, response, err := sendAPIRequest(config, http.MethodGet, path, "", apiRequestExpectedStatus(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(path)))
+	d.Set("status_code", status)
+	d.Set("response_body", response)
+
+	log.Println("[DEBUG] Finish API request reading")
+	return nil
+}
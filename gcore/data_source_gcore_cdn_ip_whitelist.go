@@ -0,0 +1,69 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	gcdncore "github.com/G-Core/gcorelabscdn-go/gcore"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const cdnPublicIPListPath = "/cdn/public-ip-list"
+
+type cdnPublicIPList struct {
+	Addresses   []string `json:"addresses"`
+	AddressesV6 []string `json:"addresses_v6"`
+}
+
+func dataCDNIPWhitelist() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataCDNIPWhitelistRead,
+		Description: "Represent IP ranges of the CDN edge servers, use them to allow only the CDN traffic on the origin",
+		Schema: map[string]*schema.Schema{
+			"addresses": {
+				Type:        schema.TypeList,
+				Description: "IPv4 networks of the CDN edge servers in CIDR notation.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"addresses_v6": {
+				Type:        schema.TypeList,
+				Description: "IPv6 networks of the CDN edge servers in CIDR notation.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataCDNIPWhitelistRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start reading CDN IP whitelist")
+
+	config := m.(*Config)
+	result, err := getCDNPublicIPList(ctx, config.CDNRequester)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	all := append(append([]string{}, result.Addresses...), result.AddressesV6...)
+	d.SetId(strconv.Itoa(schema.HashString(strings.Join(all, ","))))
+	d.Set("addresses", result.Addresses)
+	d.Set("addresses_v6", result.AddressesV6)
+
+	log.Println("[DEBUG] Finish reading CDN IP whitelist")
+	return nil
+}
+
+// getCDNPublicIPList requests the CDN edge IP ranges, the CDN SDK has no method for this endpoint.
+func getCDNPublicIPList(ctx context.Context, r gcdncore.Requester) (*cdnPublicIPList, error) {
+	var result cdnPublicIPList
+	if err := r.Request(ctx, http.MethodGet, cdnPublicIPListPath, nil, &result); err != nil {
+		return nil, fmt.Errorf("request: %w", err)
+	}
+	return &result, nil
+}
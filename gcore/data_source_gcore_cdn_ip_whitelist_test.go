@@ -0,0 +1,38 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	gcdnProvider "github.com/G-Core/gcorelabscdn-go/gcore/provider"
+)
+
+func TestGetCDNPublicIPList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != cdnPublicIPListPath {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"addresses":["5.188.7.0/24","92.223.84.0/24"],"addresses_v6":["2a03:90c0::/32"]}`)
+	}))
+	defer server.Close()
+
+	got, err := getCDNPublicIPList(context.Background(), gcdnProvider.NewClient(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &cdnPublicIPList{
+		Addresses:   []string{"5.188.7.0/24", "92.223.84.0/24"},
+		AddressesV6: []string{"2a03:90c0::/32"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("getCDNPublicIPList() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,222 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+
+	gcdncore "github.com/G-Core/gcorelabscdn-go/gcore"
+	"github.com/G-Core/gcorelabscdn-go/origingroups"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// cdnOriginGroupConsumer is the CDN resource that uses the origin group itself or in its rules.
+type cdnOriginGroupConsumer struct {
+	ID       int64
+	Cname    string
+	Resource bool
+	RuleIDs  []int
+}
+
+type cdnResourceOriginGroup struct {
+	ID          int64  `json:"id"`
+	Cname       string `json:"cname"`
+	OriginGroup int64  `json:"originGroup"`
+}
+
+func dataCDNOriginGroup() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataCDNOriginGroupRead,
+		Description: "Represent the origin group with the CDN resources that use it, e.g. to check that a shared origin group has no consumers before removing it.",
+		Schema: map[string]*schema.Schema{
+			"origin_group_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "ID of the origin group.",
+				ExactlyOneOf: []string{"origin_group_id", "name"},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Name of the origin group, it must match one group.",
+				ExactlyOneOf: []string{"origin_group_id", "name"},
+			},
+			"include_rules": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Find the rules that use the origin group too, it takes one request per CDN resource.",
+			},
+			"use_next": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"origin": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"backup": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"proxy_next_upstream": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"resources": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "CDN resources that use the origin group, ordered by ID.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"cname": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"origin_group": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "The origin group is the main origin group of the resource.",
+						},
+						"rule_ids": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Rules of the resource that use the origin group.",
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+						},
+					},
+				},
+			},
+			"in_use": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "The origin group is used by a CDN resource or a rule.",
+			},
+		},
+	}
+}
+
+func dataCDNOriginGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start CDN OriginGroup data reading")
+	config := m.(*Config)
+
+	var group *origingroups.OriginGroup
+	var err error
+	if id, ok := d.GetOk("origin_group_id"); ok {
+		err = config.CDNRequester.Request(ctx, http.MethodGet, fmt.Sprintf("/cdn/origin_groups/%d", id.(int)), nil, &group)
+	} else {
+		group, err = findCDNOriginGroup(ctx, config.CDNRequester, d.Get("name").(string))
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	consumers, err := listCDNOriginGroupConsumers(ctx, config.CDNRequester, group.ID, d.Get("include_rules").(bool))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	resources := make([]interface{}, len(consumers))
+	for i, c := range consumers {
+		resources[i] = map[string]interface{}{
+			"id":           int(c.ID),
+			"cname":        c.Cname,
+			"origin_group": c.Resource,
+			"rule_ids":     c.RuleIDs,
+		}
+	}
+
+	d.SetId(strconv.FormatInt(group.ID, 10))
+	d.Set("origin_group_id", int(group.ID))
+	d.Set("name", group.Name)
+	d.Set("use_next", group.UseNext)
+	if err := d.Set("origin", originsToSet(group.Sources)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("proxy_next_upstream", group.ProxyNextUpstream)
+	if err := d.Set("resources", resources); err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("in_use", len(consumers) > 0)
+
+	log.Printf("[DEBUG] Finish CDN OriginGroup data reading (id=%d, %d consumers)", group.ID, len(consumers))
+	return nil
+}
+
+// findCDNOriginGroup returns the origin group with the name, the name must match one group.
+func findCDNOriginGroup(ctx context.Context, r gcdncore.Requester, name string) (*origingroups.OriginGroup, error) {
+	var list []origingroups.OriginGroup
+	if err := r.Request(ctx, http.MethodGet, "/cdn/origin_groups", nil, &list); err != nil {
+		return nil, fmt.Errorf("request: %w", err)
+	}
+	var found []origingroups.OriginGroup
+	for _, group := range list {
+		if group.Name == name {
+			found = append(found, group)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("origin group %s not found", name)
+	case 1:
+		return &found[0], nil
+	}
+	return nil, fmt.Errorf("%d origin groups are named %s, use origin_group_id", len(found), name)
+}
+
+// listCDNOriginGroupConsumers returns the CDN resources that use the origin group, ordered by ID.
+// The rules are checked only with includeRules, they are requested for every resource.
+func listCDNOriginGroupConsumers(ctx context.Context, r gcdncore.Requester, groupID int64, includeRules bool) ([]cdnOriginGroupConsumer, error) {
+	var list []cdnResourceOriginGroup
+	if err := r.Request(ctx, http.MethodGet, "/cdn/resources", nil, &list); err != nil {
+		return nil, fmt.Errorf("request: %w", err)
+	}
+
+	var consumers []cdnOriginGroupConsumer
+	for _, resource := range list {
+		consumer := cdnOriginGroupConsumer{
+			ID:       resource.ID,
+			Cname:    resource.Cname,
+			Resource: resource.OriginGroup == groupID,
+			RuleIDs:  []int{},
+		}
+		if includeRules {
+			rules, err := listCDNRules(ctx, r, int(resource.ID))
+			if err != nil {
+				return nil, fmt.Errorf("rules of resource %d: %w", resource.ID, err)
+			}
+			for _, rule := range rules {
+				if rule.OriginGroup != nil && int64(*rule.OriginGroup) == groupID {
+					consumer.RuleIDs = append(consumer.RuleIDs, int(rule.ID))
+				}
+			}
+		}
+		if consumer.Resource || len(consumer.RuleIDs) > 0 {
+			consumers = append(consumers, consumer)
+		}
+	}
+	sort.Slice(consumers, func(i, j int) bool { return consumers[i].ID < consumers[j].ID })
+	return consumers, nil
+}
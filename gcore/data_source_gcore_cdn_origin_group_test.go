@@ -0,0 +1,60 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gcdnProvider "github.com/G-Core/gcorelabscdn-go/gcore/provider"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataCDNOriginGroup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/cdn/origin_groups":
+			fmt.Fprint(w, `[{"id":10,"name":"other","use_next":false,"sources":[]},
+				{"id":11,"name":"shared","use_next":true,"sources":[{"source":"origin.example.com","enabled":true,"backup":false}],"proxy_next_upstream":["error"]}]`)
+		case "/cdn/resources":
+			fmt.Fprint(w, `[{"id":3,"cname":"b.example.com","originGroup":10},{"id":1,"cname":"a.example.com","originGroup":11},{"id":2,"cname":"c.example.com","originGroup":10}]`)
+		case "/cdn/resources/1/rules":
+			fmt.Fprint(w, `[]`)
+		case "/cdn/resources/2/rules":
+			fmt.Fprint(w, `[{"id":5,"name":"api","rule":"/api/.*","weight":1,"originGroup":11},{"id":6,"name":"gone","rule":"/old/.*","originGroup":11,"deleted":true}]`)
+		case "/cdn/resources/3/rules":
+			fmt.Fprint(w, `[{"id":7,"name":"img","rule":"/img/.*","originGroup":10}]`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	config := &Config{CDNRequester: gcdnProvider.NewClient(server.URL)}
+
+	d := schema.TestResourceDataRaw(t, dataCDNOriginGroup().Schema, map[string]interface{}{"name": "shared"})
+	if diags := dataCDNOriginGroupRead(context.Background(), d, config); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if d.Id() != "11" || d.Get("origin_group_id") != 11 || !d.Get("use_next").(bool) || d.Get("origin.#") != 1 {
+		t.Errorf("origin group = %v, want the shared group", d.State())
+	}
+	if got := d.Get("resources.#").(int); got != 2 {
+		t.Fatalf("resources.# = %d, want the resource and the rule consumers", got)
+	}
+	if d.Get("resources.0.id") != 1 || !d.Get("resources.0.origin_group").(bool) || d.Get("resources.0.rule_ids.#") != 0 {
+		t.Errorf("resources.0 = %v, want the resource using the group", d.Get("resources.0"))
+	}
+	if d.Get("resources.1.id") != 2 || d.Get("resources.1.origin_group").(bool) || d.Get("resources.1.rule_ids.0") != 5 || d.Get("resources.1.rule_ids.#") != 1 {
+		t.Errorf("resources.1 = %v, want the resource with the rule using the group", d.Get("resources.1"))
+	}
+	if !d.Get("in_use").(bool) {
+		t.Error("in_use = false")
+	}
+}
@@ -0,0 +1,149 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	gcdncore "github.com/G-Core/gcorelabscdn-go/gcore"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const cdnAggregateStatsPath = "/cdn/statistics/aggregate/stats"
+
+// cdnResourceStatsMetrics are the requested metrics, the attributes of the data source have the same names.
+var cdnResourceStatsMetrics = []string{
+	"upstream_bytes",
+	"shield_bytes",
+	"sent_bytes",
+	"total_bytes",
+	"requests",
+	"cache_hit_traffic_ratio",
+	"cache_hit_requests_ratio",
+}
+
+type cdnAggregateStats struct {
+	Metrics map[string]float64 `json:"metrics"`
+}
+
+func dataCDNResourceStats() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataCDNResourceStatsRead,
+		Description: "Represent aggregated statistics of the CDN resource for the period, the origin pull traffic and the cache hit ratio",
+		Schema: map[string]*schema.Schema{
+			"resource_id": {
+				Type:        schema.TypeInt,
+				Description: "ID of the CDN resource.",
+				Required:    true,
+			},
+			"from": {
+				Type:         schema.TypeString,
+				Description:  "Beginning of the period in RFC 3339 format.",
+				Required:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"to": {
+				Type:         schema.TypeString,
+				Description:  "End of the period in RFC 3339 format, the current time by default.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"upstream_bytes": {
+				Type:        schema.TypeInt,
+				Description: "Traffic in bytes pulled from the origin.",
+				Computed:    true,
+			},
+			"shield_bytes": {
+				Type:        schema.TypeInt,
+				Description: "Traffic in bytes pulled from the origin shielding.",
+				Computed:    true,
+			},
+			"sent_bytes": {
+				Type:        schema.TypeInt,
+				Description: "Traffic in bytes sent to the clients.",
+				Computed:    true,
+			},
+			"total_bytes": {
+				Type:        schema.TypeInt,
+				Description: "Sum of the sent, shield and upstream traffic in bytes.",
+				Computed:    true,
+			},
+			"requests": {
+				Type:        schema.TypeInt,
+				Description: "Number of the client requests.",
+				Computed:    true,
+			},
+			"cache_hit_traffic_ratio": {
+				Type:        schema.TypeFloat,
+				Description: "Share of the traffic served from the cache, from 0 to 1.",
+				Computed:    true,
+			},
+			"cache_hit_requests_ratio": {
+				Type:        schema.TypeFloat,
+				Description: "Share of the requests served from the cache, from 0 to 1.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataCDNResourceStatsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	resourceID := d.Get("resource_id").(int)
+	log.Printf("[DEBUG] Start reading CDN resource statistics (resource_id=%d)", resourceID)
+
+	from, _ := time.Parse(time.RFC3339, d.Get("from").(string))
+	to := time.Now().UTC().Truncate(time.Second)
+	if v, ok := d.GetOk("to"); ok {
+		to, _ = time.Parse(time.RFC3339, v.(string))
+	}
+	if !from.Before(to) {
+		return diag.Errorf("from %s must be before to %s", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+
+	config := m.(*Config)
+	stats, err := getCDNResourceStats(ctx, config.CDNRequester, resourceID, from, to)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[DEBUG] CDN resource statistics received: %v", stats.Metrics)
+
+	d.SetId(fmt.Sprintf("%d:%d:%d", resourceID, from.Unix(), to.Unix()))
+	d.Set("to", to.Format(time.RFC3339))
+	for _, metric := range cdnResourceStatsMetrics {
+		value := stats.Metrics[metric]
+		if strings.HasSuffix(metric, "_ratio") {
+			d.Set(metric, value)
+			continue
+		}
+		d.Set(metric, int(value))
+	}
+
+	log.Println("[DEBUG] Finish reading CDN resource statistics")
+	return nil
+}
+
+// getCDNResourceStats requests the metrics of the resource aggregated over the period,
+// the CDN SDK has no method for the statistics API.
+func getCDNResourceStats(ctx context.Context, r gcdncore.Requester, resourceID int, from, to time.Time) (*cdnAggregateStats, error) {
+	query := url.Values{
+		"service":  {"CDN"},
+		"resource": {strconv.Itoa(resourceID)},
+		"from":     {from.UTC().Format(time.RFC3339)},
+		"to":       {to.UTC().Format(time.RFC3339)},
+		"metrics":  {strings.Join(cdnResourceStatsMetrics, ",")},
+	}
+
+	var result cdnAggregateStats
+	if err := r.Request(ctx, http.MethodGet, cdnAggregateStatsPath+"?"+query.Encode(), nil, &result); err != nil {
+		return nil, fmt.Errorf("request: %w", err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,39 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gcdnProvider "github.com/G-Core/gcorelabscdn-go/gcore/provider"
+)
+
+func TestGetCDNResourceStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != cdnAggregateStatsPath || q.Get("resource") != "42" || q.Get("service") != "CDN" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		if q.Get("from") != "2024-01-01T00:00:00Z" || q.Get("to") != "2024-01-02T00:00:00Z" {
+			t.Errorf("unexpected period %s - %s", q.Get("from"), q.Get("to"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"metrics":{"upstream_bytes":1024,"sent_bytes":8192,"cache_hit_traffic_ratio":0.875}}`)
+	}))
+	defer server.Close()
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	got, err := getCDNResourceStats(context.Background(), gcdnProvider.NewClient(server.URL), 42, from, from.Add(24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Metrics["upstream_bytes"] != 1024 || got.Metrics["sent_bytes"] != 8192 || got.Metrics["cache_hit_traffic_ratio"] != 0.875 {
+		t.Errorf("getCDNResourceStats() = %v", got.Metrics)
+	}
+}
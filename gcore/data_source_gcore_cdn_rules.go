@@ -0,0 +1,145 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+
+	gcdncore "github.com/G-Core/gcorelabscdn-go/gcore"
+	"github.com/G-Core/gcorelabscdn-go/rules"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataCDNRules() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataCDNRulesRead,
+		Description: "Represent all rules of the CDN resource with their options, e.g. to convert the rules configured in the console into gcore_cdn_rule resources.",
+		Schema: map[string]*schema.Schema{
+			"resource_id": {
+				Type:        schema.TypeInt,
+				Description: "ID of the CDN resource.",
+				Required:    true,
+			},
+			"rules": {
+				Type:        schema.TypeList,
+				Description: "Rules of the resource ordered by weight, the attributes match the ones of gcore_cdn_rule.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"import_id": {
+							Type:        schema.TypeString,
+							Description: "ID to import the rule into gcore_cdn_rule with, in the resource_id:rule_id format.",
+							Computed:    true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"active": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"rule": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"rule_type": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"origin_group": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"origin_protocol": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"weight": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"options": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     ruleOptionsSchema.Elem,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataCDNRulesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	resourceID := d.Get("resource_id").(int)
+	log.Printf("[DEBUG] Start CDN Rules reading (resource_id=%d)", resourceID)
+	config := m.(*Config)
+
+	list, err := listCDNRules(ctx, config.CDNRequester, resourceID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	result := make([]interface{}, 0, len(list))
+	for _, rule := range list {
+		r := map[string]interface{}{
+			"id":              int(rule.ID),
+			"import_id":       fmt.Sprintf("%d:%d", resourceID, rule.ID),
+			"name":            rule.Name,
+			"active":          rule.Active,
+			"rule":            rule.Pattern,
+			"rule_type":       rule.Type,
+			"origin_group":    0,
+			"origin_protocol": "",
+			"weight":          rule.Weight,
+		}
+		if rule.Options != nil {
+			r["options"] = optionsToList(rule.Options)
+		}
+		if rule.OriginGroup != nil {
+			r["origin_group"] = *rule.OriginGroup
+		}
+		if rule.OverrideOriginProtocol != nil {
+			r["origin_protocol"] = *rule.OverrideOriginProtocol
+		}
+		result = append(result, r)
+	}
+	if err := d.Set("rules", result); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(strconv.Itoa(resourceID))
+
+	log.Printf("[DEBUG] Finish CDN Rules reading (%d rules)", len(result))
+	return nil
+}
+
+// listCDNRules returns the rules of the resource ordered by weight and ID, the deleted rules are skipped.
+func listCDNRules(ctx context.Context, r gcdncore.Requester, resourceID int) ([]rules.Rule, error) {
+	var list []rules.Rule
+	if err := r.Request(ctx, http.MethodGet, fmt.Sprintf("/cdn/resources/%d/rules", resourceID), nil, &list); err != nil {
+		return nil, fmt.Errorf("request: %w", err)
+	}
+
+	result := make([]rules.Rule, 0, len(list))
+	for _, rule := range list {
+		if !rule.Deleted {
+			result = append(result, rule)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Weight != result[j].Weight {
+			return result[i].Weight < result[j].Weight
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result, nil
+}
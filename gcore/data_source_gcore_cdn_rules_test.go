@@ -0,0 +1,47 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gcdnProvider "github.com/G-Core/gcorelabscdn-go/gcore/provider"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataCDNRules(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/cdn/resources/42/rules" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[
+			{"id":3,"name":"images","active":true,"rule":"/images/.*","ruleType":0,"weight":2,
+			 "overrideOriginProtocol":"HTTPS","options":{"edge_cache_settings":{"enabled":true,"value":"1d"}}},
+			{"id":7,"name":"gone","active":true,"rule":"/old/.*","ruleType":0,"weight":0,"deleted":true},
+			{"id":5,"name":"api","active":false,"rule":"/api/.*","ruleType":0,"weight":1,"originGroup":11}
+		]`)
+	}))
+	defer server.Close()
+	config := &Config{CDNRequester: gcdnProvider.NewClient(server.URL)}
+
+	d := schema.TestResourceDataRaw(t, dataCDNRules().Schema, map[string]interface{}{"resource_id": 42})
+	if diags := dataCDNRulesRead(context.Background(), d, config); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if got := d.Get("rules.#").(int); got != 2 {
+		t.Fatalf("rules.# = %d, want the deleted rule skipped", got)
+	}
+	if d.Get("rules.0.name") != "api" || d.Get("rules.0.origin_group") != 11 || d.Get("rules.0.import_id") != "42:5" {
+		t.Errorf("rules.0 = %v, want the api rule first by weight", d.Get("rules.0"))
+	}
+	if d.Get("rules.1.origin_protocol") != "HTTPS" || d.Get("rules.1.options.0.edge_cache_settings.0.value") != "1d" {
+		t.Errorf("rules.1 = %v, want the images rule with its options", d.Get("rules.1"))
+	}
+}
@@ -0,0 +1,258 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	gc "github.com/G-Core/gcorelabscloud-go/gcore"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const costReportPoint = "cost_report"
+
+// costReportGroups are the attributes of the rows of the totals report, the report sums the costs up by the region
+// and the resource type, the projects are a filter only.
+var costReportGroups = []string{"region", "type"}
+
+type costReportOpts struct {
+	TimeFrom string   `json:"time_from"`
+	TimeTo   string   `json:"time_to"`
+	Projects []int    `json:"projects,omitempty"`
+	Regions  []int    `json:"regions,omitempty"`
+	Types    []string `json:"types,omitempty"`
+}
+
+type costReportRow struct {
+	RegionID     int     `json:"region_id"`
+	Type         string  `json:"type"`
+	BillingValue float64 `json:"billing_value"`
+	Currency     string  `json:"currency"`
+}
+
+type costReportTotals struct {
+	Count   int             `json:"count"`
+	Results []costReportRow `json:"results"`
+}
+
+func dataSourceCostReport() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCostReportRead,
+		Description: "Represent the cloud cost report for the period, the costs are summed up by the group_by attributes",
+		Schema: map[string]*schema.Schema{
+			"time_from": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Beginning of the period in RFC 3339 format.",
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"time_to": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "End of the period in RFC 3339 format.",
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"project_ids": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Take into account only the costs of these projects. The report doesn't split the costs by project, use a data source per project to compare the projects.",
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"region_ids": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Take into account only the costs of these regions.",
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"types": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Take into account only the costs of these resource types, e.g. instance, volume, floatingip.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"group_by": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Description: fmt.Sprintf("Attributes the rows are grouped by, available values are '%s'. "+
+					"All costs are summed up into one row if it is empty.", strings.Join(costReportGroups, "', '")),
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(costReportGroups, false),
+				},
+			},
+			"total": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Sum of all costs of the report.",
+			},
+			"currency": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Currency of the total cost.",
+			},
+			"rows": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Costs grouped by the group_by attributes, the attributes that are not grouped by are empty.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"region_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cost": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"currency": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCostReportRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start CostReport reading")
+	config := m.(*Config)
+	provider := config.Provider
+
+	from, _ := time.Parse(time.RFC3339, d.Get("time_from").(string))
+	to, _ := time.Parse(time.RFC3339, d.Get("time_to").(string))
+	if !from.Before(to) {
+		return diag.Errorf("time_from %s must be before time_to %s", d.Get("time_from"), d.Get("time_to"))
+	}
+
+	opts := costReportOpts{
+		TimeFrom: from.UTC().Format(time.RFC3339),
+		TimeTo:   to.UTC().Format(time.RFC3339),
+	}
+	for _, v := range d.Get("project_ids").([]interface{}) {
+		opts.Projects = append(opts.Projects, v.(int))
+	}
+	for _, v := range d.Get("region_ids").([]interface{}) {
+		opts.Regions = append(opts.Regions, v.(int))
+	}
+	for _, v := range d.Get("types").([]interface{}) {
+		opts.Types = append(opts.Types, v.(string))
+	}
+	var groupBy []string
+	for _, v := range d.Get("group_by").([]interface{}) {
+		groupBy = append(groupBy, v.(string))
+	}
+
+	client, err := gc.ClientServiceFromProvider(provider, gcorecloud.EndpointOpts{
+		Name:    costReportPoint,
+		Region:  0,
+		Project: 0,
+		Version: versionPointV1,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	totals, err := getCostReportTotals(client, opts)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[DEBUG] CostReport received %d rows", len(totals.Results))
+
+	rows := groupCostReportRows(totals.Results, groupBy)
+	var total float64
+	currencies := make(map[string]bool)
+	result := make([]map[string]interface{}, len(rows))
+	for i, row := range rows {
+		total += row.BillingValue
+		currencies[row.Currency] = true
+		result[i] = map[string]interface{}{
+			"region_id": row.RegionID,
+			"type":      row.Type,
+			"cost":      row.BillingValue,
+			"currency":  row.Currency,
+		}
+	}
+	if len(currencies) > 1 {
+		return diag.Errorf("the costs are in different currencies, the total can't be calculated")
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(fmt.Sprintf("%+v%v", opts, groupBy))))
+	d.Set("total", total)
+	d.Set("currency", "")
+	for currency := range currencies {
+		d.Set("currency", currency)
+	}
+	if err := d.Set("rows", result); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Println("[DEBUG] Finish CostReport reading")
+	return nil
+}
+
+// getCostReportTotals requests the costs grouped by the region and the resource type,
+// gcorelabscloud-go has no client for the cost report API.
+func getCostReportTotals(client *gcorecloud.ServiceClient, opts costReportOpts) (*costReportTotals, error) {
+	var result costReportTotals
+	_, err := client.Post(client.ServiceURL("totals"), opts, &result, &gcorecloud.RequestOpts{
+		OkCodes: []int{http.StatusOK},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get cost report: %w", err)
+	}
+	return &result, nil
+}
+
+// groupCostReportRows sums up the rows with the same values of the grouped by attributes,
+// the other attributes are reset. Rows in different currencies are never summed up.
+func groupCostReportRows(rows []costReportRow, groupBy []string) []costReportRow {
+	by := make(map[string]bool, len(groupBy))
+	for _, g := range groupBy {
+		by[g] = true
+	}
+
+	index := make(map[costReportRow]int)
+	var result []costReportRow
+	for _, row := range rows {
+		key := costReportRow{Currency: row.Currency}
+		if by["region"] {
+			key.RegionID = row.RegionID
+		}
+		if by["type"] {
+			key.Type = row.Type
+		}
+		i, ok := index[key]
+		if !ok {
+			i = len(result)
+			index[key] = i
+			result = append(result, key)
+		}
+		result[i].BillingValue += row.BillingValue
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.RegionID != b.RegionID {
+			return a.RegionID < b.RegionID
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Currency < b.Currency
+	})
+	return result
+}
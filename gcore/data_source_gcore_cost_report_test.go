@@ -0,0 +1,92 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	gc "github.com/G-Core/gcorelabscloud-go/gcore"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestGetCostReportTotals(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/cost_report/totals" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		var opts costReportOpts
+		if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+			t.Error(err)
+		}
+		if opts.TimeFrom != "2024-01-01T00:00:00Z" || !reflect.DeepEqual(opts.Projects, []int{1}) {
+			t.Errorf("unexpected options %+v", opts)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"count":1,"results":[{"region_id":76,"type":"instance","billing_value":12.5,"currency":"USD"}]}`)
+	}))
+	defer server.Close()
+
+	client, err := gc.ClientServiceFromProvider(&gcorecloud.ProviderClient{APIBase: server.URL + "/"}, gcorecloud.EndpointOpts{
+		Name:    costReportPoint,
+		Version: versionPointV1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := getCostReportTotals(client, costReportOpts{TimeFrom: "2024-01-01T00:00:00Z", TimeTo: "2024-02-01T00:00:00Z", Projects: []int{1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []costReportRow{{RegionID: 76, Type: "instance", BillingValue: 12.5, Currency: "USD"}}
+	if !reflect.DeepEqual(got.Results, want) {
+		t.Errorf("getCostReportTotals() = %+v, want %+v", got.Results, want)
+	}
+}
+
+func TestGroupCostReportRows(t *testing.T) {
+	rows := []costReportRow{
+		{RegionID: 76, Type: "instance", BillingValue: 10, Currency: "USD"},
+		{RegionID: 76, Type: "volume", BillingValue: 2, Currency: "USD"},
+		{RegionID: 8, Type: "instance", BillingValue: 5, Currency: "USD"},
+	}
+	tests := []struct {
+		name    string
+		groupBy []string
+		want    []costReportRow
+	}{
+		{"total", nil, []costReportRow{{BillingValue: 17, Currency: "USD"}}},
+		{"type", []string{"type"}, []costReportRow{
+			{Type: "instance", BillingValue: 15, Currency: "USD"},
+			{Type: "volume", BillingValue: 2, Currency: "USD"},
+		}},
+		{"region and type", []string{"region", "type"}, []costReportRow{
+			{RegionID: 8, Type: "instance", BillingValue: 5, Currency: "USD"},
+			{RegionID: 76, Type: "instance", BillingValue: 10, Currency: "USD"},
+			{RegionID: 76, Type: "volume", BillingValue: 2, Currency: "USD"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := groupCostReportRows(rows, tt.groupBy); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("groupCostReportRows() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCostReportGroupByValidation(t *testing.T) {
+	validate := dataSourceCostReport().Schema["group_by"].Elem.(*schema.Schema).ValidateFunc
+	if _, errs := validate("project", "group_by"); len(errs) == 0 {
+		t.Error("group_by project is accepted, the totals report has no project")
+	}
+	if _, errs := validate("region", "group_by"); len(errs) != 0 {
+		t.Errorf("group_by region is rejected: %v", errs)
+	}
+}
@@ -0,0 +1,220 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const dnsDelegationLookupTimeout = 10 * time.Second
+
+// defaultDNSDelegationResolvers are the public resolvers asked for the zone NS records if none are configured.
+var defaultDNSDelegationResolvers = []string{"8.8.8.8", "1.1.1.1"}
+
+func dataSourceDNSZoneDelegation() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: checkDNSDependency(dataSourceDNSZoneDelegationRead),
+		Description: "Checks that the NS records of the zone seen by public resolvers match the name servers assigned to the zone, " +
+			"use it in a check block to find out the broken delegation of the zone.",
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateDomain,
+				Description:      "A name of the DNS zone.",
+			},
+			"resolvers": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Addresses of the resolvers to query, the port is 53 if it is omitted. By default the Google and Cloudflare public resolvers are used.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"nameservers": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Name servers assigned to the zone.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"delegated": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "True if every resolver returns exactly the assigned name servers.",
+			},
+			"results": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "NS records of the zone returned by every resolver.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resolver": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"nameservers": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"delegated": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"error": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Lookup error, the zone is not delegated if the resolver can't find its NS records.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDNSZoneDelegationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zone := normalizeDNSName(d.Get("zone").(string))
+	log.Printf("[DEBUG] Start DNS Zone delegation checking (zone=%s)", zone)
+	defer log.Println("[DEBUG] Finish DNS Zone delegation checking")
+
+	config := m.(*Config)
+	assigned, err := getDNSZoneNameservers(ctx, config, zone)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("get zone nameservers: %w", err))
+	}
+	if len(assigned) == 0 {
+		return diag.Errorf("zone %s has no assigned nameservers", zone)
+	}
+
+	resolvers := defaultDNSDelegationResolvers
+	if v, ok := d.GetOk("resolvers"); ok {
+		resolvers = nil
+		for _, r := range v.([]interface{}) {
+			resolvers = append(resolvers, r.(string))
+		}
+	}
+
+	delegated := true
+	results := make([]map[string]interface{}, 0, len(resolvers))
+	for _, resolver := range resolvers {
+		result := map[string]interface{}{"resolver": resolver}
+		nameservers, err := lookupDNSNameservers(ctx, resolver, zone)
+		if err != nil {
+			log.Printf("[DEBUG] NS lookup of %s via %s failed: %s", zone, resolver, err)
+			result["error"] = err.Error()
+		}
+		result["nameservers"] = nameservers
+		result["delegated"] = err == nil && equalNameservers(assigned, nameservers)
+		delegated = delegated && result["delegated"].(bool)
+		results = append(results, result)
+	}
+
+	d.SetId(zone)
+	d.Set("nameservers", assigned)
+	d.Set("delegated", delegated)
+	if err := d.Set("results", results); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// getDNSZoneNameservers returns the sorted NS records of the zone apex including the system ones,
+// the DNS SDK returns the NS records of the delegated subdomains too.
+func getDNSZoneNameservers(ctx context.Context, config *Config, zone string) ([]string, error) {
+	zone = normalizeDNSName(zone)
+	query := url.Values{"all": {"true"}, "type": {"NS"}}
+
+	var res struct {
+		RRSets []struct {
+			Name    string `json:"name"`
+			Records []struct {
+				Content []interface{} `json:"content"`
+			} `json:"resource_records"`
+		} `json:"rrsets"`
+	}
+	uri := path.Join("/v2/zones", zone, "rrsets") + "?" + query.Encode()
+	if err := dnsRequest(ctx, config, http.MethodGet, uri, nil, &res); err != nil {
+		return nil, err
+	}
+
+	var nameservers []string
+	for _, rrset := range res.RRSets {
+		if normalizeDNSName(rrset.Name) != zone {
+			continue
+		}
+		for _, record := range rrset.Records {
+			for _, content := range record.Content {
+				nameservers = append(nameservers, fmt.Sprint(content))
+			}
+		}
+	}
+	return normalizeNameservers(nameservers), nil
+}
+
+// lookupDNSNameservers asks the resolver for the NS records of the zone.
+func lookupDNSNameservers(ctx context.Context, resolver, zone string) ([]string, error) {
+	address := resolver
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, "53")
+	}
+	r := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, address)
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dnsDelegationLookupTimeout)
+	defer cancel()
+	records, err := r.LookupNS(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+	nameservers := make([]string, len(records))
+	for i, ns := range records {
+		nameservers[i] = ns.Host
+	}
+	return normalizeNameservers(nameservers), nil
+}
+
+// normalizeNameservers returns the sorted unique host names without the trailing dot.
+func normalizeNameservers(nameservers []string) []string {
+	seen := make(map[string]bool, len(nameservers))
+	result := make([]string, 0, len(nameservers))
+	for _, ns := range nameservers {
+		ns = normalizeDNSName(ns)
+		if ns == "" || seen[ns] {
+			continue
+		}
+		seen[ns] = true
+		result = append(result, ns)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func equalNameservers(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeDNSName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(name), "."))
+}
@@ -0,0 +1,55 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	dnssdk "github.com/G-Core/gcore-dns-sdk-go"
+)
+
+func TestGetDNSZoneNameservers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/zones/example.com/rrsets" || r.URL.Query().Get("type") != "NS" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"rrsets":[`+
+			`{"name":"example.com","type":"NS","resource_records":[{"content":["NS2.gcdn.services."]},{"content":["ns1.gcorelabs.net"]}]},`+
+			`{"name":"sub.example.com","type":"NS","resource_records":[{"content":["ns.other.net"]}]}]}`)
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	config := &Config{
+		DNSClient: dnssdk.NewClient(dnssdk.PermanentAPIKeyAuth("token"), func(client *dnssdk.Client) {
+			client.BaseURL = baseURL
+		}),
+	}
+
+	got, err := getDNSZoneNameservers(context.Background(), config, "Example.com.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"ns1.gcorelabs.net", "ns2.gcdn.services"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("getDNSZoneNameservers() = %v, want %v", got, want)
+	}
+}
+
+func TestNormalizeNameservers(t *testing.T) {
+	got := normalizeNameservers([]string{"ns2.gcdn.services.", "NS1.gcorelabs.net.", "ns2.gcdn.services", " "})
+	want := []string{"ns1.gcorelabs.net", "ns2.gcdn.services"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("normalizeNameservers() = %v, want %v", got, want)
+	}
+	if !equalNameservers(want, got) || equalNameservers(want, got[:1]) {
+		t.Errorf("equalNameservers() mismatch")
+	}
+}
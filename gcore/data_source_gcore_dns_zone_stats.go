@@ -0,0 +1,181 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// dnsZoneStats are the requests to the zone, the requests are keyed by the unix time of the interval beginning.
+type dnsZoneStats struct {
+	Requests map[string]int `json:"requests"`
+	Total    int            `json:"total"`
+}
+
+func dataSourceDNSZoneStats() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: checkDNSDependency(dataSourceDNSZoneStatsRead),
+		Description: "Represent the DNS requests to the zone for the period by intervals, e.g. to alert on the traffic anomalies of the zone.",
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateDomain,
+				Description:      "A name of the DNS zone.",
+			},
+			"from": {
+				Type:         schema.TypeString,
+				Description:  "Beginning of the period in RFC 3339 format.",
+				Required:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"to": {
+				Type:         schema.TypeString,
+				Description:  "End of the period in RFC 3339 format, the current time by default.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"granularity": {
+				Type:        schema.TypeString,
+				Description: "Length of the intervals the requests are counted by, e.g. `5m`, `1h` or `24h`. The API picks it by the period if it is not set.",
+				Optional:    true,
+				ValidateDiagFunc: func(val interface{}, key cty.Path) diag.Diagnostics {
+					if d, err := time.ParseDuration(val.(string)); err != nil || d <= 0 {
+						return diag.Errorf("granularity must be a positive duration, e.g. 1h, got: %s", val)
+					}
+					return nil
+				},
+			},
+			"record_type": {
+				Type:         schema.TypeString,
+				Description:  "Count the requests of the records of the type only, e.g. `A` or `MX`. All requests are counted if it is not set.",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(dnsRecordTypes, true),
+			},
+			"total": {
+				Type:        schema.TypeInt,
+				Description: "Number of the requests for the period.",
+				Computed:    true,
+			},
+			"peak": {
+				Type:        schema.TypeInt,
+				Description: "Largest number of the requests in an interval.",
+				Computed:    true,
+			},
+			"requests": {
+				Type:        schema.TypeList,
+				Description: "Number of the requests by intervals, ordered by time.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"time": {
+							Type:        schema.TypeString,
+							Description: "Beginning of the interval in RFC 3339 format.",
+							Computed:    true,
+						},
+						"count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDNSZoneStatsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	zone := normalizeDNSName(d.Get("zone").(string))
+	log.Printf("[DEBUG] Start reading DNS zone statistics (zone=%s)", zone)
+
+	from, _ := time.Parse(time.RFC3339, d.Get("from").(string))
+	to := time.Now().UTC().Truncate(time.Second)
+	if v, ok := d.GetOk("to"); ok {
+		to, _ = time.Parse(time.RFC3339, v.(string))
+	}
+	if !from.Before(to) {
+		return diag.Errorf("from %s must be before to %s", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+
+	config := m.(*Config)
+	granularity, recordType := d.Get("granularity").(string), d.Get("record_type").(string)
+	stats, err := getDNSZoneStats(ctx, config, zone, from, to, granularity, recordType)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	requests, peak, err := flattenDNSZoneStats(stats)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%d:%d:%s:%s", zone, from.Unix(), to.Unix(), granularity, recordType))
+	d.Set("to", to.Format(time.RFC3339))
+	d.Set("total", stats.Total)
+	d.Set("peak", peak)
+	if err := d.Set("requests", requests); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Finish reading DNS zone statistics (%d requests)", stats.Total)
+	return nil
+}
+
+// getDNSZoneStats requests the statistics of the zone, the DNS SDK has no method for the statistics API.
+func getDNSZoneStats(ctx context.Context, config *Config, zone string, from, to time.Time, granularity, recordType string) (*dnsZoneStats, error) {
+	query := url.Values{
+		"from": {strconv.FormatInt(from.Unix(), 10)},
+		"to":   {strconv.FormatInt(to.Unix(), 10)},
+	}
+	if granularity != "" {
+		query.Set("granularity", granularity)
+	}
+	if recordType != "" {
+		query.Set("record_type", recordType)
+	}
+
+	var stats dnsZoneStats
+	uri := path.Join("/v2/zones", zone, "statistics") + "?" + query.Encode()
+	if err := dnsRequest(ctx, config, http.MethodGet, uri, nil, &stats); err != nil {
+		return nil, fmt.Errorf("get statistics of zone %s: %w", zone, err)
+	}
+	return &stats, nil
+}
+
+// flattenDNSZoneStats returns the intervals ordered by time and the largest number of the requests in an interval.
+func flattenDNSZoneStats(stats *dnsZoneStats) ([]map[string]interface{}, int, error) {
+	times := make([]int64, 0, len(stats.Requests))
+	for key := range stats.Requests {
+		t, err := strconv.ParseInt(key, 10, 64)
+		if err != nil {
+			return nil, 0, fmt.Errorf("parse the interval %q: %w", key, err)
+		}
+		times = append(times, t)
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+
+	var peak int
+	requests := make([]map[string]interface{}, len(times))
+	for i, t := range times {
+		count := stats.Requests[strconv.FormatInt(t, 10)]
+		if count > peak {
+			peak = count
+		}
+		requests[i] = map[string]interface{}{
+			"time":  time.Unix(t, 0).UTC().Format(time.RFC3339),
+			"count": count,
+		}
+	}
+	return requests, peak, nil
+}
@@ -0,0 +1,63 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	dnssdk "github.com/G-Core/gcore-dns-sdk-go"
+)
+
+func TestGetDNSZoneStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/v2/zones/example.com/statistics" || query.Get("from") != "1700000000" || query.Get("to") != "1700007200" ||
+			query.Get("granularity") != "1h" || query.Get("record_type") != "A" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"requests":{"1700003600":12,"1700000000":30},"total":42}`)
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	config := &Config{
+		DNSClient: dnssdk.NewClient(dnssdk.PermanentAPIKeyAuth("token"), func(client *dnssdk.Client) {
+			client.BaseURL = baseURL
+		}),
+	}
+
+	stats, err := getDNSZoneStats(context.Background(), config, "example.com", time.Unix(1700000000, 0), time.Unix(1700007200, 0), "1h", "A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Total != 42 {
+		t.Errorf("total = %d, want 42", stats.Total)
+	}
+
+	requests, peak, err := flattenDNSZoneStats(stats)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []map[string]interface{}{
+		{"time": "2023-11-14T22:13:20Z", "count": 30},
+		{"time": "2023-11-14T23:13:20Z", "count": 12},
+	}
+	if !reflect.DeepEqual(requests, want) || peak != 30 {
+		t.Errorf("flattenDNSZoneStats() = %v, %d, want %v, 30", requests, peak, want)
+	}
+}
+
+func TestFlattenDNSZoneStatsBadInterval(t *testing.T) {
+	if _, _, err := flattenDNSZoneStats(&dnsZoneStats{Requests: map[string]int{"yesterday": 1}}); err == nil {
+		t.Error("expected an error for the interval that is not a unix time")
+	}
+}
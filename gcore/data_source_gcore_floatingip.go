@@ -98,8 +98,24 @@ func dataSourceFloatingIP() *schema.Resource {
 				Computed: true,
 			},
 			"port_id": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ID of the port the floating IP is attached to.",
+			},
+			"subnet_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the subnet of the fixed IP the floating IP is attached to.",
+			},
+			"instance_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the instance the floating IP is attached to, empty when it is not attached to an instance.",
+			},
+			"instance_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the instance the floating IP is attached to.",
 			},
 			"metadata_k": &schema.Schema{
 				Type:     schema.TypeString,
@@ -147,10 +163,11 @@ func dataSourceFloatingIPRead(ctx context.Context, d *schema.ResourceData, m int
 		return diag.FromErr(err)
 	}
 
-	ipAddr := d.Get("floating_ip_address").(string)
+	ipAddr, byFloatingIP := d.Get("floating_ip_address").(string), true
 	if ipAddr == "" {
-		ipAddr = d.Get("fixed_ip_address").(string)
+		ipAddr, byFloatingIP = d.Get("fixed_ip_address").(string), false
 	}
+	addr := net.ParseIP(ipAddr)
 
 	metaOpts := &floatingips.ListOpts{}
 
@@ -174,13 +191,7 @@ func dataSourceFloatingIPRead(ctx context.Context, d *schema.ResourceData, m int
 	var found bool
 	var floatingIP floatingips.FloatingIPDetail
 	for _, ip := range ips {
-		if ip.FloatingIPAddress.String() == ipAddr {
-			floatingIP = ip
-			found = true
-			break
-		}
-
-		if ip.FixedIPAddress.String() == ipAddr {
+		if byFloatingIP && ip.FloatingIPAddress.Equal(addr) || !byFloatingIP && ip.FixedIPAddress.Equal(addr) {
 			floatingIP = ip
 			found = true
 			break
@@ -203,6 +214,9 @@ func dataSourceFloatingIPRead(ctx context.Context, d *schema.ResourceData, m int
 	d.Set("status", floatingIP.Status)
 	d.Set("port_id", floatingIP.PortID)
 	d.Set("router_id", floatingIP.RouterID)
+	d.Set("subnet_id", floatingIP.SubnetID)
+	d.Set("instance_id", floatingIP.Instance.ID)
+	d.Set("instance_name", floatingIP.Instance.Name)
 	d.Set("floating_ip_address", floatingIP.FloatingIPAddress.String())
 
 	metadataReadOnly := make([]map[string]interface{}, 0, len(floatingIP.Metadata))
@@ -151,10 +151,17 @@ func dataSourceImageRead(ctx context.Context, d *schema.ResourceData, m interfac
 		listOpts.MetadataKV = typedMetadataKV
 	}
 
-	allImages, err := images.ListAll(client, *listOpts)
+	query, err := listOpts.ToImageListQuery()
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	cached, err := catalog.get(catalogKey(client, query), func() (interface{}, error) {
+		return images.ListAll(client, *listOpts)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	allImages := cached.([]images.Image)
 
 	var found bool
 	var image images.Image
@@ -2,18 +2,20 @@ package gcore
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"log"
 
 	"github.com/G-Core/gcorelabscloud-go/gcore/k8s/v2/clusters"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v3"
 )
 
 func dataSourceK8sV2KubeConfig() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceK8sV2KubeConfigRead,
-		Description: "Represent k8s cluster's kubeconfig.",
+		Description: "Represent k8s cluster's kubeconfig. Breaking change: `kubeconfig` is sensitive, the outputs referencing it must be marked `sensitive`.",
 		Schema: map[string]*schema.Schema{
 			"project_id": {
 				Type:     schema.TypeInt,
@@ -58,6 +60,34 @@ func dataSourceK8sV2KubeConfig() *schema.Resource {
 				Type:        schema.TypeString,
 				Description: "Raw kubeconfig file",
 				Computed:    true,
+				Sensitive:   true,
+			},
+			"host": {
+				Type:        schema.TypeString,
+				Description: "Kubernetes API server URL of the current context.",
+				Computed:    true,
+			},
+			"cluster_ca_certificate": {
+				Type:        schema.TypeString,
+				Description: "PEM encoded CA certificate of the Kubernetes API server.",
+				Computed:    true,
+			},
+			"client_certificate": {
+				Type:        schema.TypeString,
+				Description: "PEM encoded client certificate of the current context user.",
+				Computed:    true,
+			},
+			"client_key": {
+				Type:        schema.TypeString,
+				Description: "PEM encoded client key of the current context user.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Description: "Bearer token of the current context user, if the kubeconfig uses a token.",
+				Computed:    true,
+				Sensitive:   true,
 			},
 		},
 	}
@@ -81,10 +111,109 @@ func dataSourceK8sV2KubeConfigRead(ctx context.Context, d *schema.ResourceData,
 	}
 
 	kubeconfig, err := clusters.GetConfig(client, clusterName).Extract()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("cant get cluster config: %s", err.Error()))
+	}
+
+	kc, err := parseKubeConfig(kubeconfig.Config)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	d.SetId(cluster.Name)
 	d.Set("kubeconfig", kubeconfig.Config)
+	d.Set("host", kc.Host)
+	d.Set("cluster_ca_certificate", kc.ClusterCACertificate)
+	d.Set("client_certificate", kc.ClientCertificate)
+	d.Set("client_key", kc.ClientKey)
+	d.Set("token", kc.Token)
 
 	log.Println("[DEBUG] Finish K8s kubeconfig reading")
 	return diags
 }
+
+// kubeConfigCredentials is the connection of the current context of a kubeconfig,
+// it can be passed to the kubernetes and helm providers.
+type kubeConfigCredentials struct {
+	Host                 string
+	ClusterCACertificate string
+	ClientCertificate    string
+	ClientKey            string
+	Token                string
+}
+
+type kubeConfigFile struct {
+	CurrentContext string `yaml:"current-context"`
+	Clusters       []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Contexts []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster string `yaml:"cluster"`
+			User    string `yaml:"user"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+	Users []struct {
+		Name string `yaml:"name"`
+		User struct {
+			ClientCertificateData string `yaml:"client-certificate-data"`
+			ClientKeyData         string `yaml:"client-key-data"`
+			Token                 string `yaml:"token"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+}
+
+// parseKubeConfig returns the credentials of the current context, or of the first one when it is not set.
+func parseKubeConfig(raw string) (*kubeConfigCredentials, error) {
+	var kc kubeConfigFile
+	if err := yaml.Unmarshal([]byte(raw), &kc); err != nil {
+		return nil, fmt.Errorf("parse kubeconfig: %w", err)
+	}
+	if len(kc.Contexts) == 0 {
+		return nil, fmt.Errorf("parse kubeconfig: no contexts")
+	}
+
+	ctx := kc.Contexts[0].Context
+	for _, c := range kc.Contexts {
+		if c.Name == kc.CurrentContext {
+			ctx = c.Context
+			break
+		}
+	}
+
+	var creds kubeConfigCredentials
+	var err error
+	for _, c := range kc.Clusters {
+		if c.Name == ctx.Cluster {
+			creds.Host = c.Cluster.Server
+			if creds.ClusterCACertificate, err = decodeKubeConfigData(c.Cluster.CertificateAuthorityData); err != nil {
+				return nil, fmt.Errorf("parse kubeconfig: cluster %s: %w", c.Name, err)
+			}
+		}
+	}
+	for _, u := range kc.Users {
+		if u.Name == ctx.User {
+			creds.Token = u.User.Token
+			if creds.ClientCertificate, err = decodeKubeConfigData(u.User.ClientCertificateData); err != nil {
+				return nil, fmt.Errorf("parse kubeconfig: user %s: %w", u.Name, err)
+			}
+			if creds.ClientKey, err = decodeKubeConfigData(u.User.ClientKeyData); err != nil {
+				return nil, fmt.Errorf("parse kubeconfig: user %s: %w", u.Name, err)
+			}
+		}
+	}
+	return &creds, nil
+}
+
+func decodeKubeConfigData(data string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return "", err
+	}
+	return string(decoded), nil
+}
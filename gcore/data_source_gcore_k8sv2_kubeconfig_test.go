@@ -0,0 +1,61 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+)
+
+func TestParseKubeConfig(t *testing.T) {
+	b64 := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+	raw := fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: other
+  cluster:
+    server: https://10.0.0.1:6443
+- name: cluster1
+  cluster:
+    server: https://k8s.example.com:6443
+    certificate-authority-data: %s
+contexts:
+- name: other@other
+  context:
+    cluster: other
+    user: other
+- name: admin@cluster1
+  context:
+    cluster: cluster1
+    user: admin
+current-context: admin@cluster1
+users:
+- name: other
+  user:
+    token: other-token
+- name: admin
+  user:
+    client-certificate-data: %s
+    client-key-data: %s
+`, b64("CA"), b64("CERT"), b64("KEY"))
+
+	creds, err := parseKubeConfig(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := kubeConfigCredentials{
+		Host:                 "https://k8s.example.com:6443",
+		ClusterCACertificate: "CA",
+		ClientCertificate:    "CERT",
+		ClientKey:            "KEY",
+	}
+	if *creds != want {
+		t.Errorf("parseKubeConfig() = %+v, want %+v", *creds, want)
+	}
+
+	if _, err := parseKubeConfig("clusters: []"); err == nil {
+		t.Error("parseKubeConfig() of a kubeconfig without contexts should fail")
+	}
+}
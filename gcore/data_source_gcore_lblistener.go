@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/lbpools"
 	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/listeners"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -52,9 +53,11 @@ func dataSourceLBListener() *schema.Resource {
 				},
 			},
 			"name": &schema.Schema{
-				Type:        schema.TypeString,
-				Description: "Name of the load balancer listener.",
-				Required:    true,
+				Type:         schema.TypeString,
+				Description:  "Name of the load balancer listener.",
+				Optional:     true,
+				Computed:     true,
+				AtLeastOneOf: []string{"name", "protocol_port"},
 			},
 			"loadbalancer_id": &schema.Schema{
 				Type:        schema.TypeString,
@@ -68,9 +71,18 @@ func dataSourceLBListener() *schema.Resource {
 				Description: "Available values are 'HTTP', 'HTTPS', 'TCP', 'UDP', 'TERMINATED_HTTPS', 'PROMETHEUS'",
 			},
 			"protocol_port": &schema.Schema{
-				Type:        schema.TypeInt,
-				Description: "Port number to listen, between 1 and 65535.",
+				Type:         schema.TypeInt,
+				Description:  "Port number to listen, between 1 and 65535. Can be used to find the listener of the load balancer instead of the name.",
+				Optional:     true,
+				Computed:     true,
+				AtLeastOneOf: []string{"name", "protocol_port"},
+				RequiredWith: []string{"loadbalancer_id"},
+			},
+			"pool_ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Description: "IDs of the pools attached to this listener.",
 				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
 			"pool_count": &schema.Schema{
 				Type:        schema.TypeInt,
@@ -171,20 +183,42 @@ func dataSourceLBListenerRead(ctx context.Context, d *schema.ResourceData, m int
 		return diag.FromErr(err)
 	}
 
+	port := d.Get("protocol_port").(int)
+
 	var found bool
 	var lb listeners.Listener
 	for _, l := range ls {
-		if l.Name == name {
-			lb = l
-			found = true
-			break
+		if name != "" && l.Name != name {
+			continue
+		}
+		if port != 0 && l.ProtocolPort != port {
+			continue
 		}
+		lb = l
+		found = true
+		break
 	}
 
 	if !found {
+		if name == "" {
+			return diag.Errorf("lb listener with port %d not found", port)
+		}
 		return diag.Errorf("lb listener with name %s not found", name)
 	}
 
+	poolsClient, err := CreateClient(provider, d, LBPoolsPoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	pools, err := listLBPools(poolsClient, lbpools.ListOpts{ListenerID: &lb.ID})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	poolIDs := make([]string, len(pools))
+	for i, p := range pools {
+		poolIDs[i] = p.ID
+	}
+
 	userList := make([]map[string]string, len(lb.UserList))
 	for i, userData := range lb.UserList {
 		u := map[string]string{"username": userData.Username, "encrypted_password": userData.EncryptedPassword}
@@ -210,6 +244,7 @@ func dataSourceLBListenerRead(ctx context.Context, d *schema.ResourceData, m int
 	d.Set("timeout_member_data", lb.TimeoutMemberData)
 	d.Set("connection_limit", lb.ConnectionLimit)
 	d.Set("user_list", userList)
+	d.Set("pool_ids", poolIDs)
 
 	log.Println("[DEBUG] Finish LBListener reading")
 	return diags
@@ -73,6 +73,22 @@ func TestAccLBListenerDataSource(t *testing.T) {
 					resource.TestCheckResourceAttr(fullName, "id", listener.ID),
 				),
 			},
+			{
+				Config: fmt.Sprintf(`
+			data "gcore_lblistener" "acctest" {
+			  %s
+              %s
+              loadbalancer_id = "%s"
+              protocol_port   = 80
+			}
+		`, projectInfo(), regionInfo(), lbID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists(fullName),
+					resource.TestCheckResourceAttr(fullName, "name", lbListenerTestName),
+					resource.TestCheckResourceAttr(fullName, "id", listener.ID),
+					resource.TestCheckResourceAttr(fullName, "pool_ids.#", "0"),
+				),
+			},
 		},
 	})
 }
@@ -73,7 +73,7 @@ func dataSourceLBPool() *schema.Resource {
 			"lb_algorithm": &schema.Schema{
 				Type:        schema.TypeString,
 				Computed:    true,
-				Description: fmt.Sprintf("Available values is '%s', '%s', '%s'", types.LoadBalancerAlgorithmRoundRobin, types.LoadBalancerAlgorithmLeastConnections, types.LoadBalancerAlgorithmSourceIP),
+				Description: lbPoolAlgorithmsDescription,
 			},
 			"protocol": &schema.Schema{
 				Type:        schema.TypeString,
@@ -134,6 +134,85 @@ func dataSourceLBPool() *schema.Resource {
 					},
 				},
 			},
+			"provisioning_status": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Provisioning status of this pool.",
+				Computed:    true,
+			},
+			"operating_status": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Operating status of this pool.",
+				Computed:    true,
+			},
+			"timeout_client_data": &schema.Schema{
+				Type:        schema.TypeInt,
+				Description: "Frontend client inactivity timeout in milliseconds.",
+				Computed:    true,
+			},
+			"timeout_member_data": &schema.Schema{
+				Type:        schema.TypeInt,
+				Description: "Backend member inactivity timeout in milliseconds.",
+				Computed:    true,
+			},
+			"timeout_member_connect": &schema.Schema{
+				Type:        schema.TypeInt,
+				Description: "Backend member connection timeout in milliseconds.",
+				Computed:    true,
+			},
+			"members": &schema.Schema{
+				Type:        schema.TypeList,
+				Description: "Members of the pool.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "Member ID.",
+							Computed:    true,
+						},
+						"address": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "IP address to communicate with real server.",
+							Computed:    true,
+						},
+						"protocol_port": &schema.Schema{
+							Type:        schema.TypeInt,
+							Description: "Port on which real server listens.",
+							Computed:    true,
+						},
+						"weight": &schema.Schema{
+							Type:        schema.TypeInt,
+							Description: "Value between 0 and 256.",
+							Computed:    true,
+						},
+						"subnet_id": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "ID of the subnet in which real server placed.",
+							Computed:    true,
+						},
+						"instance_id": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "ID of the instance of the real server.",
+							Computed:    true,
+						},
+						"operating_status": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "Operating status of this member.",
+							Computed:    true,
+						},
+						"monitor_address": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "IP address used by the health monitor to check the member.",
+							Computed:    true,
+						},
+						"monitor_port": &schema.Schema{
+							Type:        schema.TypeInt,
+							Description: "Port used by the health monitor to check the member.",
+							Computed:    true,
+						},
+					},
+				},
+			},
 			"session_persistence": &schema.Schema{
 				Type:        schema.TypeList,
 				Description: "Pool session persistence tells the load balancer to attempt to send future requests from a client to the same backend member as the initial request.",
@@ -178,24 +257,25 @@ func dataSourceLBPoolRead(ctx context.Context, d *schema.ResourceData, m interfa
 		return diag.FromErr(err)
 	}
 
-	var opts lbpools.ListOpts
+	memberDetails := true
+	opts := lbpools.ListOpts{MemberDetails: &memberDetails}
 	name := d.Get("name").(string)
 	lbID := d.Get("loadbalancer_id").(string)
 	if lbID != "" {
 		opts.LoadBalancerID = &lbID
 	}
 	lID := d.Get("listener_id").(string)
-	if lbID != "" {
+	if lID != "" {
 		opts.ListenerID = &lID
 	}
 
-	pools, err := lbpools.ListAll(client, opts)
+	pools, err := listLBPools(client, opts)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	var found bool
-	var lb lbpools.Pool
+	var lb lbPool
 	for _, p := range pools {
 		if p.Name == name {
 			lb = p
@@ -205,13 +285,22 @@ func dataSourceLBPoolRead(ctx context.Context, d *schema.ResourceData, m interfa
 	}
 
 	if !found {
-		return diag.Errorf("lb listener with name %s not found", name)
+		return diag.Errorf("lb pool with name %s not found", name)
 	}
 
 	d.SetId(lb.ID)
 	d.Set("name", lb.Name)
-	d.Set("lb_algorithm", lb.LoadBalancerAlgorithm.String())
+	d.Set("lb_algorithm", lb.LoadBalancerAlgorithm)
 	d.Set("protocol", lb.Protocol.String())
+	d.Set("provisioning_status", lb.ProvisioningStatus.String())
+	d.Set("operating_status", lb.OperatingStatus.String())
+	d.Set("timeout_client_data", lb.TimeoutClientData)
+	d.Set("timeout_member_data", lb.TimeoutMemberData)
+	d.Set("timeout_member_connect", lb.TimeoutMemberConnect)
+
+	if err := d.Set("members", flattenLBPoolMembers(lb.Members)); err != nil {
+		return diag.FromErr(err)
+	}
 
 	if len(lb.LoadBalancers) > 0 {
 		d.Set("loadbalancer_id", lb.LoadBalancers[0].ID)
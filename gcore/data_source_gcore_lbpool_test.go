@@ -125,6 +125,7 @@ func TestAccLBPoolDataSource(t *testing.T) {
 					testAccCheckResourceExists(fullName),
 					resource.TestCheckResourceAttr(fullName, "name", poolTestName),
 					resource.TestCheckResourceAttr(fullName, "id", pool.ID),
+					resource.TestCheckResourceAttr(fullName, "members.#", fmt.Sprint(len(pool.Members))),
 				),
 			},
 		},
@@ -89,6 +89,16 @@ func dataSourceLoadBalancerV2() *schema.Resource {
 					},
 				},
 			},
+			"provisioning_status": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Provisioning status of the load balancer, ACTIVE when no changes are in progress.",
+				Computed:    true,
+			},
+			"operating_status": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Operating status of the load balancer, ONLINE when the load balancer and its members are healthy.",
+				Computed:    true,
+			},
 			"vip_ip_family": &schema.Schema{
 				Type:         schema.TypeString,
 				Description:  fmt.Sprintf("Available values are '%s', '%s', '%s'", types.IPv4IPFamilyType, types.IPv6IPFamilyType, types.DualStackIPFamilyType),
@@ -109,6 +119,14 @@ func dataSourceLoadBalancerV2() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"metadata_map": &schema.Schema{
+				Type:        schema.TypeMap,
+				Description: "User metadata of the load balancer, read-only system items are excluded.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"metadata_read_only": &schema.Schema{
 				Type:        schema.TypeList,
 				Description: "List of metadata items.",
@@ -200,6 +218,28 @@ func dataSourceLoadBalancerV2Read(ctx context.Context, d *schema.ResourceData, m
 	d.Set("vip_port_id", lb.VipPortID)
 	d.Set("vrrp_ips", vrrpIps)
 	d.Set("vip_ip_family", lb.VipIPFamilyType)
+	d.Set("provisioning_status", lb.ProvisioningStatus.String())
+	d.Set("operating_status", lb.OperationStatus.String())
+
+	metadataMap := make(map[string]string)
+	metadataReadOnly := make([]map[string]interface{}, 0, len(lb.Metadata))
+	for _, metadataItem := range lb.Metadata {
+		if !metadataItem.ReadOnly {
+			metadataMap[metadataItem.Key] = metadataItem.Value
+		}
+		metadataReadOnly = append(metadataReadOnly, map[string]interface{}{
+			"key":       metadataItem.Key,
+			"value":     metadataItem.Value,
+			"read_only": metadataItem.ReadOnly,
+		})
+	}
+
+	if err := d.Set("metadata_map", metadataMap); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("metadata_read_only", metadataReadOnly); err != nil {
+		return diag.FromErr(err)
+	}
 
 	log.Println("[DEBUG] Finish LoadBalancer reading")
 	return diags
@@ -6,6 +6,7 @@ import (
 
 	"github.com/G-Core/gcorelabscloud-go/gcore/network/v1/availablenetworks"
 	"github.com/G-Core/gcorelabscloud-go/gcore/network/v1/networks"
+	"github.com/G-Core/gcorelabscloud-go/gcore/subnet/v1/subnets"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -70,6 +71,40 @@ func dataSourceNetwork() *schema.Resource {
 				Type:     schema.TypeBool,
 				Computed: true,
 			},
+			"subnets": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Subnets of the network",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cidr": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"gateway_ip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Gateway IP of the subnet, 'disable' if the subnet has no gateway",
+						},
+						"enable_dhcp": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"ip_version": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
 			"metadata_k": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
@@ -144,6 +179,7 @@ func dataSourceNetworkRead(ctx context.Context, d *schema.ResourceData, m interf
 
 	// todo refactor, also refactor inner func
 	var rawNetwork map[string]interface{}
+	var networkSubnets []subnets.Subnet
 	network, found := findNetworkByName(name, nets)
 	if !found {
 		// trying to find among shared networks
@@ -160,11 +196,21 @@ func dataSourceNetworkRead(ctx context.Context, d *schema.ResourceData, m interf
 		if err != nil {
 			return diag.FromErr(err)
 		}
+		networkSubnets = network.Subnets
 	} else {
 		rawNetwork, err = StructToMap(network)
 		if err != nil {
 			return diag.FromErr(err)
 		}
+
+		clientSubnet, err := CreateClient(provider, d, subnetPoint, versionPointV1)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		networkSubnets, err = subnets.ListAll(clientSubnet, subnets.ListOpts{NetworkID: network.ID})
+		if err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	d.SetId(rawNetwork["id"].(string))
@@ -176,6 +222,10 @@ func dataSourceNetworkRead(ctx context.Context, d *schema.ResourceData, m interf
 	d.Set("external", rawNetwork["external"])
 	d.Set("shared", rawNetwork["shared"])
 
+	if err := d.Set("subnets", flattenNetworkSubnets(networkSubnets)); err != nil {
+		return diag.FromErr(err)
+	}
+
 	metadataReadOnly := make([]map[string]interface{}, 0, len(network.Metadata))
 	if len(network.Metadata) > 0 {
 		for _, metadataItem := range network.Metadata {
@@ -194,3 +244,22 @@ func dataSourceNetworkRead(ctx context.Context, d *schema.ResourceData, m interf
 	log.Println("[DEBUG] Finish Network reading")
 	return diags
 }
+
+func flattenNetworkSubnets(snets []subnets.Subnet) []map[string]interface{} {
+	result := make([]map[string]interface{}, len(snets))
+	for i, subnet := range snets {
+		gatewayIP := "disable"
+		if subnet.GatewayIP != nil {
+			gatewayIP = subnet.GatewayIP.String()
+		}
+		result[i] = map[string]interface{}{
+			"id":          subnet.ID,
+			"name":        subnet.Name,
+			"cidr":        subnet.CIDR.String(),
+			"gateway_ip":  gatewayIP,
+			"enable_dhcp": subnet.EnableDHCP,
+			"ip_version":  subnet.IPVersion,
+		}
+	}
+	return result
+}
@@ -86,6 +86,7 @@ func TestAccNetworkDataSource(t *testing.T) {
 					testAccCheckResourceExists(fullName),
 					resource.TestCheckResourceAttr(fullName, "name", opts1.Name),
 					resource.TestCheckResourceAttr(fullName, "id", network1ID),
+					resource.TestCheckResourceAttr(fullName, "subnets.#", "0"),
 					testAccCheckMetadata(fullName, true, map[string]string{
 						"key1": "val1", "key2": "val2",
 					}),
@@ -1,11 +1,14 @@
 package gcore
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"log"
 	"net"
+	"sort"
 
+	"github.com/AlekSi/pointer"
 	"github.com/G-Core/gcorelabscloud-go/gcore/reservedfixedip/v1/reservedfixedips"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -52,8 +55,11 @@ func dataSourceReservedFixedIP() *schema.Resource {
 				},
 			},
 			"fixed_ip_address": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Reserved fixed IP address. When omitted, the first available reserved fixed IP of the subnet is returned.",
+				AtLeastOneOf: []string{"fixed_ip_address", "subnet_id"},
 				ValidateDiagFunc: func(val interface{}, key cty.Path) diag.Diagnostics {
 					v := val.(string)
 					ip := net.ParseIP(v)
@@ -69,8 +75,12 @@ func dataSourceReservedFixedIP() *schema.Resource {
 				Computed: true,
 			},
 			"subnet_id": &schema.Schema{
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "Subnet to search the reserved fixed IP in. Available IPs are ordered by address, so the same IP is returned until it is assigned.",
+				AtLeastOneOf:     []string{"fixed_ip_address", "subnet_id"},
+				ValidateDiagFunc: validateUUID,
 			},
 			"network_id": &schema.Schema{
 				Type:     schema.TypeString,
@@ -85,6 +95,21 @@ func dataSourceReservedFixedIP() *schema.Resource {
 				Description: "ID of the port_id underlying the reserved fixed IP",
 				Computed:    true,
 			},
+			"device_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "ID of the resource the reserved fixed IP is attached to, e.g. the instance or the load balancer. Empty if the IP is not attached.",
+				Computed:    true,
+			},
+			"device_type": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Type of the resource the reserved fixed IP is attached to, e.g. 'instance' or 'loadbalancer'. Empty if the IP is not attached.",
+				Computed:    true,
+			},
+			"reservation_status": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Status of the reservation, e.g. 'available' or 'attached'.",
+				Computed:    true,
+			},
 			"allowed_address_pairs": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -117,15 +142,20 @@ func dataSourceReservedFixedIPRead(ctx context.Context, d *schema.ResourceData,
 	}
 
 	ipAddr := d.Get("fixed_ip_address").(string)
-	ips, err := reservedfixedips.ListAll(client, reservedfixedips.ListOpts{})
+	subnetID := d.Get("subnet_id").(string)
+	ips, err := reservedfixedips.ListAll(client, reservedfixedips.ListOpts{AvailableOnly: ipAddr == ""})
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	sortReservedFixedIPs(ips)
 
 	var found bool
 	var reservedFixedIP reservedfixedips.ReservedFixedIP
 	for _, ip := range ips {
-		if ip.FixedIPAddress.String() == ipAddr {
+		if subnetID != "" && ip.SubnetID != subnetID {
+			continue
+		}
+		if ipAddr == "" || ip.FixedIPAddress.String() == ipAddr {
 			reservedFixedIP = ip
 			found = true
 			break
@@ -133,6 +163,9 @@ func dataSourceReservedFixedIPRead(ctx context.Context, d *schema.ResourceData,
 	}
 
 	if !found {
+		if ipAddr == "" {
+			return diag.Errorf("available reserved fixed ip in subnet %s not found", subnetID)
+		}
 		return diag.Errorf("reserved fixed ip %s not found", ipAddr)
 	}
 
@@ -146,6 +179,9 @@ func dataSourceReservedFixedIPRead(ctx context.Context, d *schema.ResourceData,
 	d.Set("network_id", reservedFixedIP.NetworkID)
 	d.Set("is_vip", reservedFixedIP.IsVip)
 	d.Set("port_id", reservedFixedIP.PortID)
+	d.Set("device_id", pointer.GetString(reservedFixedIP.Reservation.ResourceID))
+	d.Set("device_type", pointer.GetString(reservedFixedIP.Reservation.ResourceType))
+	d.Set("reservation_status", reservedFixedIP.Reservation.Status)
 
 	allowedPairs := make([]map[string]interface{}, len(reservedFixedIP.AllowedAddressPairs))
 	for i, p := range reservedFixedIP.AllowedAddressPairs {
@@ -163,3 +199,13 @@ func dataSourceReservedFixedIPRead(ctx context.Context, d *schema.ResourceData,
 	log.Println("[DEBUG] Finish ReservedFixedIP reading")
 	return diags
 }
+
+// sortReservedFixedIPs orders reserved fixed IPs by address to make the lookup of an available IP deterministic.
+func sortReservedFixedIPs(ips []reservedfixedips.ReservedFixedIP) {
+	sort.SliceStable(ips, func(i, j int) bool {
+		if c := bytes.Compare(ips[i].FixedIPAddress.To16(), ips[j].FixedIPAddress.To16()); c != 0 {
+			return c < 0
+		}
+		return ips[i].PortID < ips[j].PortID
+	})
+}
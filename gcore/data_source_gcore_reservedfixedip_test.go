@@ -78,6 +78,20 @@ func TestAccReservedFixedIPDataSource(t *testing.T) {
 					resource.TestCheckResourceAttr(fullName, "fixed_ip_address", fip.FixedIPAddress.String()),
 				),
 			},
+			{
+				Config: fmt.Sprintf(`
+			data "gcore_reservedfixedip" "acctest" {
+			  %s
+              %s
+              subnet_id = "%s"
+			}
+		`, projectInfo(), regionInfo(), fip.SubnetID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists(fullName),
+					resource.TestCheckResourceAttr(fullName, "subnet_id", fip.SubnetID),
+					resource.TestCheckResourceAttrSet(fullName, "fixed_ip_address"),
+				),
+			},
 		},
 	})
 }
@@ -3,6 +3,7 @@ package gcore
 import (
 	"context"
 	"log"
+	"strings"
 
 	gcorecloud "github.com/G-Core/gcorelabscloud-go"
 	"github.com/G-Core/gcorelabscloud-go/gcore/secret/v1/secrets"
@@ -13,7 +14,7 @@ import (
 func dataSourceSecret() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceSecretRead,
-		Description: "Represent secret",
+		Description: "Represent secret. When several secrets match, e.g. the rotated certificates, the most recently created one is returned.",
 		Schema: map[string]*schema.Schema{
 			"project_id": &schema.Schema{
 				Type:     schema.TypeInt,
@@ -50,8 +51,17 @@ func dataSourceSecret() *schema.Resource {
 				},
 			},
 			"name": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Description:  "Name of the secret. Alternative for `name_prefix`.",
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "name_prefix"},
+			},
+			"name_prefix": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "Prefix of the secret name, e.g. of the names with the rotation date suffix. Alternative for `name`.",
+				Optional:     true,
+				ExactlyOneOf: []string{"name", "name_prefix"},
 			},
 			"algorithm": &schema.Schema{
 				Type:     schema.TypeString,
@@ -108,30 +118,46 @@ func dataSourceSecretRead(ctx context.Context, d *schema.ResourceData, m interfa
 		return diag.Errorf("cannot get secrets. Error: %s", err.Error())
 	}
 
-	var found bool
-	name := d.Get("name").(string)
-	for _, secret := range allSecrets {
-		if name == secret.Name {
-			d.SetId(secret.ID)
-			d.Set("name", name)
-			d.Set("algorithm", secret.Algorithm)
-			d.Set("bit_length", secret.BitLength)
-			d.Set("mode", secret.Mode)
-			d.Set("status", secret.Status)
-			d.Set("expiration", secret.Expiration.Format(gcorecloud.RFC3339ZColon))
-			d.Set("created", secret.CreatedAt.Format(gcorecloud.RFC3339ZColon))
-			if err := d.Set("content_types", secret.ContentTypes); err != nil {
-				return diag.FromErr(err)
-			}
-			found = true
-			break
+	name, prefix := d.Get("name").(string), d.Get("name_prefix").(string)
+	secret := latestSecret(allSecrets, func(s secrets.Secret) bool {
+		if prefix != "" {
+			return strings.HasPrefix(s.Name, prefix)
+		}
+		return s.Name == name
+	})
+	if secret == nil {
+		if prefix != "" {
+			return diag.Errorf("secret with name prefix %s does not exist", prefix)
 		}
+		return diag.Errorf("secret with name %s does not exit", name)
 	}
 
-	if !found {
-		return diag.Errorf("secret with name %s does not exit", name)
+	d.SetId(secret.ID)
+	d.Set("name", secret.Name)
+	d.Set("algorithm", secret.Algorithm)
+	d.Set("bit_length", secret.BitLength)
+	d.Set("mode", secret.Mode)
+	d.Set("status", secret.Status)
+	d.Set("expiration", secret.Expiration.Format(gcorecloud.RFC3339ZColon))
+	d.Set("created", secret.CreatedAt.Format(gcorecloud.RFC3339ZColon))
+	if err := d.Set("content_types", secret.ContentTypes); err != nil {
+		return diag.FromErr(err)
 	}
 
 	log.Println("[DEBUG] Finish secret reading")
 	return diags
 }
+
+// latestSecret returns the most recently created secret of the matching ones, nil if none matches.
+func latestSecret(list []secrets.Secret, match func(secrets.Secret) bool) *secrets.Secret {
+	var latest *secrets.Secret
+	for i := range list {
+		if !match(list[i]) {
+			continue
+		}
+		if latest == nil || list[i].CreatedAt.After(latest.CreatedAt.Time) {
+			latest = &list[i]
+		}
+	}
+	return latest
+}
@@ -0,0 +1,209 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/loadbalancers"
+	"github.com/G-Core/gcorelabscloud-go/gcore/securitygroup/v1/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceServiceSecurityRules() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceServiceSecurityRulesRead,
+		Description: "Represent ingress security group rules that allow traffic from G-Core services (CDN edge servers, load balancer instances), " +
+			"the rules can be used in the dynamic security_group_rules block of gcore_securitygroup",
+		Schema: map[string]*schema.Schema{
+			"project_id": &schema.Schema{
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Description:      "ID of the project of the load balancer.",
+				ConflictsWith:    []string{"project_name"},
+				DiffSuppressFunc: suppressDiffProjectID,
+			},
+			"region_id": &schema.Schema{
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Description:      "ID of the region of the load balancer.",
+				ConflictsWith:    []string{"region_name"},
+				DiffSuppressFunc: suppressDiffRegionID,
+			},
+			"project_name": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Name of the project of the load balancer.",
+				ConflictsWith: []string{"project_id"},
+			},
+			"region_name": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Name of the region of the load balancer.",
+				ConflictsWith: []string{"region_id"},
+			},
+			"cdn": &schema.Schema{
+				Type:         schema.TypeBool,
+				Optional:     true,
+				Description:  "Allow traffic from the CDN edge servers.",
+				AtLeastOneOf: []string{"cdn", "loadbalancer_id"},
+			},
+			"loadbalancer_id": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Allow traffic (proxied requests and health checks) from the instances of the load balancer. Requires the project and the region.",
+				ValidateDiagFunc: validateUUID,
+				AtLeastOneOf:     []string{"cdn", "loadbalancer_id"},
+			},
+			"protocol": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      string(types.ProtocolTCP),
+				Description:  fmt.Sprintf("Protocol of the rules. Available value is %s", strings.Join(types.Protocol("").StringList(), ",")),
+				ValidateFunc: validation.StringInSlice(types.Protocol("").StringList(), false),
+			},
+			"port_range_min": &schema.Schema{
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          0,
+				ValidateDiagFunc: validatePortRange,
+			},
+			"port_range_max": &schema.Schema{
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          0,
+				ValidateDiagFunc: validatePortRange,
+			},
+			"rules": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Security group rules, one per source network.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"direction": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ethertype": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"protocol": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"port_range_min": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"port_range_max": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"remote_ip_prefix": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceServiceSecurityRulesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start ServiceSecurityRules reading")
+	config := m.(*Config)
+
+	protocol := d.Get("protocol").(string)
+	portMin := d.Get("port_range_min").(int)
+	portMax := d.Get("port_range_max").(int)
+
+	var rules []map[string]interface{}
+	if d.Get("cdn").(bool) {
+		ips, err := getCDNPublicIPList(ctx, config.CDNRequester)
+		if err != nil {
+			return diag.Errorf("get CDN IP list: %s", err)
+		}
+		prefixes := append(append([]string{}, ips.Addresses...), ips.AddressesV6...)
+		cdnRules, err := securityGroupServiceRules(prefixes, "CDN edge servers", protocol, portMin, portMax)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		rules = append(rules, cdnRules...)
+	}
+
+	if lbID := d.Get("loadbalancer_id").(string); lbID != "" {
+		client, err := CreateClient(config.Provider, d, LoadBalancersPoint, versionPointV1)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		lb, err := loadbalancers.Get(client, lbID, nil).Extract()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		prefixes := make([]string, 0, len(lb.VrrpIPs))
+		for _, ip := range lb.VrrpIPs {
+			prefixes = append(prefixes, hostPrefix(ip.IpAddress))
+		}
+		lbRules, err := securityGroupServiceRules(prefixes, fmt.Sprintf("load balancer %s", lb.Name), protocol, portMin, portMax)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		rules = append(rules, lbRules...)
+	}
+
+	prefixes := make([]string, len(rules))
+	for i, r := range rules {
+		prefixes[i] = r["remote_ip_prefix"].(string)
+	}
+	d.SetId(strconv.Itoa(schema.HashString(fmt.Sprintf("%s:%d-%d:%s", protocol, portMin, portMax, strings.Join(prefixes, ",")))))
+	if err := d.Set("rules", rules); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Println("[DEBUG] Finish ServiceSecurityRules reading")
+	return nil
+}
+
+// securityGroupServiceRules builds an ingress rule for every source network, the ethertype follows the network family.
+func securityGroupServiceRules(prefixes []string, source, protocol string, portMin, portMax int) ([]map[string]interface{}, error) {
+	rules := make([]map[string]interface{}, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		ip, _, err := net.ParseCIDR(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", source, err)
+		}
+		etherType := types.EtherTypeIPv4
+		if ip.To4() == nil {
+			etherType = types.EtherTypeIPv6
+		}
+		rules = append(rules, map[string]interface{}{
+			"direction":        types.RuleDirectionIngress.String(),
+			"ethertype":        etherType.String(),
+			"protocol":         protocol,
+			"port_range_min":   portMin,
+			"port_range_max":   portMax,
+			"remote_ip_prefix": prefix,
+			"description":      fmt.Sprintf("Allow %s", source),
+		})
+	}
+	return rules, nil
+}
+
+// hostPrefix returns the single address network of the IP.
+func hostPrefix(ip net.IP) string {
+	if ip.To4() != nil {
+		return ip.String() + "/32"
+	}
+	return ip.String() + "/128"
+}
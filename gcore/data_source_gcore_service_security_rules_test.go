@@ -0,0 +1,39 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"net"
+	"testing"
+)
+
+func TestSecurityGroupServiceRules(t *testing.T) {
+	prefixes := []string{"5.188.7.0/24", hostPrefix(net.ParseIP("10.0.0.5")), hostPrefix(net.ParseIP("2a03:90c0::1"))}
+	rules, err := securityGroupServiceRules(prefixes, "test", "tcp", 443, 443)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		prefix    string
+		etherType string
+	}{
+		{"5.188.7.0/24", "IPv4"},
+		{"10.0.0.5/32", "IPv4"},
+		{"2a03:90c0::1/128", "IPv6"},
+	}
+	if len(rules) != len(want) {
+		t.Fatalf("securityGroupServiceRules() returned %d rules, want %d", len(rules), len(want))
+	}
+	for i, w := range want {
+		r := rules[i]
+		if r["remote_ip_prefix"] != w.prefix || r["ethertype"] != w.etherType || r["direction"] != "ingress" {
+			t.Errorf("rule %d = %v, want %s %s ingress", i, r, w.prefix, w.etherType)
+		}
+	}
+
+	if _, err := securityGroupServiceRules([]string{"10.0.0.5"}, "test", "tcp", 0, 0); err == nil {
+		t.Errorf("securityGroupServiceRules() expected error for an address without prefix length")
+	}
+}
@@ -3,10 +3,12 @@ package gcore
 import (
 	"context"
 	"log"
+	"net"
 
 	"github.com/G-Core/gcorelabscloud-go/gcore/subnet/v1/subnets"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func dataSourceSubnet() *schema.Resource {
@@ -48,8 +50,11 @@ func dataSourceSubnet() *schema.Resource {
 				},
 			},
 			"name": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				AtLeastOneOf: []string{"name", "cidr"},
+				Description:  "Name of the subnet. Can be combined with `cidr` and `network_id` when the name is not unique.",
 			},
 			"network_id": &schema.Schema{
 				Type:     schema.TypeString,
@@ -61,8 +66,11 @@ func dataSourceSubnet() *schema.Resource {
 				Computed: true,
 			},
 			"cidr": &schema.Schema{
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsCIDR,
+				Description:  "CIDR of the subnet, e.g. '192.168.10.0/24'. Use it with `network_id` to find an auto-named subnet.",
 			},
 			"connect_to_network_router": &schema.Schema{
 				Type:     schema.TypeBool,
@@ -162,19 +170,38 @@ func dataSourceSubnetRead(ctx context.Context, d *schema.ResourceData, m interfa
 		return diag.FromErr(err)
 	}
 
-	var found bool
-	var subnet subnets.Subnet
+	var subnetCIDR *net.IPNet
+	if v := d.Get("cidr").(string); v != "" {
+		_, subnetCIDR, err = net.ParseCIDR(v)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	var found []subnets.Subnet
 	for _, sn := range snets {
-		if sn.Name == name {
-			subnet = sn
-			found = true
+		if name != "" && sn.Name != name {
+			continue
+		}
+		if subnetCIDR != nil && sn.CIDR.String() != subnetCIDR.String() {
+			continue
+		}
+		found = append(found, sn)
+		// the lookup by name returns the first subnet as before
+		if subnetCIDR == nil {
 			break
 		}
 	}
 
-	if !found {
+	switch {
+	case len(found) == 0 && subnetCIDR == nil:
 		return diag.Errorf("subnet with name %s not found", name)
+	case len(found) == 0:
+		return diag.Errorf("subnet with cidr %s not found", subnetCIDR)
+	case len(found) > 1:
+		return diag.Errorf("found %d subnets with cidr %s, specify network_id or name", len(found), subnetCIDR)
 	}
+	subnet := found[0]
 
 	d.SetId(subnet.ID)
 	d.Set("name", subnet.Name)
@@ -0,0 +1,231 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/subnet/v1/subnets"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceSubnetIPAvailability() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSubnetIPAvailabilityRead,
+		Description: "Represent the used and free IP addresses of the subnets, e.g. to stop the plan before a rollout exhausts a subnet.",
+		Schema: map[string]*schema.Schema{
+			"project_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+				DiffSuppressFunc: suppressDiffProjectID,
+			},
+			"region_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+				DiffSuppressFunc: suppressDiffRegionID,
+			},
+			"project_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+			},
+			"region_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+			},
+			"network_id": &schema.Schema{
+				Type:             schema.TypeString,
+				Description:      "ID of the network, all its subnets are reported.",
+				Optional:         true,
+				ExactlyOneOf:     []string{"network_id", "subnet_id"},
+				ValidateDiagFunc: validateUUID,
+			},
+			"subnet_id": &schema.Schema{
+				Type:             schema.TypeString,
+				Description:      "ID of the subnet.",
+				Optional:         true,
+				ExactlyOneOf:     []string{"network_id", "subnet_id"},
+				ValidateDiagFunc: validateUUID,
+			},
+			"min_available_ips": &schema.Schema{
+				Type:         schema.TypeInt,
+				Description:  "The read fails if a subnet has less free IP addresses, so the plan stops before the rollout. The subnets with unknown IP availability are skipped with a warning.",
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"subnets": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cidr": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ip_version": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"ip_availability_known": &schema.Schema{
+							Type:        schema.TypeBool,
+							Description: "The API reports the IP counts of the subnet, otherwise the counts are 0.",
+							Computed:    true,
+						},
+						"total_ips": &schema.Schema{
+							Type:        schema.TypeFloat,
+							Description: "Number of the IP addresses of the subnet, a float as IPv6 subnets exceed the integer range.",
+							Computed:    true,
+						},
+						"available_ips": &schema.Schema{
+							Type:        schema.TypeFloat,
+							Description: "Number of the free IP addresses.",
+							Computed:    true,
+						},
+						"used_ips": &schema.Schema{
+							Type:        schema.TypeFloat,
+							Description: "Number of the used IP addresses.",
+							Computed:    true,
+						},
+						"used_percent": &schema.Schema{
+							Type:        schema.TypeFloat,
+							Description: "Share of the used IP addresses in percent.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSubnetIPAvailabilityRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start Subnet IP availability reading")
+	config := m.(*Config)
+	provider := config.Provider
+
+	client, err := CreateClient(provider, d, subnetPoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var found []subnets.Subnet
+	if subnetID := d.Get("subnet_id").(string); subnetID != "" {
+		subnet, err := subnets.Get(client, subnetID).Extract()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		found = []subnets.Subnet{*subnet}
+	} else {
+		found, err = subnets.ListAll(client, subnets.ListOpts{NetworkID: d.Get("network_id").(string)})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].ID < found[j].ID })
+
+	ids := make([]string, len(found))
+	for i, sn := range found {
+		ids[i] = sn.ID
+	}
+	d.SetId(strconv.Itoa(schema.HashString(strings.Join(ids, ","))))
+	availability := flattenSubnetIPAvailability(found)
+	if err := d.Set("subnets", availability); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	if v, ok := d.GetOk("min_available_ips"); ok {
+		diags = checkSubnetIPAvailability(availability, v.(int))
+	}
+
+	log.Println("[DEBUG] Finish Subnet IP availability reading")
+	return diags
+}
+
+// flattenSubnetIPAvailability returns the IP counts of the subnets, the counts the API doesn't report are 0
+// and ip_availability_known is false.
+func flattenSubnetIPAvailability(found []subnets.Subnet) []interface{} {
+	result := make([]interface{}, len(found))
+	for i, sn := range found {
+		var total, available, used, usedPercent float64
+		known := sn.TotalIps != nil && sn.AvailableIps != nil
+		if known {
+			total, available = *sn.TotalIps, *sn.AvailableIps
+			used = total - available
+			if total > 0 {
+				usedPercent = used / total * 100
+			}
+		} else {
+			log.Printf("[DEBUG] IP availability of subnet %s is unknown", sn.ID)
+		}
+		result[i] = map[string]interface{}{
+			"id":                    sn.ID,
+			"name":                  sn.Name,
+			"cidr":                  sn.CIDR.String(),
+			"ip_version":            sn.IPVersion,
+			"ip_availability_known": known,
+			"total_ips":             total,
+			"available_ips":         available,
+			"used_ips":              used,
+			"used_percent":          usedPercent,
+		}
+	}
+	return result
+}
+
+// checkSubnetIPAvailability returns the error listing the subnets with less than min free IP addresses
+// and the warning listing the subnets whose IP availability is unknown.
+func checkSubnetIPAvailability(availability []interface{}, min int) diag.Diagnostics {
+	var short, unknown []string
+	for _, raw := range availability {
+		sn := raw.(map[string]interface{})
+		if !sn["ip_availability_known"].(bool) {
+			unknown = append(unknown, fmt.Sprintf("%s (%s)", sn["id"], sn["cidr"]))
+			continue
+		}
+		if available := sn["available_ips"].(float64); available < float64(min) {
+			short = append(short, fmt.Sprintf("%s (%s) has %.0f", sn["id"], sn["cidr"], available))
+		}
+	}
+
+	var diags diag.Diagnostics
+	if len(unknown) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Unknown subnet IP availability",
+			Detail:   fmt.Sprintf("The API doesn't report the IP counts of the subnets, min_available_ips is not checked for them: %s", strings.Join(unknown, ", ")),
+		})
+	}
+	if len(short) > 0 {
+		diags = append(diags, diag.Errorf("subnets have less than %d free IP addresses: %s", min, strings.Join(short, ", "))...)
+	}
+	return diags
+}
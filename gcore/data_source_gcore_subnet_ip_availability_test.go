@@ -0,0 +1,46 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/subnet/v1/subnets"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func TestFlattenSubnetIPAvailability(t *testing.T) {
+	_, ipNet, _ := net.ParseCIDR("192.168.10.0/24")
+	total, available := 253.0, 3.0
+	found := []subnets.Subnet{
+		{ID: "full", Name: "full", CIDR: gcorecloud.CIDR{IPNet: *ipNet}, IPVersion: 4, TotalIps: &total, AvailableIps: &available},
+		{ID: "unknown", Name: "unknown", CIDR: gcorecloud.CIDR{IPNet: *ipNet}, IPVersion: 4},
+	}
+
+	got := flattenSubnetIPAvailability(found)
+	full := got[0].(map[string]interface{})
+	if full["cidr"] != "192.168.10.0/24" || full["used_ips"] != 250.0 || full["available_ips"] != 3.0 {
+		t.Errorf("flattenSubnetIPAvailability() = %v, want 250 used and 3 available IPs of 192.168.10.0/24", full)
+	}
+	if percent := full["used_percent"].(float64); percent < 98.8 || percent > 98.9 {
+		t.Errorf("used_percent = %v, want 98.8", percent)
+	}
+	if unknown := got[1].(map[string]interface{}); unknown["ip_availability_known"] != false || unknown["total_ips"] != 0.0 {
+		t.Errorf("flattenSubnetIPAvailability() = %v, want the unknown counts marked", unknown)
+	}
+
+	if diags := checkSubnetIPAvailability(got[:1], 3); len(diags) != 0 {
+		t.Errorf("checkSubnetIPAvailability(3) = %v, want no diagnostics", diags)
+	}
+	diags := checkSubnetIPAvailability(got, 10)
+	if len(diags) != 2 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Detail, "unknown (192.168.10.0/24)") {
+		t.Fatalf("checkSubnetIPAvailability(10) = %v, want a warning for the unknown subnet", diags)
+	}
+	if diags[1].Severity != diag.Error || !strings.Contains(diags[1].Summary, "full (192.168.10.0/24) has 3") || strings.Contains(diags[1].Summary, "unknown") {
+		t.Errorf("checkSubnetIPAvailability(10) = %v, want only the full subnet reported", diags[1])
+	}
+}
@@ -99,6 +99,16 @@ func TestAccSubnetDataSource(t *testing.T) {
 			}
 		`, projectInfo(), regionInfo(), name)
 	}
+	tpl3 := func(cidr string) string {
+		return fmt.Sprintf(`
+			data "gcore_subnet" "acctest" {
+			%s
+			%s
+			network_id = "%s"
+			cidr = "%s"
+			}
+		`, projectInfo(), regionInfo(), networkID, cidr)
+	}
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
 		ProviderFactories: testAccProviders,
@@ -126,6 +136,15 @@ func TestAccSubnetDataSource(t *testing.T) {
 					}),
 				),
 			},
+			{
+				Config: tpl3(cidr2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists(fullName),
+					resource.TestCheckResourceAttr(fullName, "id", subnet2ID),
+					resource.TestCheckResourceAttr(fullName, "name", optsSubnet2.Name),
+					resource.TestCheckResourceAttr(fullName, "cidr", cidr2),
+				),
+			},
 		},
 	})
 }
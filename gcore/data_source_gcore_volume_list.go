@@ -0,0 +1,212 @@
+package gcore
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/volume/v1/volumes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceVolumeList() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceVolumeListRead,
+		Description: "Represent a list of volumes filtered by the attached instance, bootable flag or metadata",
+		Schema: map[string]*schema.Schema{
+			"project_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+				DiffSuppressFunc: suppressDiffProjectID,
+			},
+			"region_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+				DiffSuppressFunc: suppressDiffRegionID,
+			},
+			"project_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+			},
+			"region_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+			},
+			"instance_id": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Return only volumes attached to the instance.",
+				ValidateDiagFunc: validateUUID,
+			},
+			"bootable": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Return only bootable (true) or only non-bootable (false) volumes.",
+			},
+			"metadata_k": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return only volumes that have the metadata key.",
+			},
+			"metadata_kv": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Return only volumes that have all the metadata key-value pairs.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"volumes": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"type_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"bootable": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"attachments": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"instance_id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"instance_name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"device": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"metadata": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceVolumeListRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start Volume list reading")
+	var diags diag.Diagnostics
+	config := m.(*Config)
+	provider := config.Provider
+
+	client, err := CreateClient(provider, d, volumesPoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	volumeOpts := &volumes.ListOpts{}
+	if instanceID, ok := d.GetOk("instance_id"); ok {
+		id := instanceID.(string)
+		volumeOpts.InstanceID = &id
+	}
+	if bootable, ok := d.GetOkExists("bootable"); ok {
+		b := bootable.(bool)
+		volumeOpts.Bootable = &b
+	}
+	if metadataK, ok := d.GetOk("metadata_k"); ok {
+		volumeOpts.MetadataK = metadataK.(string)
+	}
+	if metadataRaw, ok := d.GetOk("metadata_kv"); ok {
+		typedMetadataKV := make(map[string]string, len(metadataRaw.(map[string]interface{})))
+		for k, v := range metadataRaw.(map[string]interface{}) {
+			typedMetadataKV[k] = v.(string)
+		}
+		volumeOpts.MetadataKV = typedMetadataKV
+	}
+
+	vols, err := volumes.ListAll(client, volumeOpts)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := make([]string, 0, len(vols))
+	volumeList := make([]map[string]interface{}, 0, len(vols))
+	for _, v := range vols {
+		attachments := make([]map[string]interface{}, 0, len(v.Attachments))
+		for _, a := range v.Attachments {
+			attachments = append(attachments, map[string]interface{}{
+				"instance_id":   a.ServerID,
+				"instance_name": a.InstanceName,
+				"device":        a.Device,
+			})
+		}
+		meta := make(map[string]string, len(v.Metadata))
+		for _, metadataItem := range v.Metadata {
+			meta[metadataItem.Key] = metadataItem.Value
+		}
+
+		ids = append(ids, v.ID)
+		volumeList = append(volumeList, map[string]interface{}{
+			"id":          v.ID,
+			"name":        v.Name,
+			"size":        v.Size,
+			"type_name":   v.VolumeType.String(),
+			"status":      v.Status.String(),
+			"bootable":    v.Bootable,
+			"attachments": attachments,
+			"metadata":    meta,
+		})
+	}
+
+	d.SetId(strconv.Itoa(schema.HashString(strings.Join(ids, ","))))
+	if err := d.Set("volumes", volumeList); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Println("[DEBUG] Finish Volume list reading")
+	return diags
+}
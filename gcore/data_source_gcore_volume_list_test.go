@@ -0,0 +1,65 @@
+//go:build cloud
+// +build cloud
+
+package gcore
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/volume/v1/volumes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccVolumeListDataSource(t *testing.T) {
+	cfg, err := createTestConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := CreateTestClient(cfg.Provider, volumesPoint, versionPointV1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := volumes.CreateOpts{
+		Name:     volume1TestName,
+		Size:     volumeTestSize,
+		Source:   volumes.NewVolume,
+		TypeName: volumes.Standard,
+		Metadata: map[string]string{"service": "volume-list-acctest"},
+	}
+
+	volumeID, err := createTestVolume(client, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer volumes.Delete(client, volumeID, volumes.DeleteOpts{})
+
+	fullName := "data.gcore_volume_list.acctest"
+	tpl := fmt.Sprintf(`
+		data "gcore_volume_list" "acctest" {
+		  %s
+		  %s
+		  bootable    = false
+		  metadata_kv = {service = "volume-list-acctest"}
+		}
+	`, projectInfo(), regionInfo())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: tpl,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists(fullName),
+					resource.TestCheckResourceAttr(fullName, "volumes.#", "1"),
+					resource.TestCheckResourceAttr(fullName, "volumes.0.id", volumeID),
+					resource.TestCheckResourceAttr(fullName, "volumes.0.name", opts.Name),
+					resource.TestCheckResourceAttr(fullName, "volumes.0.metadata.service", "volume-list-acctest"),
+				),
+			},
+		},
+	})
+}
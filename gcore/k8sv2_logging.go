@@ -0,0 +1,146 @@
+package gcore
+
+import (
+	"fmt"
+	"log"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/k8s/v2/clusters"
+	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// k8sV2Logging is the delivery of the control plane and audit logs of the cluster to a LaaS topic,
+// the clusters package of gcorelabscloud-go doesn't support it.
+type k8sV2Logging struct {
+	Enabled             bool                         `json:"enabled"`
+	DestinationRegionID int                          `json:"destination_region_id,omitempty"`
+	TopicName           string                       `json:"topic_name,omitempty"`
+	RetentionPolicy     *k8sV2LoggingRetentionPolicy `json:"retention_policy,omitempty"`
+}
+
+type k8sV2LoggingRetentionPolicy struct {
+	Period int `json:"period"`
+}
+
+// k8sV2CreateOpts adds the logging to the create request.
+type k8sV2CreateOpts struct {
+	clusters.CreateOpts
+	Logging *k8sV2Logging
+}
+
+func (opts k8sV2CreateOpts) ToClusterCreateMap() (map[string]interface{}, error) {
+	b, err := opts.CreateOpts.ToClusterCreateMap()
+	if err != nil || opts.Logging == nil {
+		return b, err
+	}
+	b["logging"] = opts.Logging
+	return b, nil
+}
+
+func k8sV2LoggingSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Delivery of the control plane and audit logs of the cluster to LaaS.",
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"enabled": {
+					Type:        schema.TypeBool,
+					Description: "Enable the log delivery.",
+					Optional:    true,
+					Default:     true,
+				},
+				"destination_region_id": {
+					Type:        schema.TypeInt,
+					Description: "ID of the LaaS region the logs are delivered to, the region of the cluster by default.",
+					Optional:    true,
+					Computed:    true,
+				},
+				"topic_name": {
+					Type:        schema.TypeString,
+					Description: "Name of the LaaS topic, the topic is created for the cluster by default.",
+					Optional:    true,
+					Computed:    true,
+				},
+				"retention_period": {
+					Type:         schema.TypeInt,
+					Description:  "Number of days the logs are kept.",
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+// k8sV2LoggingFromSchema returns the configured logging, the delivery is disabled when the block is removed.
+func k8sV2LoggingFromSchema(d *schema.ResourceData) *k8sV2Logging {
+	raw := d.Get("logging").([]interface{})
+	if len(raw) == 0 || raw[0] == nil {
+		return &k8sV2Logging{Enabled: false}
+	}
+	l := raw[0].(map[string]interface{})
+	logging := &k8sV2Logging{
+		Enabled:             l["enabled"].(bool),
+		DestinationRegionID: l["destination_region_id"].(int),
+		TopicName:           l["topic_name"].(string),
+	}
+	if period := l["retention_period"].(int); period > 0 {
+		logging.RetentionPolicy = &k8sV2LoggingRetentionPolicy{Period: period}
+	}
+	return logging
+}
+
+// k8sV2SetLoggingState sets the logging of the cluster, the disabled delivery is left out
+// unless the logging block is configured, so the clusters without logging have no diff.
+func k8sV2SetLoggingState(d *schema.ResourceData, logging *k8sV2Logging) error {
+	if logging == nil || (!logging.Enabled && len(d.Get("logging").([]interface{})) == 0) {
+		return d.Set("logging", nil)
+	}
+	period := 0
+	if logging.RetentionPolicy != nil {
+		period = logging.RetentionPolicy.Period
+	}
+	return d.Set("logging", []interface{}{map[string]interface{}{
+		"enabled":               logging.Enabled,
+		"destination_region_id": logging.DestinationRegionID,
+		"topic_name":            logging.TopicName,
+		"retention_period":      period,
+	}})
+}
+
+// k8sV2GetLogging returns the logging of the cluster from its details.
+func k8sV2GetLogging(result clusters.GetResult) (*k8sV2Logging, error) {
+	var s struct {
+		Logging *k8sV2Logging `json:"logging"`
+	}
+	if err := result.ExtractInto(&s); err != nil {
+		return nil, err
+	}
+	return s.Logging, nil
+}
+
+// k8sV2UpdateLogging changes the log delivery of the cluster and waits for the task.
+func k8sV2UpdateLogging(client, tasksClient *gcorecloud.ServiceClient, clusterName string, logging *k8sV2Logging) error {
+	log.Printf("[DEBUG] Update logging of k8s cluster %s: %+v", clusterName, logging)
+	var results tasks.TaskResults
+	_, err := client.Patch(client.ServiceURL(clusterName), map[string]interface{}{"logging": logging}, &results, &gcorecloud.RequestOpts{
+		OkCodes: []int{200, 201},
+	})
+	if err != nil {
+		return fmt.Errorf("update logging: %w", err)
+	}
+	if len(results.Tasks) == 0 {
+		return nil
+	}
+	taskID := results.Tasks[0]
+	log.Printf("[DEBUG] Task id (%s)", taskID)
+	_, err = tasks.WaitTaskAndReturnResult(tasksClient, taskID, true, K8sCreateTimeout, func(task tasks.TaskID) (interface{}, error) {
+		return nil, nil
+	})
+	return err
+}
@@ -0,0 +1,103 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	gc "github.com/G-Core/gcorelabscloud-go/gcore"
+	"github.com/G-Core/gcorelabscloud-go/gcore/k8s/v2/clusters"
+	"github.com/G-Core/gcorelabscloud-go/gcore/k8s/v2/pools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestK8sV2CreateOptsLogging(t *testing.T) {
+	opts := k8sV2CreateOpts{
+		CreateOpts: clusters.CreateOpts{
+			Name:    "cluster",
+			KeyPair: "kp",
+			Version: "v1.28.1",
+			Pools:   []pools.CreateOpts{{Name: "pool", FlavorID: "g1-standard-1-2", MinNodeCount: 1, MaxNodeCount: 1}},
+		},
+		Logging: &k8sV2Logging{Enabled: true, TopicName: "audit", RetentionPolicy: &k8sV2LoggingRetentionPolicy{Period: 45}},
+	}
+	b, err := opts.ToClusterCreateMap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := json.Marshal(b["logging"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"enabled":true,"topic_name":"audit","retention_policy":{"period":45}}`; string(raw) != want {
+		t.Errorf("logging = %s, want %s", raw, want)
+	}
+
+	opts.Logging = nil
+	if b, _ = opts.ToClusterCreateMap(); b["logging"] != nil {
+		t.Errorf("logging = %v, want none when the block isn't set", b["logging"])
+	}
+}
+
+func TestK8sV2LoggingState(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceK8sV2().Schema, map[string]interface{}{
+		"logging": []interface{}{map[string]interface{}{"retention_period": 30}},
+	})
+	want := &k8sV2Logging{Enabled: true, RetentionPolicy: &k8sV2LoggingRetentionPolicy{Period: 30}}
+	if got := k8sV2LoggingFromSchema(d); !reflect.DeepEqual(got, want) {
+		t.Errorf("k8sV2LoggingFromSchema() = %+v, want %+v", got, want)
+	}
+
+	if err := k8sV2SetLoggingState(d, &k8sV2Logging{Enabled: true, DestinationRegionID: 76, TopicName: "cluster-logs"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.Get("logging.0.topic_name").(string); got != "cluster-logs" {
+		t.Errorf("logging.0.topic_name = %q, want the topic of the cluster", got)
+	}
+
+	unset := schema.TestResourceDataRaw(t, resourceK8sV2().Schema, map[string]interface{}{})
+	if got := k8sV2LoggingFromSchema(unset); got.Enabled {
+		t.Errorf("k8sV2LoggingFromSchema() = %+v, want the delivery disabled without the block", got)
+	}
+	if err := k8sV2SetLoggingState(unset, &k8sV2Logging{Enabled: false}); err != nil {
+		t.Fatal(err)
+	}
+	if got := unset.Get("logging").([]interface{}); len(got) != 0 {
+		t.Errorf("logging = %v, want none for the disabled delivery", got)
+	}
+}
+
+func TestK8sV2UpdateLogging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/v2/k8s/clusters/1/2/cluster" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		var body map[string]k8sV2Logging
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["logging"].Enabled {
+			t.Errorf("unexpected body %v (%v), want the delivery disabled", body, err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"tasks":[]}`)
+	}))
+	defer server.Close()
+
+	client, err := gc.ClientServiceFromProvider(&gcorecloud.ProviderClient{APIBase: server.URL + "/"}, gcorecloud.EndpointOpts{
+		Name:    K8sPoint,
+		Project: 1,
+		Region:  2,
+		Version: versionPointV2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := k8sV2UpdateLogging(client, client, "cluster", &k8sV2Logging{Enabled: false}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,58 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"reflect"
+	"testing"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/instances"
+	"github.com/G-Core/gcorelabscloud-go/gcore/securitygroup/v1/securitygroups"
+)
+
+func TestK8sV2SecurityGroupNames(t *testing.T) {
+	groups := []securitygroups.SecurityGroup{
+		{ID: "sg-1", Name: "web"},
+		{ID: "sg-2", Name: "db"},
+		{ID: "sg-3", Name: "db"},
+	}
+
+	names, err := resourceK8sV2SecurityGroupNames(groups, []string{"sg-1", "sg-missing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := map[string]string{"sg-1": "web"}; !reflect.DeepEqual(names, expected) {
+		t.Fatalf("expected %v, got %v", expected, names)
+	}
+
+	if _, err := resourceK8sV2SecurityGroupNames(groups, []string{"sg-2"}); err == nil {
+		t.Fatal("expected an error for a security group with an ambiguous name")
+	}
+}
+
+func TestK8sV2PortsSecurityGroupIDs(t *testing.T) {
+	port := func(ids ...string) instances.InstancePorts {
+		p := instances.InstancePorts{ID: "port"}
+		for _, id := range ids {
+			p.SecurityGroups = append(p.SecurityGroups, gcorecloud.ItemIDName{ID: id})
+		}
+		return p
+	}
+	nodePorts := [][]instances.InstancePorts{
+		{port("default", "sg-1", "sg-2")},
+		{port("default"), port("sg-1")},
+	}
+
+	got := resourceK8sV2PortsSecurityGroupIDs(nodePorts, []string{"sg-1", "sg-2"})
+	if expected := []string{"sg-1"}; !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+
+	// a pool without nodes has nothing detached
+	got = resourceK8sV2PortsSecurityGroupIDs(nil, []string{"sg-1", "sg-2"})
+	if expected := []string{"sg-1", "sg-2"}; !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
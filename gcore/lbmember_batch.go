@@ -0,0 +1,228 @@
+package gcore
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/lbpools"
+	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
+)
+
+// lbMemberBatchWindow is how long the creation of a member waits for other members of the same pool,
+// terraform creates independent resources in parallel, so the members of a pool usually come together.
+const lbMemberBatchWindow = 2 * time.Second
+
+// lbMemberBatcher coalesces the members of a pool created at the same time into a single pool update,
+// every pool update takes about a minute no matter how many members it adds.
+// The members are batched per pool and provider credentials, so the batch is sent with the credentials of all its members.
+// The changes of the member list of a pool are serialized by lockPool, the pool update replaces the whole list.
+type lbMemberBatcher struct {
+	mu      sync.Mutex
+	pending map[lbMemberBatchID]*lbMemberBatch
+	pools   map[string]*sync.Mutex
+}
+
+type lbMemberBatchID struct {
+	pool        string
+	credentials string
+}
+
+type lbMemberBatch struct {
+	requests []*lbMemberRequest
+}
+
+type lbMemberRequest struct {
+	opts    lbpools.CreatePoolMemberOpts
+	timeout int
+	done    chan lbMemberResult
+}
+
+type lbMemberResult struct {
+	id  string
+	err error
+}
+
+// lbMemberFlush creates the members and returns the result of every member in the same order.
+type lbMemberFlush func(members []lbpools.CreatePoolMemberOpts, timeout int) []lbMemberResult
+
+var lbMemberBatches = &lbMemberBatcher{}
+
+// create adds the member to the pending batch of the pool and returns the member ID when the batch is created.
+// The first member of a batch schedules the flush after the window, the batch waits for the longest timeout of its members.
+// The flush serves all the members of the batch, so it must not depend on the operation of the first one.
+func (b *lbMemberBatcher) create(key, credentials string, opts lbpools.CreatePoolMemberOpts, timeout int, window time.Duration, flush lbMemberFlush) (string, error) {
+	req := &lbMemberRequest{opts: opts, timeout: timeout, done: make(chan lbMemberResult, 1)}
+	id := lbMemberBatchID{pool: key, credentials: credentials}
+
+	b.mu.Lock()
+	if b.pending == nil {
+		b.pending = map[lbMemberBatchID]*lbMemberBatch{}
+	}
+	batch, ok := b.pending[id]
+	if !ok {
+		batch = &lbMemberBatch{}
+		b.pending[id] = batch
+		time.AfterFunc(window, func() { b.flush(id, batch, flush) })
+	}
+	batch.requests = append(batch.requests, req)
+	b.mu.Unlock()
+
+	res := <-req.done
+	return res.id, res.err
+}
+
+func (b *lbMemberBatcher) flush(id lbMemberBatchID, batch *lbMemberBatch, flush lbMemberFlush) {
+	b.mu.Lock()
+	if b.pending[id] == batch {
+		delete(b.pending, id)
+	}
+	b.mu.Unlock()
+
+	unlock := b.lockPool(id.pool)
+	defer unlock()
+
+	var timeout int
+	members := make([]lbpools.CreatePoolMemberOpts, len(batch.requests))
+	for i, req := range batch.requests {
+		members[i] = req.opts
+		if req.timeout > timeout {
+			timeout = req.timeout
+		}
+	}
+	log.Printf("[DEBUG] Creating %d LBMembers of %s", len(members), id.pool)
+	results := flush(members, timeout)
+	for i, req := range batch.requests {
+		if i >= len(results) {
+			req.done <- lbMemberResult{err: fmt.Errorf("created %d members instead of %d", len(results), len(members))}
+			continue
+		}
+		req.done <- results[i]
+	}
+}
+
+// lockPool serializes the changes of the member list of the pool, it returns the unlock function
+// that can be called more than once.
+func (b *lbMemberBatcher) lockPool(key string) func() {
+	b.mu.Lock()
+	if b.pools == nil {
+		b.pools = map[string]*sync.Mutex{}
+	}
+	mu, ok := b.pools[key]
+	if !ok {
+		mu = &sync.Mutex{}
+		b.pools[key] = mu
+	}
+	b.mu.Unlock()
+
+	mu.Lock()
+	var once sync.Once
+	return func() { once.Do(mu.Unlock) }
+}
+
+func lbMemberBatchKey(client *gcorecloud.ServiceClient, poolID string) string {
+	return client.ResourceBaseURL() + poolID
+}
+
+// createLBMembers creates the members of the pool and returns their results in the same order.
+// A single member is created with the member API, more members are added with one pool update.
+// When the pool update fails, e.g. a member is invalid, the members are created one by one,
+// so every member gets its own error and the valid members are created.
+func createLBMembers(client *gcorecloud.ServiceClient, poolID string, members []lbpools.CreatePoolMemberOpts, timeout int) []lbMemberResult {
+	results := make([]lbMemberResult, len(members))
+	if len(members) == 1 {
+		results[0].id, results[0].err = createLBMember(client, poolID, members[0], timeout)
+		return results
+	}
+
+	if err := addLBPoolMembers(client, poolID, members, timeout); err != nil {
+		log.Printf("[WARN] Cannot add %d LBMembers to pool %s at once, creating them one by one: %s", len(members), poolID, err)
+		for i, opts := range members {
+			results[i].id, results[i].err = createLBMember(client, poolID, opts, timeout)
+		}
+		return results
+	}
+
+	pool, err := getLBPool(client, poolID)
+	if err != nil {
+		for i := range results {
+			results[i].err = err
+		}
+		return results
+	}
+	for i, opts := range members {
+		if pm := findLBPoolMember(pool.Members, opts.Address, opts.ProtocolPort); pm != nil {
+			results[i].id = pm.ID
+			continue
+		}
+		results[i].err = fmt.Errorf("member %s:%d not found in pool %s after the update", opts.Address, opts.ProtocolPort, poolID)
+	}
+	return results
+}
+
+// createLBMember creates the member with the member API and returns its ID.
+func createLBMember(client *gcorecloud.ServiceClient, poolID string, opts lbpools.CreatePoolMemberOpts, timeout int) (string, error) {
+	rc := GetConflictRetryConfig(timeout)
+	results, err := lbpools.CreateMember(client, poolID, opts, &gcorecloud.RequestOpts{
+		ConflictRetryAmount:   rc.Amount,
+		ConflictRetryInterval: rc.Interval,
+	}).Extract()
+	if err != nil {
+		return "", err
+	}
+	taskID := results.Tasks[0]
+	pmID, err := tasks.WaitTaskAndReturnResult(client, taskID, true, timeout, func(task tasks.TaskID) (interface{}, error) {
+		taskInfo, err := tasks.Get(client, string(task)).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
+		}
+		pmID, err := lbpools.ExtractPoolMemberIDFromTask(taskInfo)
+		if err != nil {
+			return nil, fmt.Errorf("cannot retrieve LBMember ID from task info: %w", err)
+		}
+		return pmID, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return pmID.(string), nil
+}
+
+// addLBPoolMembers adds the members to the pool with one pool update, the existing members are kept.
+func addLBPoolMembers(client *gcorecloud.ServiceClient, poolID string, members []lbpools.CreatePoolMemberOpts, timeout int) error {
+	pool, err := getLBPool(client, poolID)
+	if err != nil {
+		return err
+	}
+	opts := lbpools.UpdateOpts{Name: pool.Name}
+	for _, pm := range pool.Members {
+		opts.Members = append(opts.Members, lbPoolMemberOpts(pm))
+	}
+	opts.Members = append(opts.Members, members...)
+
+	rc := GetConflictRetryConfig(timeout)
+	results, err := lbpools.Update(client, poolID, opts, &gcorecloud.RequestOpts{
+		ConflictRetryAmount:   rc.Amount,
+		ConflictRetryInterval: rc.Interval,
+	}).Extract()
+	if err != nil {
+		return err
+	}
+	return tasks.WaitForStatus(client, string(results.Tasks[0]), tasks.TaskStateFinished, timeout, true)
+}
+
+// lbPoolMemberOpts returns the options that keep the existing member as is in a pool update.
+func lbPoolMemberOpts(pm lbpools.PoolMember) lbpools.CreatePoolMemberOpts {
+	return lbpools.CreatePoolMemberOpts{
+		Address:        *pm.Address,
+		ProtocolPort:   pm.ProtocolPort,
+		Weight:         pm.Weight,
+		SubnetID:       pm.SubnetID,
+		InstanceID:     pm.InstanceID,
+		MonitorAddress: pm.MonitorAddress,
+		MonitorPort:    pm.MonitorPort,
+		ID:             pm.ID,
+	}
+}
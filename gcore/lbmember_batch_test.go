@@ -0,0 +1,255 @@
+package gcore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/lbpools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestLBMemberBatcher(t *testing.T) {
+	b := &lbMemberBatcher{}
+	var mu sync.Mutex
+	var flushes [][]lbpools.CreatePoolMemberOpts
+	flush := func(members []lbpools.CreatePoolMemberOpts, timeout int) []lbMemberResult {
+		mu.Lock()
+		flushes = append(flushes, members)
+		mu.Unlock()
+		results := make([]lbMemberResult, len(members))
+		for i, m := range members {
+			results[i].id = fmt.Sprintf("%s:%d", m.Address, m.ProtocolPort)
+		}
+		return results
+	}
+
+	var wg sync.WaitGroup
+	ids := make([]string, 3)
+	for i := range ids {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			opts := lbpools.CreatePoolMemberOpts{Address: net.ParseIP("10.0.0.1"), ProtocolPort: 8080 + i}
+			id, err := b.create("pool", "", opts, 60, 50*time.Millisecond, flush)
+			if err != nil {
+				t.Error(err)
+			}
+			ids[i] = id
+		}(i)
+	}
+	wg.Wait()
+
+	if len(flushes) != 1 || len(flushes[0]) != 3 {
+		t.Fatalf("members were created in %d batches, want 1 batch of 3", len(flushes))
+	}
+	for i, id := range ids {
+		if want := fmt.Sprintf("10.0.0.1:%d", 8080+i); id != want {
+			t.Errorf("member %d got ID %s, want %s", i, id, want)
+		}
+	}
+
+	failure := errors.New("pool is immutable")
+	_, err := b.create("pool", "", lbpools.CreatePoolMemberOpts{}, 60, time.Millisecond, func(members []lbpools.CreatePoolMemberOpts, timeout int) []lbMemberResult {
+		return []lbMemberResult{{err: failure}}
+	})
+	if !errors.Is(err, failure) {
+		t.Errorf("create() error = %v, want %v", err, failure)
+	}
+	if len(b.pending) != 0 {
+		t.Errorf("%d batches left pending", len(b.pending))
+	}
+}
+
+func TestLBMemberBatcherLockPool(t *testing.T) {
+	b := &lbMemberBatcher{}
+	unlock := b.lockPool("pool")
+
+	created := make(chan struct{})
+	go func() {
+		b.create("pool", "", lbpools.CreatePoolMemberOpts{}, 60, time.Millisecond, func(members []lbpools.CreatePoolMemberOpts, timeout int) []lbMemberResult {
+			return []lbMemberResult{{id: "id"}}
+		})
+		close(created)
+	}()
+
+	select {
+	case <-created:
+		t.Fatal("a batch was created while the pool was locked")
+	case <-time.After(50 * time.Millisecond):
+	}
+	unlock()
+	unlock()
+	<-created
+}
+
+func TestLBMemberBatcherCredentials(t *testing.T) {
+	b := &lbMemberBatcher{}
+	var mu sync.Mutex
+	timeouts := map[int]int{}
+	flush := func(members []lbpools.CreatePoolMemberOpts, timeout int) []lbMemberResult {
+		mu.Lock()
+		timeouts[len(members)] = timeout
+		mu.Unlock()
+		results := make([]lbMemberResult, len(members))
+		for i := range members {
+			results[i].id = fmt.Sprintf("member%d", i)
+		}
+		return results
+	}
+
+	var wg sync.WaitGroup
+	for _, r := range []struct {
+		credentials string
+		timeout     int
+	}{{"first", 60}, {"first", 300}, {"second", 120}} {
+		wg.Add(1)
+		go func(credentials string, timeout int) {
+			defer wg.Done()
+			if _, err := b.create("pool", credentials, lbpools.CreatePoolMemberOpts{}, timeout, 50*time.Millisecond, flush); err != nil {
+				t.Error(err)
+			}
+		}(r.credentials, r.timeout)
+	}
+	wg.Wait()
+
+	// the members of the first credentials are created together with the longest timeout, the member of the second alone
+	if want := map[int]int{2: 300, 1: 120}; fmt.Sprint(timeouts) != fmt.Sprint(want) {
+		t.Errorf("batches were flushed with the timeouts %v, want %v", timeouts, want)
+	}
+}
+
+func TestCreateLBMembersPerMemberErrors(t *testing.T) {
+	const taskID = "9d3a2c1e-8f3b-4d5c-9a7e-1b2c3d4e5f60"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/lbpools/1/2/pool":
+			fmt.Fprint(w, `{"id":"pool","name":"web","lb_algorithm":"ROUND_ROBIN","protocol":"HTTP","members":[]}`)
+		case r.Method == http.MethodPatch && r.URL.Path == "/v1/lbpools/1/2/pool":
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"message":"invalid member"}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/lbpools/1/2/pool/member":
+			var member struct {
+				ProtocolPort int `json:"protocol_port"`
+			}
+			json.NewDecoder(r.Body).Decode(&member)
+			if member.ProtocolPort == 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"message":"invalid protocol_port"}`)
+				return
+			}
+			fmt.Fprintf(w, `{"tasks":[%q]}`, taskID)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/lbpools/1/2/tasks/"+taskID:
+			fmt.Fprintf(w, `{"id":%q,"state":"FINISHED","created_on":"2024-01-01T10:00:00","created_resources":{"members":["member"]}}`, taskID)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := &gcorecloud.ServiceClient{
+		ProviderClient: &gcorecloud.ProviderClient{APIBase: server.URL + "/"},
+		Endpoint:       server.URL + "/v1/lbpools/1/2/",
+	}
+
+	results := createLBMembers(client, "pool", []lbpools.CreatePoolMemberOpts{
+		{Address: net.ParseIP("10.0.0.1"), ProtocolPort: 80},
+		{Address: net.ParseIP("10.0.0.2")},
+	}, 5)
+	if len(results) != 2 {
+		t.Fatalf("createLBMembers() returned %d results, want 2", len(results))
+	}
+	if results[0].err != nil || results[0].id != "member" {
+		t.Errorf("valid member got %+v, want ID member", results[0])
+	}
+	if results[1].err == nil || results[1].id != "" {
+		t.Errorf("invalid member got %+v, want an error", results[1])
+	}
+}
+
+func TestLBMemberCreateBatched(t *testing.T) {
+	const taskID = "9d3a2c1e-8f3b-4d5c-9a7e-1b2c3d4e5f60"
+	var mu sync.Mutex
+	var members []string
+	var updates int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/lbpools/1/2/pool":
+			fmt.Fprintf(w, `{"id":"pool","name":"web","lb_algorithm":"ROUND_ROBIN","protocol":"HTTP","members":[%s]}`, strings.Join(members, ","))
+		case r.Method == http.MethodPatch && r.URL.Path == "/v1/lbpools/1/2/pool":
+			var opts struct {
+				Members []struct {
+					Address      string `json:"address"`
+					ProtocolPort int    `json:"protocol_port"`
+				} `json:"members"`
+			}
+			json.NewDecoder(r.Body).Decode(&opts)
+			members = nil
+			for _, m := range opts.Members {
+				members = append(members, fmt.Sprintf(`{"id":"member-%d","address":%q,"protocol_port":%d,"weight":1}`, m.ProtocolPort, m.Address, m.ProtocolPort))
+			}
+			updates++
+			fmt.Fprintf(w, `{"tasks":[%q]}`, taskID)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/tasks/"+taskID:
+			fmt.Fprintf(w, `{"id":%q,"state":"FINISHED","created_on":"2024-01-01T10:00:00"}`, taskID)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	config := &Config{Provider: &gcorecloud.ProviderClient{APIBase: server.URL + "/"}, ProviderKey: "key"}
+	resource := Provider().ResourcesMap["gcore_lbmember"]
+
+	// every operation gets its own provider client from the context wrapper, the first one is cancelled
+	// while its member waits for the batch
+	firstCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var wg sync.WaitGroup
+	ids := make([]string, 3)
+	for i := range ids {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ctx := context.Background()
+			if i == 0 {
+				ctx = firstCtx
+			}
+			d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+				"project_id":    1,
+				"region_id":     2,
+				"pool_id":       "pool",
+				"address":       "10.0.0.1",
+				"protocol_port": 8080 + i,
+			})
+			resource.CreateContext(ctx, d, config)
+			ids[i] = d.Id()
+		}(i)
+	}
+	time.Sleep(lbMemberBatchWindow / 2)
+	cancel()
+	wg.Wait()
+
+	if updates != 1 {
+		t.Errorf("members were created with %d pool updates, want 1", updates)
+	}
+	for i, id := range ids {
+		if want := fmt.Sprintf("member-%d", 8080+i); id != want {
+			t.Errorf("member %d got ID %q, want %s", i, id, want)
+		}
+	}
+}
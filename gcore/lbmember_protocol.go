@@ -0,0 +1,72 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// lbMemberProtocolMismatch returns why the member port doesn't suit the protocol of the pool, or an empty string.
+// The pool protocol is what the load balancer speaks to the member, the well-known ports tell what the member expects.
+func lbMemberProtocolMismatch(protocol types.ProtocolType, port int) string {
+	switch protocol {
+	case types.ProtocolTypeHTTP:
+		if port == 443 {
+			return "the HTTP pool sends plain HTTP to the port 443 that usually expects TLS"
+		}
+	case types.ProtocolTypeHTTPS:
+		if port == 80 {
+			return "the HTTPS pool passes TLS through to the port 80 that usually serves plain HTTP"
+		}
+	case types.ProtocolTypePROXY, types.ProtocolTypePROXYV2:
+		if port == 443 {
+			return fmt.Sprintf("the %s pool prepends the PROXY protocol header to the TLS of the port 443, the member must accept it before the TLS handshake", protocol)
+		}
+	}
+	return ""
+}
+
+// validateLBMemberProtocol reads the pool of the member and checks the member port against the pool protocol,
+// so a member that can't serve the traffic of the pool fails the plan rather than the health checks after the apply.
+// The check is skipped when the pool is not known yet or can't be read.
+func validateLBMemberProtocol(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Get("ignore_protocol_mismatch").(bool) {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChange("pool_id") && !d.HasChange("protocol_port") {
+		return nil
+	}
+	for _, key := range []string{"pool_id", "protocol_port", "project_id", "project_name", "region_id", "region_name"} {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+	config, ok := meta.(*Config)
+	if !ok || config.Provider == nil {
+		return nil
+	}
+
+	poolID, port := d.Get("pool_id").(string), d.Get("protocol_port").(int)
+	client, err := createLBMemberPoolClient(config.Provider, d)
+	if err != nil {
+		log.Printf("[WARN] Skipping the protocol check of the member of the pool %s: %s", poolID, err)
+		return nil
+	}
+	pool, err := getLBPool(client, poolID)
+	if err != nil {
+		log.Printf("[WARN] Skipping the protocol check of the member of the pool %s: %s", poolID, err)
+		return nil
+	}
+	if mismatch := lbMemberProtocolMismatch(pool.Protocol, port); mismatch != "" {
+		return fmt.Errorf("protocol_port %d doesn't match the pool %s: %s. Set ignore_protocol_mismatch if it is intended", port, poolID, mismatch)
+	}
+	return nil
+}
+
+func createLBMemberPoolClient(provider *gcorecloud.ProviderClient, d *schema.ResourceDiff) (*gcorecloud.ServiceClient, error) {
+	return createDiffClient(provider, d, LBPoolsPoint, versionPointV1)
+}
@@ -0,0 +1,34 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"testing"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/types"
+)
+
+func TestLBMemberProtocolMismatch(t *testing.T) {
+	tests := []struct {
+		protocol types.ProtocolType
+		port     int
+		mismatch bool
+	}{
+		{types.ProtocolTypeHTTP, 80, false},
+		{types.ProtocolTypeHTTP, 8080, false},
+		{types.ProtocolTypeHTTP, 443, true},
+		{types.ProtocolTypeHTTPS, 443, false},
+		{types.ProtocolTypeHTTPS, 80, true},
+		{types.ProtocolTypePROXY, 80, false},
+		{types.ProtocolTypePROXY, 443, true},
+		{types.ProtocolTypePROXYV2, 443, true},
+		{types.ProtocolTypeTCP, 443, false},
+		{types.ProtocolTypeUDP, 443, false},
+	}
+	for _, tt := range tests {
+		if got := lbMemberProtocolMismatch(tt.protocol, tt.port); (got != "") != tt.mismatch {
+			t.Errorf("lbMemberProtocolMismatch(%s, %d) = %q, want mismatch %v", tt.protocol, tt.port, got, tt.mismatch)
+		}
+	}
+}
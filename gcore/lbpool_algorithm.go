@@ -0,0 +1,71 @@
+package gcore
+
+import (
+	"strings"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/lbpools"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/types"
+	"github.com/G-Core/gcorelabscloud-go/pagination"
+)
+
+const lbPoolAlgorithmSourceIPPort = "SOURCE_IP_PORT"
+
+// lbPoolAlgorithms are the balancing algorithms the API accepts for the pools,
+// the SDK doesn't know SOURCE_IP_PORT yet.
+var lbPoolAlgorithms = []string{
+	types.LoadBalancerAlgorithmRoundRobin.String(),
+	types.LoadBalancerAlgorithmLeastConnections.String(),
+	types.LoadBalancerAlgorithmSourceIP.String(),
+	lbPoolAlgorithmSourceIPPort,
+}
+
+var lbPoolAlgorithmsDescription = "Available values are '" + strings.Join(lbPoolAlgorithms, "', '") + "'"
+
+// lbPool is the pool with the algorithm read as is, the SDK fails to read the pool
+// with an algorithm it doesn't know. The algorithm goes first: the SDK decodes the embedded
+// structs of a list one by one when the first field is embedded.
+type lbPool struct {
+	LoadBalancerAlgorithm string `json:"lb_algorithm"`
+	lbpools.Pool
+}
+
+func getLBPool(client *gcorecloud.ServiceClient, id string) (*lbPool, error) {
+	var pool lbPool
+	if err := lbpools.Get(client, id).ExtractInto(&pool); err != nil {
+		return nil, err
+	}
+	return &pool, nil
+}
+
+// lbPoolPage is the page of the pools that doesn't decode the algorithm with the SDK,
+// the SDK pages decode the pools to check whether the page is empty.
+type lbPoolPage struct {
+	lbpools.PoolPage
+}
+
+func (r lbPoolPage) IsEmpty() (bool, error) {
+	pools, err := extractLBPools(r)
+	return len(pools) == 0, err
+}
+
+func extractLBPools(r pagination.Page) ([]lbPool, error) {
+	var pools []lbPool
+	err := r.(lbPoolPage).Result.ExtractIntoSlicePtr(&pools, "results")
+	return pools, err
+}
+
+func listLBPools(client *gcorecloud.ServiceClient, opts lbpools.ListOpts) ([]lbPool, error) {
+	query, err := opts.ToLBPoolListQuery()
+	if err != nil {
+		return nil, err
+	}
+	pager := pagination.NewPager(client, client.ServiceURL()+query, func(r pagination.PageResult) pagination.Page {
+		return lbPoolPage{lbpools.PoolPage{LinkedPageBase: pagination.LinkedPageBase{PageResult: r}}}
+	})
+	page, err := pager.AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return extractLBPools(page)
+}
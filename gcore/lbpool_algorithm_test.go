@@ -0,0 +1,111 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/lbpools"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestLBPoolAlgorithmValidation(t *testing.T) {
+	validate := resourceLBPool().Schema["lb_algorithm"].ValidateDiagFunc
+	for _, algorithm := range []string{"ROUND_ROBIN", "LEAST_CONNECTIONS", "SOURCE_IP", "SOURCE_IP_PORT"} {
+		if diags := validate(algorithm, cty.Path{}); diags.HasError() {
+			t.Errorf("lb_algorithm %s is rejected: %v", algorithm, diags)
+		}
+	}
+	if diags := validate("RANDOM", cty.Path{}); !diags.HasError() {
+		t.Error("lb_algorithm RANDOM is accepted")
+	}
+}
+
+func TestGetLBPoolUnknownAlgorithm(t *testing.T) {
+	const pool = `{"id":"pool","name":"web","lb_algorithm":"SOURCE_IP_PORT","protocol":"TCP","members":[{"id":"member","protocol_port":80}]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/lbpools/1/2/pool":
+			fmt.Fprint(w, pool)
+		case "/v1/lbpools/1/2":
+			fmt.Fprintf(w, `{"count":1,"results":[%s]}`, pool)
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := &gcorecloud.ServiceClient{
+		ProviderClient: &gcorecloud.ProviderClient{APIBase: server.URL + "/"},
+		Endpoint:       server.URL + "/v1/lbpools/1/2/",
+	}
+
+	if _, err := lbpools.Get(client, "pool").Extract(); err == nil {
+		t.Fatal("the SDK reads SOURCE_IP_PORT, getLBPool is not needed anymore")
+	}
+
+	got, err := getLBPool(client, "pool")
+	if err != nil {
+		t.Fatalf("getLBPool() error = %v", err)
+	}
+	if got.LoadBalancerAlgorithm != lbPoolAlgorithmSourceIPPort || got.Name != "web" || len(got.Members) != 1 {
+		t.Errorf("getLBPool() = %+v", got)
+	}
+
+	pools, err := listLBPools(client, lbpools.ListOpts{})
+	if err != nil {
+		t.Fatalf("listLBPools() error = %v", err)
+	}
+	if len(pools) != 1 || pools[0].LoadBalancerAlgorithm != lbPoolAlgorithmSourceIPPort || pools[0].ID != "pool" {
+		t.Errorf("listLBPools() = %+v", pools)
+	}
+}
+
+func TestLBPoolImportMembers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/v1/lbpools/1/2/pool" {
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"id":"pool","name":"web","lb_algorithm":"ROUND_ROBIN","protocol":"HTTP",`+
+			`"members":[{"id":"member","address":"10.0.0.1","protocol_port":8080,"weight":5,"operating_status":"ONLINE"}]}`)
+	}))
+	defer server.Close()
+	config := &Config{Provider: &gcorecloud.ProviderClient{APIBase: server.URL + "/"}}
+
+	r := resourceLBPool()
+	d := r.TestResourceData()
+	d.SetId("1:2:pool")
+	imported, err := r.Importer.StateContext(context.Background(), d, config)
+	if err != nil {
+		t.Fatalf("import error = %v", err)
+	}
+	members := imported[0].Get("member").(*schema.Set).List()
+	if len(members) != 1 {
+		t.Fatalf("imported %d members, want 1", len(members))
+	}
+	if m := members[0].(map[string]interface{}); m["id"] != "member" || m["address"] != "10.0.0.1" || m["weight"] != 5 {
+		t.Errorf("imported member %v", m)
+	}
+}
+
+func TestLBPoolMemberWeightValidation(t *testing.T) {
+	member := resourceLBPool().Schema["member"].Elem.(*schema.Resource)
+	validate := member.Schema["weight"].ValidateFunc
+	if _, errs := validate(0, "weight"); len(errs) == 0 {
+		t.Error("weight 0 is accepted, the pool update doesn't send it")
+	}
+	if _, errs := validate(256, "weight"); len(errs) != 0 {
+		t.Errorf("weight 256 is rejected: %v", errs)
+	}
+}
@@ -0,0 +1,78 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/instances"
+	"github.com/G-Core/gcorelabscloud-go/gcore/network/v1/networks"
+	"github.com/G-Core/gcorelabscloud-go/gcore/reservedfixedip/v1/reservedfixedips"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// validateLoadBalancerInternal checks that the internal load balancer has its VIP in a given network or port,
+// the load balancer without them gets the VIP in the public network.
+func validateLoadBalancerInternal(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.Get("internal").(bool) {
+		return nil
+	}
+	raw := d.GetRawConfig()
+	if raw.IsNull() || !raw.IsKnown() {
+		return nil
+	}
+	if raw.GetAttr("vip_network_id").IsNull() && raw.GetAttr("vip_port_id").IsNull() {
+		return fmt.Errorf("internal load balancer requires vip_network_id or vip_port_id of a private network")
+	}
+	return nil
+}
+
+// checkLoadBalancerInternalVIP checks that the VIP network or port of the internal load balancer is not external,
+// so it is not exposed to the internet by mistake.
+func checkLoadBalancerInternalVIP(config *Config, d *schema.ResourceData) error {
+	if networkID := d.Get("vip_network_id").(string); networkID != "" {
+		client, err := CreateClient(config.Provider, d, networksPoint, versionPointV1)
+		if err != nil {
+			return err
+		}
+		network, err := networks.Get(client, networkID).Extract()
+		if err != nil {
+			return fmt.Errorf("get VIP network %s: %w", networkID, err)
+		}
+		if network.External {
+			return fmt.Errorf("VIP network %s of the internal load balancer is external, use a private network", networkID)
+		}
+	}
+	if portID := d.Get("vip_port_id").(string); portID != "" {
+		client, err := CreateClient(config.Provider, d, reservedFixedIPsPoint, versionPointV1)
+		if err != nil {
+			return err
+		}
+		port, err := reservedfixedips.Get(client, portID).Extract()
+		if err != nil {
+			return fmt.Errorf("get VIP port %s: %w", portID, err)
+		}
+		if port.IsExternal {
+			return fmt.Errorf("VIP port %s of the internal load balancer is external, use a port of a private network", portID)
+		}
+	}
+	return nil
+}
+
+// loadBalancerInternalDiagnostics warns about the floating IPs assigned to the internal load balancer,
+// e.g. with gcore_floatingip, which expose it to the internet.
+func loadBalancerInternalDiagnostics(lbID string, floatingIPs []instances.FloatingIP) diag.Diagnostics {
+	if len(floatingIPs) == 0 {
+		return nil
+	}
+	addresses := make([]string, 0, len(floatingIPs))
+	for _, fip := range floatingIPs {
+		addresses = append(addresses, fip.FloatingIPAddress.String())
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Internal load balancer %s has floating IPs", lbID),
+		Detail:   fmt.Sprintf("Floating IPs %s are assigned to the internal load balancer %s and expose it to the internet.", strings.Join(addresses, ", "), lbID),
+	}}
+}
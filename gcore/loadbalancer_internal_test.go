@@ -0,0 +1,66 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/instances"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestCheckLoadBalancerInternalVIP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/networks/1/2/private":
+			fmt.Fprint(w, `{"id":"private","external":false}`)
+		case "/v1/networks/1/2/public":
+			fmt.Fprint(w, `{"id":"public","external":true}`)
+		case "/v1/reserved_fixed_ips/1/2/port":
+			fmt.Fprint(w, `{"port_id":"port","is_external":true}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	config := &Config{Provider: &gcorecloud.ProviderClient{APIBase: server.URL + "/"}}
+
+	tests := []struct {
+		name    string
+		raw     map[string]interface{}
+		wantErr bool
+	}{
+		{"private network", map[string]interface{}{"vip_network_id": "private"}, false},
+		{"external network", map[string]interface{}{"vip_network_id": "public"}, true},
+		{"external port", map[string]interface{}{"vip_port_id": "port"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.raw["project_id"], tt.raw["region_id"], tt.raw["name"], tt.raw["internal"] = 1, 2, "lb", true
+			d := schema.TestResourceDataRaw(t, resourceLoadBalancerV2().Schema, tt.raw)
+			err := checkLoadBalancerInternalVIP(config, d)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkLoadBalancerInternalVIP() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadBalancerInternalDiagnostics(t *testing.T) {
+	if diags := loadBalancerInternalDiagnostics("lb", nil); diags != nil {
+		t.Errorf("loadBalancerInternalDiagnostics() = %v, want no warning without floating IPs", diags)
+	}
+	diags := loadBalancerInternalDiagnostics("lb", []instances.FloatingIP{{FloatingIPAddress: net.ParseIP("203.0.113.10")}})
+	if len(diags) != 1 || !strings.Contains(diags[0].Detail, "203.0.113.10") {
+		t.Errorf("loadBalancerInternalDiagnostics() = %v, want the floating IP reported", diags)
+	}
+}
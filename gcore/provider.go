@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"regexp"
+	"time"
 
 	dnssdk "github.com/G-Core/gcore-dns-sdk-go"
 	storageSDK "github.com/G-Core/gcore-storage-sdk-go"
@@ -16,6 +18,7 @@ import (
 	gc "github.com/G-Core/gcorelabscloud-go/gcore"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform/version"
 )
 
@@ -23,12 +26,15 @@ const (
 	ProviderOptPermanentToken    = "permanent_api_token"
 	ProviderOptSkipCredsAuthErr  = "ignore_creds_auth_error"
 	ProviderOptSingleApiEndpoint = "api_endpoint"
+	ProviderOptNamePrefix        = "name_prefix"
+	ProviderOptNameSuffix        = "name_suffix"
+	ProviderOptNameRegex         = "name_regex"
 
 	lifecyclePolicyResource = "gcore_lifecyclepolicy"
 )
 
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"user_name": {
 				Type:     schema.TypeString,
@@ -120,10 +126,101 @@ func Provider() *schema.Provider {
 				Description: "Client id",
 				DefaultFunc: schema.EnvDefaultFunc("GCORE_CLIENT_ID", ""),
 			},
+			ProviderOptNamePrefix: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Prefix added to the names of the cloud resources (instances, volumes, networks, subnets, routers, load balancers, listeners, pools, security groups and server groups) on creation.",
+				DefaultFunc: schema.EnvDefaultFunc("GCORE_NAME_PREFIX", ""),
+			},
+			ProviderOptNameSuffix: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Suffix added to the names of the cloud resources (instances, volumes, networks, subnets, routers, load balancers, listeners, pools, security groups and server groups) on creation.",
+				DefaultFunc: schema.EnvDefaultFunc("GCORE_NAME_SUFFIX", ""),
+			},
+			ProviderOptNameRegex: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Regular expression the names of the cloud resources (including `name_prefix` and `name_suffix`) must match. Names are checked at plan time.",
+				DefaultFunc:  schema.EnvDefaultFunc("GCORE_NAME_REGEX", ""),
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			ProviderOptMaxRetries: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3,
+				Description:  "Number of retries of the cloud and DNS API requests rejected by the rate limit (429) or failed with a transient gateway error (502, 503, 504, only for GET, PUT and DELETE requests). 0 disables the retries.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			ProviderOptRetryBackoff: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				Description:  "Delay in seconds before the first retry, it doubles with every next retry up to a minute. Retry-After of the API response takes precedence.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			ProviderOptRequestsPerSecond: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Maximum number of the cloud, CDN and DNS API requests per second sent by the provider. 0 means no limit.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			ProviderOptUserAgentSuffix: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Suffix appended to the User-Agent of the API requests, e.g. the name of the pipeline.",
+				DefaultFunc: schema.EnvDefaultFunc("GCORE_USER_AGENT_SUFFIX", ""),
+			},
+			ProviderOptRequestIDPrefix: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Prefix of the unique X-Client-Request-Id header set for the cloud, CDN and DNS API requests. The header isn't sent if the prefix is empty.",
+				DefaultFunc: schema.EnvDefaultFunc("GCORE_REQUEST_ID_PREFIX", ""),
+			},
+			ProviderOptTolerateRegionOutage: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Description: "Keep the state of the resources whose refresh fails with 503 Service Unavailable of the cloud or DNS API, e.g. during the maintenance of a region, " +
+					"and warn instead of failing the whole refresh. The data sources still fail, they have no state to keep.",
+				DefaultFunc: schema.EnvDefaultFunc("GCORE_TOLERATE_REGION_OUTAGE", false),
+			},
+			ProviderOptApplyMetricsPath: {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Path of the JSON file the provider writes the performance summary of the run to: the durations of the resource operations, " +
+					"of the cloud and DNS API requests and of the cloud tasks, with the slowest operations. The file is rewritten after every operation, " +
+					"so it holds the summary of the apply when terraform stops the provider. Nothing is recorded if the path is not set.",
+				DefaultFunc: schema.EnvDefaultFunc("GCORE_APPLY_METRICS_PATH", ""),
+			},
+			ProviderOptFeatures: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Opt-in provider features.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						ProviderFeatureVolumeSnapshots: {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Read the snapshots of the volumes into the snapshots attribute of gcore_volume, it takes a request per volume on every refresh.",
+						},
+						ProviderFeatureK8sNodeCount: {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Read the number of active node instances into the active_node_count attribute of the gcore_k8sv2 pools, it takes a request per pool on every refresh.",
+						},
+					},
+				},
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"gcore_ai_cluster":          resourceAICluster(),
+			"gcore_api_request":         resourceAPIRequest(),
 			"gcore_volume":              resourceVolume(),
+			"gcore_volume_attachment":   resourceVolumeAttachment(),
 			"gcore_network":             resourceNetwork(),
 			"gcore_subnet":              resourceSubnet(),
 			"gcore_router":              resourceRouter(),
@@ -160,16 +257,23 @@ func Provider() *schema.Provider {
 			"gcore_cdn_sslcert":         resourceCDNCert(),
 			lifecyclePolicyResource:     resourceLifecyclePolicy(),
 			"gcore_ddos_protection":     resourceDDoSProtection(),
+			"gcore_waap_domain":         resourceWAAPDomain(),
+			"gcore_waap_api_path":       resourceWAAPAPIPath(),
+			"gcore_waap_api_discovery":  resourceWAAPAPIDiscovery(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"gcore_ai_cluster":             dataSourceAICluster(),
+			"gcore_api_request":            dataSourceAPIRequest(),
+			"gcore_account":                dataSourceAccount(),
 			"gcore_project":                dataSourceProject(),
 			"gcore_region":                 dataSourceRegion(),
 			"gcore_securitygroup":          dataSourceSecurityGroup(),
 			"gcore_image":                  dataSourceImage(),
 			"gcore_volume":                 dataSourceVolume(),
+			"gcore_volume_list":            dataSourceVolumeList(),
 			"gcore_network":                dataSourceNetwork(),
 			"gcore_subnet":                 dataSourceSubnet(),
+			"gcore_subnet_ip_availability": dataSourceSubnetIPAvailability(),
 			"gcore_router":                 dataSourceRouter(),
 			"gcore_loadbalancer":           dataSourceLoadBalancer(),
 			"gcore_loadbalancerv2":         dataSourceLoadBalancerV2(),
@@ -194,9 +298,50 @@ func Provider() *schema.Provider {
 			"gcore_ddos_profile_template":  dataSourceDDoSProfileTemplate(),
 			"gcore_cdn_shielding_location": dataOriginShieldingLocation(),
 			"gcore_cdn_preset":             dataPreset(),
+			"gcore_cdn_ip_whitelist":       dataCDNIPWhitelist(),
+			"gcore_cdn_resource_stats":     dataCDNResourceStats(),
+			"gcore_cdn_rules":              dataCDNRules(),
+			"gcore_cdn_origin_group":       dataCDNOriginGroup(),
+			"gcore_service_security_rules": dataSourceServiceSecurityRules(),
+			"gcore_dns_zone_delegation":    dataSourceDNSZoneDelegation(),
+			"gcore_dns_zone_stats":         dataSourceDNSZoneStats(),
+			"gcore_cost_report":            dataSourceCostReport(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
+
+	for name, r := range p.ResourcesMap {
+		withRequestContext(r)
+		r.ReadContext = tolerateRegionOutage(r.ReadContext)
+		withApplyMetrics(name, r)
+	}
+	for name, r := range p.DataSourcesMap {
+		withRequestContext(r)
+		withApplyMetrics("data."+name, r)
+	}
+
+	return p
+}
+
+// withRequestContext makes the cloud API calls of the resource use the context of the CRUD operation.
+// The context carries the operation timeout, so a hung API call cannot stall an apply past it.
+func withRequestContext(r *schema.Resource) {
+	wrap := func(f schema.CreateContextFunc) schema.CreateContextFunc {
+		if f == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			if config, ok := m.(*Config); ok {
+				m = config.withContext(ctx)
+			}
+			return f(ctx, d, m)
+		}
+	}
+
+	r.CreateContext = wrap(r.CreateContext)
+	r.ReadContext = schema.ReadContextFunc(wrap(schema.CreateContextFunc(r.ReadContext)))
+	r.UpdateContext = schema.UpdateContextFunc(wrap(schema.CreateContextFunc(r.UpdateContext)))
+	r.DeleteContext = schema.DeleteContextFunc(wrap(schema.CreateContextFunc(r.DeleteContext)))
 }
 
 func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
@@ -248,29 +393,58 @@ func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{},
 
 	clientID := d.Get("gcore_client_id").(string)
 
-	var err error
-	var provider *gcorecloud.ProviderClient
-	if permanentToken != "" {
-		provider, err = gc.APITokenClient(gcorecloud.APITokenOptions{
-			APIURL:   cloudApi,
-			APIToken: permanentToken,
-		})
-	} else {
-		provider, err = gc.AuthenticatedClient(gcorecloud.AuthOptions{
-			APIURL:      cloudApi,
-			AuthURL:     platform,
-			Username:    username,
-			Password:    password,
-			AllowReauth: true,
-			ClientID:    clientID,
-		})
+	maxRetries := d.Get(ProviderOptMaxRetries).(int)
+	retryBackoff := d.Get(ProviderOptRetryBackoff).(int)
+	requestsPerSecond := d.Get(ProviderOptRequestsPerSecond).(int)
+	retrying := newRetryTransport(nil, maxRetries, time.Duration(retryBackoff)*time.Second, requestsPerSecond)
+	tagger := requestTagger{
+		userAgentSuffix: d.Get(ProviderOptUserAgentSuffix).(string),
+		requestIDPrefix: d.Get(ProviderOptRequestIDPrefix).(string),
 	}
+	// the metrics transport is outermost to time the retries and the rate limit waits too,
+	// it only records the requests of the operations of the providers writing the metrics
+	transport := newMetricsTransport(newTaggingTransport(retrying, tagger))
+
+	clientKey := providerClientKey(cloudApi, platform, permanentToken, username, password, clientID,
+		fmt.Sprintf("%d/%d/%d/%s/%s", maxRetries, retryBackoff, requestsPerSecond, tagger.userAgentSuffix, tagger.requestIDPrefix))
+	cached, err := providerClients.get(clientKey, func() (interface{}, error) {
+		var client *gcorecloud.ProviderClient
+		var err error
+		if permanentToken != "" {
+			client, err = gc.APITokenClient(gcorecloud.APITokenOptions{
+				APIURL:   cloudApi,
+				APIToken: permanentToken,
+			})
+		} else {
+			client, err = gc.AuthenticatedClient(gcorecloud.AuthOptions{
+				APIURL:      cloudApi,
+				AuthURL:     platform,
+				Username:    username,
+				Password:    password,
+				AllowReauth: true,
+				ClientID:    clientID,
+			})
+		}
+		if err != nil {
+			return nil, err
+		}
+		client.HTTPClient.Transport = transport
+		return client, nil
+	})
+	var provider *gcorecloud.ProviderClient
 	if err != nil {
 		provider = &gcorecloud.ProviderClient{}
 		log.Printf("[ERROR] init auth client: %s\n", err)
+	} else {
+		provider = cached.(*gcorecloud.ProviderClient)
 	}
 
 	cdnProvider := gcdnProvider.NewClient(cdnAPI, gcdnProvider.WithSignerFunc(func(req *http.Request) error {
+		// the CDN client doesn't accept a transport, so only the rate limit and the tags apply to it
+		if err := retrying.limiter.wait(req.Context()); err != nil {
+			return err
+		}
+		tagger.tag(req)
 		for k, v := range provider.AuthenticatedHeaders() {
 			req.Header.Set(k, v)
 		}
@@ -281,8 +455,23 @@ func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{},
 
 	provider.SetDebug(os.Getenv("TF_LOG") == "DEBUG")
 	config := Config{
-		Provider:  provider,
-		CDNClient: cdnService,
+		Provider:             provider,
+		APIEndpoint:          apiEndpoint,
+		PlatformAPI:          platform,
+		CDNClient:            cdnService,
+		CDNRequester:         cdnProvider,
+		NamePrefix:           d.Get(ProviderOptNamePrefix).(string),
+		NameSuffix:           d.Get(ProviderOptNameSuffix).(string),
+		Features:             providerFeaturesFromSchema(d),
+		TolerateRegionOutage: d.Get(ProviderOptTolerateRegionOutage).(bool),
+		ApplyMetrics:         getApplyMetrics(d.Get(ProviderOptApplyMetricsPath).(string)),
+		ProviderKey:          clientKey,
+	}
+	if nameRegex := d.Get(ProviderOptNameRegex).(string); nameRegex != "" {
+		config.NameRegex, err = regexp.Compile(nameRegex)
+		if err != nil {
+			return nil, diag.FromErr(fmt.Errorf("name regex: %w", err))
+		}
 	}
 
 	userAgent := fmt.Sprintf("terraform/%s", version.Version)
@@ -291,12 +480,17 @@ func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{},
 		if err != nil {
 			return nil, diag.FromErr(fmt.Errorf("storage api url: %w", err))
 		}
+		// the storage client doesn't accept a transport, so only the User-Agent suffix applies to it
+		storageUserAgent := userAgent
+		if tagger.userAgentSuffix != "" {
+			storageUserAgent += " " + tagger.userAgentSuffix
+		}
 		config.StorageClient = storageSDK.NewSDK(
 			stHost,
 			stPath,
 			storageSDK.WithBearerAuth(provider.AccessToken),
 			storageSDK.WithPermanentTokenAuth(func() string { return permanentToken }),
-			storageSDK.WithUserAgent(userAgent),
+			storageSDK.WithUserAgent(storageUserAgent),
 		)
 	}
 	if dnsAPI != "" {
@@ -316,7 +510,11 @@ func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{},
 			},
 			func(client *dnssdk.Client) {
 				client.UserAgent = userAgent
+			},
+			func(client *dnssdk.Client) {
+				client.HTTPClient.Transport = transport
 			})
+		config.DNSAuthHeader = func() string { return string(authorizer()) }
 	}
 
 	return &config, diags
@@ -0,0 +1,30 @@
+package gcore
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	ProviderOptFeatures            = "features"
+	ProviderFeatureVolumeSnapshots = "volume_snapshots"
+	ProviderFeatureK8sNodeCount    = "k8s_active_node_count"
+)
+
+// providerFeatures are the opt-in provider behaviours configured in the features block.
+type providerFeatures struct {
+	VolumeSnapshots bool
+	K8sNodeCount    bool
+}
+
+func providerFeaturesFromSchema(d *schema.ResourceData) providerFeatures {
+	var features providerFeatures
+	for _, v := range d.Get(ProviderOptFeatures).([]interface{}) {
+		f, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		features.VolumeSnapshots = f[ProviderFeatureVolumeSnapshots].(bool)
+		features.K8sNodeCount = f[ProviderFeatureK8sNodeCount].(bool)
+	}
+	return features
+}
@@ -0,0 +1,31 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestProviderFeaturesVolumeSnapshots(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"features": []interface{}{map[string]interface{}{"volume_snapshots": true}},
+	})
+	if features := providerFeaturesFromSchema(d); !features.VolumeSnapshots {
+		t.Errorf("providerFeaturesFromSchema() = %+v, want the volume snapshots", features)
+	}
+	if features := providerFeaturesFromSchema(schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{})); features.VolumeSnapshots {
+		t.Error("providerFeaturesFromSchema() reads the volume snapshots without the feature")
+	}
+}
+
+func TestProviderFeaturesK8sNodeCount(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"features": []interface{}{map[string]interface{}{"k8s_active_node_count": true}},
+	})
+	if features := providerFeaturesFromSchema(d); !features.K8sNodeCount || features.VolumeSnapshots {
+		t.Errorf("providerFeaturesFromSchema() = %+v, want only the k8s node count", features)
+	}
+}
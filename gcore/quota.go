@@ -0,0 +1,176 @@
+package gcore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	gc "github.com/G-Core/gcorelabscloud-go/gcore"
+	"github.com/G-Core/gcorelabscloud-go/gcore/quota/v2/quotas"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+const (
+	quotasPoint = "client_quotas"
+
+	quotaLimitSuffix = "_limit"
+	quotaUsageSuffix = "_usage"
+)
+
+type exceededQuota struct {
+	Name  string
+	Usage int
+	Limit int
+}
+
+func (q exceededQuota) String() string {
+	return fmt.Sprintf("%s: usage %d, limit %d", q.Name, q.Usage, q.Limit)
+}
+
+// isQuotaExceededError checks if the API rejected a request because of the quota limits:
+// a client error response whose exception_class names a quota.
+// The failed tasks only have a free text error, so they are not matched.
+func isQuotaExceededError(err error) bool {
+	var resp gcorecloud.ErrUnexpectedResponseCode
+	var err400 gcorecloud.ErrDefault400
+	var err403 gcorecloud.ErrDefault403
+	var err409 gcorecloud.ErrDefault409
+	switch {
+	case errors.As(err, &err400):
+		resp = err400.ErrUnexpectedResponseCode
+	case errors.As(err, &err403):
+		resp = err403.ErrUnexpectedResponseCode
+	case errors.As(err, &err409):
+		resp = err409.ErrUnexpectedResponseCode
+	default:
+		return false
+	}
+
+	var body gcorecloud.GcoreErrorType
+	if err := json.Unmarshal(resp.Body, &body); err != nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(body.ExceptionClass), "quota")
+}
+
+// retryOnQuotaExceeded runs create and, if waitForQuota is set, repeats it while it fails on quota limits.
+func retryOnQuotaExceeded(ctx context.Context, waitForQuota bool, timeout time.Duration, create func() error) error {
+	if !waitForQuota {
+		return create()
+	}
+
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		err := create()
+		if err == nil {
+			return nil
+		}
+		if isQuotaExceededError(err) {
+			log.Printf("[DEBUG] Quota exceeded, waiting for it to be released: %s", err)
+			return retry.RetryableError(err)
+		}
+		return retry.NonRetryableError(err)
+	})
+}
+
+// quotaExceededDiagnostics converts a create error into diagnostics.
+// For the quota errors the diagnostic details contain usage and limit of the affected quotas.
+func quotaExceededDiagnostics(provider *gcorecloud.ProviderClient, regionID int, err error) diag.Diagnostics {
+	if !isQuotaExceededError(err) {
+		return diag.FromErr(err)
+	}
+
+	detail := err.Error()
+	exceeded, qErr := findExceededQuotas(provider, regionID, err.Error())
+	switch {
+	case qErr != nil:
+		log.Printf("[WARN] Cannot get quotas: %s", qErr)
+		detail += "\n\nCannot get current quota usage: " + qErr.Error()
+	case len(exceeded) > 0:
+		lines := make([]string, len(exceeded))
+		for i, q := range exceeded {
+			lines[i] = q.String()
+		}
+		detail += "\n\nExceeded quotas:\n" + strings.Join(lines, "\n")
+	}
+	detail += "\n\nSet `wait_for_quota` to wait until the quota is released, or request a quota increase."
+
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Quota exceeded",
+			Detail:   detail,
+		},
+	}
+}
+
+// findExceededQuotas returns global and regional quotas related to the message.
+// When the message doesn't mention any quota all exhausted quotas are returned.
+func findExceededQuotas(provider *gcorecloud.ProviderClient, regionID int, message string) ([]exceededQuota, error) {
+	client, err := gc.ClientServiceFromProvider(provider, gcorecloud.EndpointOpts{
+		Name:    quotasPoint,
+		Region:  0,
+		Project: 0,
+		Version: versionPointV2,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	combined, err := quotas.ListCombined(client, nil).Extract()
+	if err != nil {
+		return nil, err
+	}
+
+	all := collectQuotas(combined.GlobalQuotas)
+	for _, regional := range combined.RegionalQuotas {
+		if regional["region_id"] == regionID {
+			all = append(all, collectQuotas(regional)...)
+		}
+	}
+
+	return filterExceededQuotas(all, message), nil
+}
+
+func collectQuotas(q quotas.Quota) []exceededQuota {
+	result := make([]exceededQuota, 0, len(q)/2)
+	for key, limit := range q {
+		if !strings.HasSuffix(key, quotaLimitSuffix) {
+			continue
+		}
+		name := strings.TrimSuffix(key, quotaLimitSuffix)
+		usage, ok := q[name+quotaUsageSuffix]
+		if !ok {
+			continue
+		}
+		result = append(result, exceededQuota{Name: name, Usage: usage, Limit: limit})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+func filterExceededQuotas(all []exceededQuota, message string) []exceededQuota {
+	message = strings.ToLower(message)
+	var mentioned, exhausted []exceededQuota
+	for _, q := range all {
+		pattern := `\b(` + regexp.QuoteMeta(q.Name) + `|` + regexp.QuoteMeta(strings.ReplaceAll(q.Name, "_", " ")) + `)\b`
+		if regexp.MustCompile(pattern).MatchString(message) {
+			mentioned = append(mentioned, q)
+			continue
+		}
+		if q.Limit > 0 && q.Usage >= q.Limit {
+			exhausted = append(exhausted, q)
+		}
+	}
+	if len(mentioned) > 0 {
+		return mentioned
+	}
+	return exhausted
+}
@@ -0,0 +1,105 @@
+package gcore
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/quota/v2/quotas"
+)
+
+func TestIsQuotaExceededError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "nil error",
+			err:  nil,
+			want: false,
+		},
+		{
+			name: "quota error",
+			err: gcorecloud.ErrDefault400{ErrUnexpectedResponseCode: gcorecloud.ErrUnexpectedResponseCode{
+				Actual: 400,
+				Body:   []byte(`{"exception_class": "QuotaLimitExceeded", "message": "Quota exceeded for cpu_count"}`),
+			}},
+			want: true,
+		},
+		{
+			name: "wrapped quota error",
+			err: fmt.Errorf("create instance: %w", gcorecloud.ErrDefault403{ErrUnexpectedResponseCode: gcorecloud.ErrUnexpectedResponseCode{
+				Actual: 403,
+				Body:   []byte(`{"exception_class": "QuotaLimitExceeded", "message": "Quota exceeded"}`),
+			}}),
+			want: true,
+		},
+		{
+			name: "other error mentioning quota",
+			err: gcorecloud.ErrDefault400{ErrUnexpectedResponseCode: gcorecloud.ErrUnexpectedResponseCode{
+				Actual: 400,
+				Body:   []byte(`{"exception_class": "ValidationError", "message": "quota_id is not a valid field"}`),
+			}},
+			want: false,
+		},
+		{
+			name: "task error",
+			err:  errors.New("task failed: Quota exceeded for cpu_count"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isQuotaExceededError(tt.err); got != tt.want {
+				t.Errorf("isQuotaExceededError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterExceededQuotas(t *testing.T) {
+	q := collectQuotas(quotas.Quota{
+		"region_id":                1,
+		"cpu_count_limit":          10,
+		"cpu_count_usage":          8,
+		"ram_limit":                4096,
+		"ram_usage":                4096,
+		"volume_count_limit":       0,
+		"volume_count_usage":       0,
+		"external_ip_count_limit":  2,
+		"external_ip_count_usage":  1,
+		"image_size_without_usage": 1,
+	})
+
+	tests := []struct {
+		name    string
+		message string
+		want    []exceededQuota
+	}{
+		{
+			name:    "quota mentioned by name",
+			message: "Quota exceeded for cpu_count",
+			want:    []exceededQuota{{Name: "cpu_count", Usage: 8, Limit: 10}},
+		},
+		{
+			name:    "quota mentioned in text",
+			message: "Not enough External IP count quota",
+			want:    []exceededQuota{{Name: "external_ip_count", Usage: 1, Limit: 2}},
+		},
+		{
+			name:    "no quota mentioned",
+			message: "Quota exceeded",
+			want:    []exceededQuota{{Name: "ram", Usage: 4096, Limit: 4096}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := filterExceededQuotas(q, tt.message); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filterExceededQuotas() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const ProviderOptTolerateRegionOutage = "tolerate_region_outage"
+
+type regionOutageKey struct{}
+
+// regionOutage collects the requests of an operation answered with 503 Service Unavailable after all retries.
+type regionOutage struct {
+	mu       sync.Mutex
+	requests []string
+}
+
+func withRegionOutage(ctx context.Context) (context.Context, *regionOutage) {
+	outage := &regionOutage{}
+	return context.WithValue(ctx, regionOutageKey{}, outage), outage
+}
+
+// markRegionOutage records the unavailable request if the operation tolerates the outage.
+func markRegionOutage(req *http.Request) {
+	outage, ok := req.Context().Value(regionOutageKey{}).(*regionOutage)
+	if !ok {
+		return
+	}
+	outage.mu.Lock()
+	outage.requests = append(outage.requests, req.Method+" "+req.URL.Path)
+	outage.mu.Unlock()
+}
+
+func (o *regionOutage) unavailable() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.requests
+}
+
+// tolerateRegionOutage makes the failed read of the resource a warning when the API answered 503,
+// the SDK keeps the state of the resource then, so an unavailable region doesn't fail the refresh
+// of the resources in the other regions. Other failures are returned as is.
+func tolerateRegionOutage(read schema.ReadContextFunc) schema.ReadContextFunc {
+	if read == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		config, ok := m.(*Config)
+		if !ok || !config.TolerateRegionOutage {
+			return read(ctx, d, m)
+		}
+
+		ctx, outage := withRegionOutage(ctx)
+		diags := read(ctx, d, m)
+		requests := outage.unavailable()
+		if !diags.HasError() || len(requests) == 0 {
+			return diags
+		}
+		log.Printf("[WARN] Keeping the state of %s, the API is unavailable: %s", d.Id(), strings.Join(requests, ", "))
+		return regionOutageDiagnostics(d.Id(), requests, diags)
+	}
+}
+
+// regionOutageDiagnostics turns the errors of the read into the warning, the other diagnostics are kept.
+func regionOutageDiagnostics(id string, requests []string, diags diag.Diagnostics) diag.Diagnostics {
+	result := make(diag.Diagnostics, 0, len(diags))
+	var errs []string
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			errs = append(errs, d.Summary)
+			continue
+		}
+		result = append(result, d)
+	}
+	return append(result, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Resource %s is not refreshed, the API is unavailable", id),
+		Detail: fmt.Sprintf("The API answered 503 Service Unavailable to %s, e.g. because of the maintenance of the region. "+
+			"The state of the resource is kept as is. Read errors: %s", strings.Join(requests, ", "), strings.Join(errs, "; ")),
+	})
+}
@@ -0,0 +1,71 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestTolerateRegionOutage(t *testing.T) {
+	status := http.StatusServiceUnavailable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: newRetryTransport(nil, 0, 0, 0)}
+
+	read := tolerateRegionOutage(func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/v1/networks/1/2/id", nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		resp.Body.Close()
+		return diag.Errorf("unexpected status %d", resp.StatusCode)
+	})
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+	d.SetId("id")
+
+	tests := []struct {
+		name     string
+		tolerate bool
+		status   int
+		wantErr  bool
+	}{
+		{"maintenance tolerated", true, http.StatusServiceUnavailable, false},
+		{"maintenance not tolerated", false, http.StatusServiceUnavailable, true},
+		{"other failure", true, http.StatusInternalServerError, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status = tt.status
+			diags := read(context.Background(), d, &Config{TolerateRegionOutage: tt.tolerate})
+			if diags.HasError() != tt.wantErr {
+				t.Fatalf("read() = %v, wantErr %v", diags, tt.wantErr)
+			}
+			if !tt.wantErr && (len(diags) != 1 || !strings.Contains(diags[0].Detail, "GET /v1/networks/1/2/id")) {
+				t.Errorf("read() = %v, want the warning with the unavailable request", diags)
+			}
+		})
+	}
+}
+
+func TestRegionOutageDiagnostics(t *testing.T) {
+	diags := diag.Diagnostics{
+		{Severity: diag.Warning, Summary: "flavor suggestion"},
+		{Severity: diag.Error, Summary: fmt.Sprintf("status %d", http.StatusServiceUnavailable)},
+	}
+	got := regionOutageDiagnostics("id", []string{"GET /v1/networks"}, diags)
+	if got.HasError() || len(got) != 2 || got[0].Summary != "flavor suggestion" || !strings.Contains(got[1].Detail, "status 503") {
+		t.Errorf("regionOutageDiagnostics() = %v, want the other warnings kept and the error in the detail", got)
+	}
+}
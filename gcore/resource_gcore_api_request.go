@@ -0,0 +1,183 @@
+package gcore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var (
+	apiRequestMethods      = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+	apiRequestPathValidate = validation.StringMatch(regexp.MustCompile(`^/`), "must start with '/', e.g. '/cloud/v1/projects'")
+)
+
+func resourceAPIRequest() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAPIRequestCreate,
+		ReadContext:   resourceAPIRequestRead,
+		DeleteContext: resourceAPIRequestDelete,
+		Description: "Send a request to the Gcore API for the features the typed resources don't cover yet. " +
+			"The request is sent once on create and the response is kept in the state, any change of the request sends it again. " +
+			"The optional destroy request is sent on destroy.",
+		Schema: map[string]*schema.Schema{
+			"method": {
+				Type:         schema.TypeString,
+				Description:  fmt.Sprintf("HTTP method of the request, one of %s. `POST` by default.", strings.Join(apiRequestMethods, ", ")),
+				Optional:     true,
+				ForceNew:     true,
+				Default:      http.MethodPost,
+				ValidateFunc: validation.StringInSlice(apiRequestMethods, false),
+			},
+			"path": {
+				Type:         schema.TypeString,
+				Description:  "Path of the request relative to the `api_endpoint` of the provider, with the query string if any, e.g. `/cloud/v1/projects`.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: apiRequestPathValidate,
+			},
+			"body": {
+				Type:         schema.TypeString,
+				Description:  "JSON body of the request.",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsJSON,
+			},
+			"expected_status": {
+				Type:        schema.TypeSet,
+				Description: "HTTP status codes of the successful response, any 2xx code by default.",
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(100, 599),
+				},
+			},
+			"destroy_method": {
+				Type:         schema.TypeString,
+				Description:  fmt.Sprintf("HTTP method of the request sent on destroy, one of %s. `DELETE` by default.", strings.Join(apiRequestMethods, ", ")),
+				Optional:     true,
+				ForceNew:     true,
+				Default:      http.MethodDelete,
+				ValidateFunc: validation.StringInSlice(apiRequestMethods, false),
+			},
+			"destroy_path": {
+				Type:         schema.TypeString,
+				Description:  "Path of the request sent on destroy, nothing is sent if it is not set.",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: apiRequestPathValidate,
+			},
+			"destroy_body": {
+				Type:         schema.TypeString,
+				Description:  "JSON body of the request sent on destroy.",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsJSON,
+			},
+			"status_code": {
+				Type:        schema.TypeInt,
+				Description: "HTTP status code of the response.",
+				Computed:    true,
+			},
+			"response_body": {
+				Type:        schema.TypeString,
+				Description: "Body of the response, use `jsondecode` to access its fields. It is sensitive as the responses may contain secrets, use `nonsensitive` for the fields that are safe to show.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func resourceAPIRequestCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start API request creating")
+	config := m.(*Config)
+
+	status, response, err := sendAPIRequest(config, d.Get("method").(string), d.Get("path").(string),
+		d.Get("body").(string), apiRequestExpectedStatus(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id.UniqueId())
+	d.Set("status_code", status)
+	d.Set("response_body", response)
+
+	log.Printf("[DEBUG] Finish API request creating (%s)", d.Id())
+	return resourceAPIRequestRead(ctx, d, m)
+}
+
+// resourceAPIRequestRead keeps the captured response, the request may not be safe to repeat.
+func resourceAPIRequestRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourceAPIRequestDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start API request deleting")
+	config := m.(*Config)
+
+	if path := d.Get("destroy_path").(string); path != "" {
+		_, _, err := sendAPIRequest(config, d.Get("destroy_method").(string), path, d.Get("destroy_body").(string), nil)
+		var notFound gcorecloud.ErrDefault404
+		if errors.As(err, &notFound) {
+			log.Printf("[DEBUG] Destroy path %s is already gone", path)
+		} else if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+	log.Println("[DEBUG] Finish API request deleting")
+	return nil
+}
+
+func apiRequestExpectedStatus(d *schema.ResourceData) []int {
+	raw := d.Get("expected_status").(*schema.Set).List()
+	if len(raw) == 0 {
+		return nil
+	}
+	codes := make([]int, len(raw))
+	for i, v := range raw {
+		codes[i] = v.(int)
+	}
+	return codes
+}
+
+// sendAPIRequest sends the request with the credentials of the provider and returns the status code and the body
+// of the response. Any 2xx code is a success if expected is empty.
+func sendAPIRequest(config *Config, method, path, body string, expected []int) (int, string, error) {
+	if len(expected) == 0 {
+		for code := 200; code < 300; code++ {
+			expected = append(expected, code)
+		}
+	}
+	url := strings.TrimRight(config.APIEndpoint, "/") + path
+	opts := &gcorecloud.RequestOpts{OkCodes: expected}
+	if body != "" {
+		opts.RawBody = strings.NewReader(body)
+		opts.MoreHeaders = map[string]string{"Content-Type": "application/json"}
+	}
+
+	resp, err := config.Provider.Request(method, url, opts)
+	if err != nil {
+		return 0, "", fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	response, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, "", fmt.Errorf("%s %s, read response: %w", method, path, err)
+	}
+	return resp.StatusCode, string(response), nil
+}
@@ -0,0 +1,53 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+)
+
+func TestSendAPIRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cloud/v1/things":
+			body, _ := io.ReadAll(r.Body)
+			if r.Method != http.MethodPost || string(body) != `{"name":"x"}` || r.Header.Get("Content-Type") != "application/json" {
+				t.Errorf("unexpected request %s %s %q", r.Method, r.URL, body)
+			}
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id":"thing-id"}`)
+		case "/cloud/v1/things/accepted":
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	config := &Config{Provider: &gcorecloud.ProviderClient{}, APIEndpoint: server.URL + "/"}
+
+	status, response, err := sendAPIRequest(config, http.MethodPost, "/cloud/v1/things", `{"name":"x"}`, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != http.StatusCreated || response != `{"id":"thing-id"}` {
+		t.Errorf("sendAPIRequest() = %d, %q", status, response)
+	}
+
+	if _, _, err := sendAPIRequest(config, http.MethodPut, "/cloud/v1/things/accepted", "", []int{http.StatusOK}); err == nil {
+		t.Error("unexpected status is accepted")
+	}
+
+	_, _, err = sendAPIRequest(config, http.MethodDelete, "/cloud/v1/things/gone", "", nil)
+	var notFound gcorecloud.ErrDefault404
+	if !errors.As(err, &notFound) {
+		t.Errorf("sendAPIRequest() error = %v, want not found", err)
+	}
+}
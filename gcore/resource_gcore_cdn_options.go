@@ -1,11 +1,21 @@
 package gcore
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"log"
 	"maps"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 var (
+	// cdnRedirectCodes are the origin redirect codes the follow_origin_redirect option supports
+	cdnRedirectCodes = []int{301, 302, 303, 307, 308}
+	// cdnSecondsRegexp matches the time values in seconds, the API stores '30' as '30s' and the plan would never converge
+	cdnSecondsRegexp = regexp.MustCompile(`^[1-9][0-9]*s$`)
+
 	commonOptions = map[string]*schema.Schema{
 		"allowed_http_methods": {
 			Type:        schema.TypeList,
@@ -22,7 +32,8 @@ var (
 					},
 					"value": {
 						Type:        schema.TypeSet,
-						Elem:        &schema.Schema{Type: schema.TypeString},
+						Elem:        &schema.Schema{Type: schema.TypeString, StateFunc: cdnUpperCaseStateFunc},
+						Set:         cdnUpperCaseHash,
 						Required:    true,
 						Description: "Available methods: GET, HEAD, POST, PUT, PATCH, DELETE, OPTIONS.",
 					},
@@ -139,7 +150,8 @@ var (
 					},
 					"excepted_values": {
 						Type:        schema.TypeSet,
-						Elem:        &schema.Schema{Type: schema.TypeString},
+						Elem:        &schema.Schema{Type: schema.TypeString, StateFunc: cdnUpperCaseStateFunc},
+						Set:         cdnUpperCaseHash,
 						Required:    true,
 						Description: "List of countries according to ISO-3166-1.",
 					},
@@ -253,8 +265,9 @@ var (
 					},
 					"codes": {
 						Type:        schema.TypeSet,
-						Elem:        &schema.Schema{Type: schema.TypeInt},
+						Elem:        &schema.Schema{Type: schema.TypeInt, ValidateFunc: validation.IntInSlice(cdnRedirectCodes)},
 						Required:    true,
+						MinItems:    1,
 						Description: "Specify the redirect status code that the origin server returns. Possible values: 301, 302, 303, 307, 308.",
 					},
 				},
@@ -337,8 +350,9 @@ var (
 						Default:  true,
 					},
 					"value": {
-						Type:     schema.TypeString,
-						Required: true,
+						Type:      schema.TypeString,
+						Required:  true,
+						StateFunc: cdnHostnameStateFunc,
 					},
 				},
 			},
@@ -510,9 +524,10 @@ var (
 						Default:  true,
 					},
 					"value": {
-						Type:        schema.TypeString,
-						Required:    true,
-						Description: "Specify time in seconds ('1s', '30s' for example).",
+						Type:         schema.TypeString,
+						Required:     true,
+						Description:  "Specify time in seconds ('1s', '30s' for example).",
+						ValidateFunc: validation.StringMatch(cdnSecondsRegexp, "must be a number of seconds, e.g. '30s'"),
 					},
 				},
 			},
@@ -531,9 +546,10 @@ var (
 						Default:  true,
 					},
 					"value": {
-						Type:        schema.TypeString,
-						Required:    true,
-						Description: "Specify time in seconds ('1s', '30s' for example).",
+						Type:         schema.TypeString,
+						Required:     true,
+						Description:  "Specify time in seconds ('1s', '30s' for example).",
+						ValidateFunc: validation.StringMatch(cdnSecondsRegexp, "must be a number of seconds, e.g. '30s'"),
 					},
 				},
 			},
@@ -635,7 +651,8 @@ var (
 					},
 					"excepted_values": {
 						Type:        schema.TypeSet,
-						Elem:        &schema.Schema{Type: schema.TypeString},
+						Elem:        &schema.Schema{Type: schema.TypeString, StateFunc: cdnHostnameStateFunc},
+						Set:         cdnHostnameHash,
 						Required:    true,
 						Description: "Specify list of domain names or wildcard domains (without http:// or https://). For example, example.com or *.example.com.",
 					},
@@ -646,7 +663,7 @@ var (
 			Type:        schema.TypeList,
 			MaxItems:    1,
 			Optional:    true,
-			Description: "It allows to limit the amount of HTTP requests",
+			Description: "It allows to limit the amount of HTTP requests from one client IP address, the requests over the limit are rejected with 503.",
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					"enabled": {
@@ -655,22 +672,30 @@ var (
 						Default:  true,
 					},
 					"rate": {
-						Type:     schema.TypeInt,
-						Required: true,
+						Type:         schema.TypeInt,
+						Required:     true,
+						Description:  "Maximum rate of the requests in rate_unit.",
+						ValidateFunc: validation.IntAtLeast(1),
 					},
 					"burst": {
-						Type:     schema.TypeInt,
-						Required: true,
+						Type:         schema.TypeInt,
+						Required:     true,
+						Description:  "Number of the requests over the rate that are queued instead of being rejected.",
+						ValidateFunc: validation.IntAtLeast(0),
 					},
 					"rate_unit": {
-						Type:     schema.TypeString,
-						Optional: true,
-						Default:  "r/s",
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      "r/s",
+						Description:  "Unit of the rate, requests per second (r/s) or per minute (r/m).",
+						ValidateFunc: validation.StringInSlice([]string{"r/s", "r/m"}, false),
 					},
 					"delay": {
-						Type:     schema.TypeInt,
-						Optional: true,
-						Default:  0,
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      0,
+						Description:  "Number of the queued requests that are sent without the delay, the rest of the burst is throttled to the rate.",
+						ValidateFunc: validation.IntAtLeast(0),
 					},
 				},
 			},
@@ -792,6 +817,7 @@ var (
 					"custom_hostname": {
 						Type:        schema.TypeString,
 						Optional:    true,
+						StateFunc:   cdnHostnameStateFunc,
 						Description: "Custom SNI hostname. Required if sni_type is set to 'custom'.",
 					},
 				},
@@ -1079,3 +1105,57 @@ var (
 func init() {
 	maps.Copy(resourceOptions, commonOptions)
 }
+
+// The CDN API stores hostnames in lower case without the trailing dot and the HTTP methods and
+// country codes in upper case. The values are normalized the same way in the state (StateFunc)
+// and in the set hashes, so a configuration written differently converges on the first apply.
+
+func normalizeCDNHostname(v string) string {
+	normalized := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(v)), ".")
+	if normalized != v {
+		log.Printf("[DEBUG] CDN hostname %q is normalized to %q", v, normalized)
+	}
+	return normalized
+}
+
+func normalizeCDNUpperCase(v string) string {
+	normalized := strings.ToUpper(strings.TrimSpace(v))
+	if normalized != v {
+		log.Printf("[DEBUG] CDN value %q is normalized to %q", v, normalized)
+	}
+	return normalized
+}
+
+// normalizeCDNStrings normalizes the values and drops the duplicates the API would drop.
+func normalizeCDNStrings(values []string, normalize func(string) string) []string {
+	result := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		v = normalize(v)
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func cdnHostnameStateFunc(v interface{}) string {
+	return normalizeCDNHostname(v.(string))
+}
+
+func cdnUpperCaseStateFunc(v interface{}) string {
+	return normalizeCDNUpperCase(v.(string))
+}
+
+func cdnHostnameHash(v interface{}) int {
+	return schema.HashString(normalizeCDNHostname(v.(string)))
+}
+
+func cdnUpperCaseHash(v interface{}) int {
+	return schema.HashString(normalizeCDNUpperCase(v.(string)))
+}
+
+func suppressCDNUpperCaseDiff(k, old, new string, d *schema.ResourceData) bool {
+	return strings.EqualFold(strings.TrimSpace(old), strings.TrimSpace(new))
+}
@@ -12,8 +12,13 @@ import (
 	"github.com/G-Core/gcorelabscdn-go/origingroups"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+var cdnProxyNextUpstreamValues = []string{
+	"error", "timeout", "invalid_header", "http_403", "http_404", "http_429", "http_500", "http_502", "http_503", "http_504",
+}
+
 func resourceCDNOriginGroup() *schema.Resource {
 	return &schema.Resource{
 		Importer: &schema.ResourceImporter{
@@ -57,18 +62,21 @@ func resourceCDNOriginGroup() *schema.Resource {
 				},
 			},
 			"proxy_next_upstream": {
-				Type:        schema.TypeSet,
-				Elem:        &schema.Schema{Type: schema.TypeString},
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(cdnProxyNextUpstreamValues, false),
+				},
 				Optional:    true,
 				Computed:    true,
-				Description: "Available values: error, timeout, invalid_header, http_403, http_404, http_429, http_500, http_502, http_503, http_504.",
+				Description: "Cases when the request is passed to the next origin of the group, used only when `use_next` is enabled. Available values: error, timeout, invalid_header, http_403, http_404, http_429, http_500, http_502, http_503, http_504. The timeout case is controlled by the `proxy_connect_timeout` and `proxy_read_timeout` options of the CDN resource.",
 			},
 		},
 		CreateContext: resourceCDNOriginGroupCreate,
 		ReadContext:   resourceCDNOriginGroupRead,
 		UpdateContext: resourceCDNOriginGroupUpdate,
 		DeleteContext: resourceCDNOriginGroupDelete,
-		Description:   "Represent origin group",
+		Description:   "Represent origin group. Failover between the origins is driven by `use_next` and `proxy_next_upstream`.",
 	}
 }
 
@@ -23,6 +23,7 @@ func resourceCDNResource() *schema.Resource {
 				Type:        schema.TypeString,
 				Required:    true,
 				ForceNew:    true,
+				StateFunc:   cdnHostnameStateFunc,
 				Description: "A CNAME that will be used to deliver content though a CDN. If you update this field new resource will be created.",
 			},
 			"description": {
@@ -51,10 +52,11 @@ func resourceCDNResource() *schema.Resource {
 				Description: "A domain name or IP of your origin source. Specify a port if custom. You can use either 'origin' parameter or 'originGroup' in the resource definition.",
 			},
 			"origin_protocol": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "HTTP",
-				Description: "This option defines the protocol that will be used by CDN servers to request content from an origin source. If not specified, we will use HTTP to connect to an origin server. Possible values are: HTTPS, HTTP, MATCH.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "HTTP",
+				DiffSuppressFunc: suppressCDNUpperCaseDiff,
+				Description:      "This option defines the protocol that will be used by CDN servers to request content from an origin source. If not specified, we will use HTTP to connect to an origin server. Possible values are: HTTPS, HTTP, MATCH.",
 			},
 			"secondary_hostnames": {
 				Type:     schema.TypeSet,
@@ -62,7 +64,8 @@ func resourceCDNResource() *schema.Resource {
 				DefaultFunc: func() (interface{}, error) {
 					return []string{}, nil
 				},
-				Elem:        &schema.Schema{Type: schema.TypeString},
+				Elem:        &schema.Schema{Type: schema.TypeString, StateFunc: cdnHostnameStateFunc},
+				Set:         cdnHostnameHash,
 				Description: "List of additional CNAMEs.",
 			},
 			"ssl_enabled": {
@@ -103,7 +106,7 @@ func resourceCDNResourceCreate(ctx context.Context, d *schema.ResourceData, m in
 	client := config.CDNClient
 
 	var req resources.CreateRequest
-	req.Cname = d.Get("cname").(string)
+	req.Cname = normalizeCDNHostname(d.Get("cname").(string))
 	req.Description = d.Get("description").(string)
 	req.Origin = d.Get("origin").(string)
 	req.OriginGroup = d.Get("origin_group").(int)
@@ -114,7 +117,7 @@ func resourceCDNResourceCreate(ctx context.Context, d *schema.ResourceData, m in
 	req.Options = listToOptions(d.Get("options").([]interface{}))
 
 	for _, hostname := range d.Get("secondary_hostnames").(*schema.Set).List() {
-		req.SecondaryHostnames = append(req.SecondaryHostnames, hostname.(string))
+		req.SecondaryHostnames = append(req.SecondaryHostnames, normalizeCDNHostname(hostname.(string)))
 	}
 
 	result, err := client.Resources().Create(ctx, &req)
@@ -145,11 +148,11 @@ func resourceCDNResourceRead(ctx context.Context, d *schema.ResourceData, m inte
 		return diag.FromErr(err)
 	}
 
-	d.Set("cname", result.Cname)
+	d.Set("cname", normalizeCDNHostname(result.Cname))
 	d.Set("description", result.Description)
 	d.Set("origin_group", result.OriginGroup)
 	d.Set("origin_protocol", result.OriginProtocol)
-	d.Set("secondary_hostnames", result.SecondaryHostnames)
+	d.Set("secondary_hostnames", normalizeCDNStrings(result.SecondaryHostnames, normalizeCDNHostname))
 	d.Set("ssl_enabled", result.SSlEnabled)
 	d.Set("ssl_data", result.SSLData)
 	d.Set("status", result.Status)
@@ -183,7 +186,7 @@ func resourceCDNResourceUpdate(ctx context.Context, d *schema.ResourceData, m in
 	req.Options = listToOptions(d.Get("options").([]interface{}))
 	req.SecondaryHostnames = make([]string, 0)
 	for _, hostname := range d.Get("secondary_hostnames").(*schema.Set).List() {
-		req.SecondaryHostnames = append(req.SecondaryHostnames, hostname.(string))
+		req.SecondaryHostnames = append(req.SecondaryHostnames, normalizeCDNHostname(hostname.(string)))
 	}
 
 	if _, err := client.Resources().Update(ctx, id, &req); err != nil {
@@ -227,7 +230,7 @@ func listToOptions(l []interface{}) *gcdn.Options {
 			Enabled: opt["enabled"].(bool),
 		}
 		for _, v := range opt["value"].(*schema.Set).List() {
-			opts.AllowedHTTPMethods.Value = append(opts.AllowedHTTPMethods.Value, v.(string))
+			opts.AllowedHTTPMethods.Value = append(opts.AllowedHTTPMethods.Value, normalizeCDNUpperCase(v.(string)))
 		}
 	}
 	if opt, ok := getOptByName(fields, "brotli_compression"); ok {
@@ -269,7 +272,7 @@ func listToOptions(l []interface{}) *gcdn.Options {
 			PolicyType: opt["policy_type"].(string),
 		}
 		for _, v := range opt["excepted_values"].(*schema.Set).List() {
-			opts.CountryACL.ExceptedValues = append(opts.CountryACL.ExceptedValues, v.(string))
+			opts.CountryACL.ExceptedValues = append(opts.CountryACL.ExceptedValues, normalizeCDNUpperCase(v.(string)))
 		}
 	}
 	if opt, ok := getOptByName(fields, "disable_cache"); ok {
@@ -334,7 +337,7 @@ func listToOptions(l []interface{}) *gcdn.Options {
 	if opt, ok := getOptByName(fields, "host_header"); ok {
 		opts.HostHeader = &gcdn.HostHeader{
 			Enabled: opt["enabled"].(bool),
-			Value:   opt["value"].(string),
+			Value:   normalizeCDNHostname(opt["value"].(string)),
 		}
 	}
 	if opt, ok := getOptByName(fields, "http3_enabled"); ok {
@@ -443,7 +446,7 @@ func listToOptions(l []interface{}) *gcdn.Options {
 			PolicyType: opt["policy_type"].(string),
 		}
 		for _, v := range opt["excepted_values"].(*schema.Set).List() {
-			opts.ReferrerACL.ExceptedValues = append(opts.ReferrerACL.ExceptedValues, v.(string))
+			opts.ReferrerACL.ExceptedValues = append(opts.ReferrerACL.ExceptedValues, normalizeCDNHostname(v.(string)))
 		}
 	}
 	if opt, ok := getOptByName(fields, "request_limiter"); ok {
@@ -488,7 +491,7 @@ func listToOptions(l []interface{}) *gcdn.Options {
 		opts.SNI = &gcdn.SNIOption{
 			Enabled:        opt["enabled"].(bool),
 			SNIType:        opt["sni_type"].(string),
-			CustomHostname: opt["custom_hostname"].(string),
+			CustomHostname: normalizeCDNHostname(opt["custom_hostname"].(string)),
 		}
 	}
 	if opt, ok := getOptByName(fields, "stale"); ok {
@@ -606,6 +609,7 @@ func optionsToList(options *gcdn.Options) []interface{} {
 	result := make(map[string][]interface{})
 	if options.AllowedHTTPMethods != nil {
 		m := structToMap(options.AllowedHTTPMethods)
+		m["value"] = normalizeCDNStrings(options.AllowedHTTPMethods.Value, normalizeCDNUpperCase)
 		result["allowed_http_methods"] = []interface{}{m}
 	}
 	if options.BrotliCompression != nil {
@@ -626,6 +630,7 @@ func optionsToList(options *gcdn.Options) []interface{} {
 	}
 	if options.CountryACL != nil {
 		m := structToMap(options.CountryACL)
+		m["excepted_values"] = normalizeCDNStrings(options.CountryACL.ExceptedValues, normalizeCDNUpperCase)
 		result["country_acl"] = []interface{}{m}
 	}
 	if options.DisableCache != nil {
@@ -662,6 +667,7 @@ func optionsToList(options *gcdn.Options) []interface{} {
 	}
 	if options.HostHeader != nil {
 		m := structToMap(options.HostHeader)
+		m["value"] = normalizeCDNHostname(options.HostHeader.Value)
 		result["host_header"] = []interface{}{m}
 	}
 	if options.HTTP3Enabled != nil {
@@ -718,6 +724,7 @@ func optionsToList(options *gcdn.Options) []interface{} {
 	}
 	if options.ReferrerACL != nil {
 		m := structToMap(options.ReferrerACL)
+		m["excepted_values"] = normalizeCDNStrings(options.ReferrerACL.ExceptedValues, normalizeCDNHostname)
 		result["referrer_acl"] = []interface{}{m}
 	}
 	if options.RequestLimiter != nil {
@@ -742,6 +749,7 @@ func optionsToList(options *gcdn.Options) []interface{} {
 	}
 	if options.SNI != nil {
 		m := structToMap(options.SNI)
+		m["custom_hostname"] = normalizeCDNHostname(options.SNI.CustomHostname)
 		result["sni"] = []interface{}{m}
 	}
 	if options.Stale != nil {
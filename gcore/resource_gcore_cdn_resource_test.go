@@ -5,10 +5,13 @@ package gcore
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
+	gcdn "github.com/G-Core/gcorelabscdn-go/gcore"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func TestAccCDNResource(t *testing.T) {
@@ -60,3 +63,92 @@ resource "gcore_cdn_resource" "acctest" {
 		},
 	})
 }
+
+func TestNormalizeCDNValues(t *testing.T) {
+	hostnames := normalizeCDNStrings([]string{"CDN.Example.com.", "cdn.example.com", " static.example.com"}, normalizeCDNHostname)
+	if want := []string{"cdn.example.com", "static.example.com"}; !reflect.DeepEqual(hostnames, want) {
+		t.Errorf("normalized hostnames %q, want %q", hostnames, want)
+	}
+	if cdnHostnameHash("CDN.Example.com.") != cdnHostnameHash("cdn.example.com") {
+		t.Error("hostnames differing only in case have different set hashes")
+	}
+
+	countries := normalizeCDNStrings([]string{"gb", "GB", "de"}, normalizeCDNUpperCase)
+	if want := []string{"GB", "DE"}; !reflect.DeepEqual(countries, want) {
+		t.Errorf("normalized countries %q, want %q", countries, want)
+	}
+	if !suppressCDNUpperCaseDiff("origin_protocol", "HTTPS", "https", nil) {
+		t.Error("origin_protocol differing only in case is not suppressed")
+	}
+
+	opts := listToOptions([]interface{}{map[string]interface{}{
+		"host_header": []interface{}{map[string]interface{}{"enabled": true, "value": "Origin.Example.com"}},
+	}})
+	if opts.HostHeader.Value != "origin.example.com" {
+		t.Errorf("host_header value %q, want origin.example.com", opts.HostHeader.Value)
+	}
+	list := optionsToList(opts)
+	hostHeader := list[0].(map[string][]interface{})["host_header"][0].(map[string]interface{})
+	if hostHeader["value"] != "origin.example.com" {
+		t.Errorf("host_header value in the state %q, want origin.example.com", hostHeader["value"])
+	}
+}
+
+func TestCDNRequestLimiterOption(t *testing.T) {
+	limiter := map[string]interface{}{"enabled": true, "rate": 10, "burst": 20, "rate_unit": "r/m", "delay": 5}
+	opts := listToOptions([]interface{}{map[string]interface{}{
+		"request_limiter": []interface{}{limiter},
+	}})
+	want := gcdn.RequestLimiter{Enabled: true, Rate: 10, Burst: 20, RateUnit: "r/m", Delay: 5}
+	if opts.RequestLimiter == nil || *opts.RequestLimiter != want {
+		t.Fatalf("request limiter %+v, want %+v", opts.RequestLimiter, want)
+	}
+	list := optionsToList(opts)
+	if got := list[0].(map[string][]interface{})["request_limiter"][0]; !reflect.DeepEqual(got, limiter) {
+		t.Errorf("request limiter in the state %v, want %v", got, limiter)
+	}
+
+	limiterSchema := commonOptions["request_limiter"].Elem.(*schema.Resource).Schema
+	if _, errs := limiterSchema["rate_unit"].ValidateFunc("r/h", "rate_unit"); len(errs) == 0 {
+		t.Error("unknown rate unit is accepted")
+	}
+	if _, errs := limiterSchema["rate"].ValidateFunc(0, "rate"); len(errs) == 0 {
+		t.Error("zero rate is accepted")
+	}
+}
+
+func TestCDNOriginRedirectAndTimeoutOptions(t *testing.T) {
+	opts := listToOptions([]interface{}{map[string]interface{}{
+		"follow_origin_redirect": []interface{}{map[string]interface{}{
+			"enabled": true,
+			"codes":   schema.NewSet(schema.HashInt, []interface{}{301, 308}),
+		}},
+		"proxy_connect_timeout": []interface{}{map[string]interface{}{"enabled": true, "value": "4s"}},
+		"proxy_read_timeout":    []interface{}{map[string]interface{}{"enabled": true, "value": "30s"}},
+	}})
+	if opts.FollowOriginRedirect == nil || len(opts.FollowOriginRedirect.Codes) != 2 {
+		t.Fatalf("follow origin redirect %+v, want codes 301 and 308", opts.FollowOriginRedirect)
+	}
+	if opts.ProxyConnectTimeout == nil || opts.ProxyConnectTimeout.Value != "4s" {
+		t.Errorf("proxy connect timeout %+v, want 4s", opts.ProxyConnectTimeout)
+	}
+	if opts.ProxyReadTimeout == nil || opts.ProxyReadTimeout.Value != "30s" {
+		t.Errorf("proxy read timeout %+v, want 30s", opts.ProxyReadTimeout)
+	}
+
+	codeSchema := commonOptions["follow_origin_redirect"].Elem.(*schema.Resource).Schema["codes"].Elem.(*schema.Schema)
+	if _, errs := codeSchema.ValidateFunc(304, "codes"); len(errs) == 0 {
+		t.Error("not a redirect code is accepted")
+	}
+	for _, name := range []string{"proxy_connect_timeout", "proxy_read_timeout"} {
+		valueSchema := commonOptions[name].Elem.(*schema.Resource).Schema["value"]
+		for _, v := range []string{"30", "0s", "1m"} {
+			if _, errs := valueSchema.ValidateFunc(v, "value"); len(errs) == 0 {
+				t.Errorf("%s value %q is accepted", name, v)
+			}
+		}
+		if _, errs := valueSchema.ValidateFunc("5s", "value"); len(errs) != 0 {
+			t.Errorf("%s value 5s is rejected: %v", name, errs)
+		}
+	}
+}
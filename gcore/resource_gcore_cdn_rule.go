@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"strconv"
 	"strings"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/G-Core/gcorelabscdn-go/rules"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceCDNRuleImportParseId(id string) (string, string, error) {
@@ -23,6 +25,33 @@ func resourceCDNRuleImportParseId(id string) (string, string, error) {
 	return parts[0], parts[1], nil
 }
 
+// cdnRuleCreateRequest and cdnRuleUpdateRequest override the weight of the SDK requests,
+// the SDK omits the zero weight, so a rule could not be moved to the top with weight 0.
+type cdnRuleCreateRequest struct {
+	rules.CreateRequest
+	Weight *int `json:"weight,omitempty"`
+}
+
+type cdnRuleUpdateRequest struct {
+	rules.UpdateRequest
+	Weight *int `json:"weight,omitempty"`
+}
+
+// cdnRuleWeight returns the configured weight of the rule or nil to let the API assign it.
+func cdnRuleWeight(d *schema.ResourceData) *int {
+	raw := d.GetRawConfig()
+	if raw.IsNull() || !raw.IsKnown() {
+		if v, ok := d.GetOk("weight"); ok {
+			return pointer.ToInt(v.(int))
+		}
+		return nil
+	}
+	if raw.GetAttr("weight").IsNull() {
+		return nil
+	}
+	return pointer.ToInt(d.Get("weight").(int))
+}
+
 func resourceCDNRule() *schema.Resource {
 	return &schema.Resource{
 		Importer: &schema.ResourceImporter{
@@ -75,15 +104,17 @@ func resourceCDNRule() *schema.Resource {
 				Description: "ID of the Origins Group. Use one of your Origins Group or create a new one. You can use either 'origin' parameter or 'originGroup' in the resource definition.",
 			},
 			"origin_protocol": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "This option defines the protocol that will be used by CDN servers to request content from an origin source. If not specified, it will be inherit from resource. Possible values are: HTTPS, HTTP, MATCH.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressCDNUpperCaseDiff,
+				Description:      "This option defines the protocol that will be used by CDN servers to request content from an origin source. If not specified, it will be inherit from resource. Possible values are: HTTPS, HTTP, MATCH.",
 			},
 			"weight": {
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Computed:    true,
-				Description: "Rule weight that determines rule execution order: from the smallest (0) to the highest.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Rule weight that determines rule execution order: from the smallest (0) to the highest. The order of the rules with the same weight is not defined, set a unique weight for every rule of the resource to get the same order on every apply. If not specified, it is assigned by the API.",
 			},
 			"options": ruleOptionsSchema,
 		},
@@ -98,17 +129,14 @@ func resourceCDNRule() *schema.Resource {
 func resourceCDNRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Println("[DEBUG] Start CDN Rule creating")
 	config := m.(*Config)
-	client := config.CDNClient
 
-	var req rules.CreateRequest
+	var req cdnRuleCreateRequest
 	req.Name = d.Get("name").(string)
 	req.Active = d.Get("active").(bool)
 	req.Rule = d.Get("rule").(string)
 	req.RuleType = d.Get("rule_type").(int)
 
-	if d.Get("weight") != nil {
-		req.Weight = d.Get("weight").(int)
-	}
+	req.Weight = cdnRuleWeight(d)
 
 	if d.Get("origin_group") != nil && d.Get("origin_group").(int) > 0 {
 		req.OriginGroup = pointer.ToInt(d.Get("origin_group").(int))
@@ -122,8 +150,9 @@ func resourceCDNRuleCreate(ctx context.Context, d *schema.ResourceData, m interf
 
 	req.Options = listToOptions(d.Get("options").([]interface{}))
 
-	result, err := client.Rules().Create(ctx, int64(resourceID), &req)
-	if err != nil {
+	var result rules.Rule
+	path := fmt.Sprintf("/cdn/resources/%d/rules", resourceID)
+	if err := config.CDNRequester.Request(ctx, http.MethodPost, path, &req, &result); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -171,22 +200,19 @@ func resourceCDNRuleUpdate(ctx context.Context, d *schema.ResourceData, m interf
 	ruleID := d.Id()
 	log.Printf("[DEBUG] Start CDN Rule updating (id=%s)\n", ruleID)
 	config := m.(*Config)
-	client := config.CDNClient
 
 	id, err := strconv.ParseInt(ruleID, 10, 64)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	var req rules.UpdateRequest
+	var req cdnRuleUpdateRequest
 	req.Name = d.Get("name").(string)
 	req.Active = d.Get("active").(bool)
 	req.Rule = d.Get("rule").(string)
 	req.RuleType = d.Get("rule_type").(int)
 
-	if d.Get("weight") != nil {
-		req.Weight = d.Get("weight").(int)
-	}
+	req.Weight = cdnRuleWeight(d)
 
 	if d.Get("origin_group") != nil && d.Get("origin_group").(int) > 0 {
 		req.OriginGroup = pointer.ToInt(d.Get("origin_group").(int))
@@ -200,7 +226,8 @@ func resourceCDNRuleUpdate(ctx context.Context, d *schema.ResourceData, m interf
 
 	resourceID := d.Get("resource_id").(int)
 
-	if _, err := client.Rules().Update(ctx, int64(resourceID), id, &req); err != nil {
+	path := fmt.Sprintf("/cdn/resources/%d/rules/%d", resourceID, id)
+	if err := config.CDNRequester.Request(ctx, http.MethodPut, path, &req, &rules.Rule{}); err != nil {
 		return diag.FromErr(err)
 	}
 
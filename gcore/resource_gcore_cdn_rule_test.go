@@ -4,9 +4,12 @@
 package gcore
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/AlekSi/pointer"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -80,3 +83,21 @@ resource "gcore_cdn_rule" "acctest" {
 		},
 	})
 }
+
+func TestCDNRuleRequestWeight(t *testing.T) {
+	body, err := json.Marshal(cdnRuleUpdateRequest{Weight: pointer.ToInt(0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(body), `"weight":0`) {
+		t.Errorf("request %s doesn't contain the zero weight", body)
+	}
+
+	body, err = json.Marshal(cdnRuleCreateRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(body), `"weight"`) {
+		t.Errorf("request %s contains the weight that is not set", body)
+	}
+}
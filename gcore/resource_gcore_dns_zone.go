@@ -1,39 +1,62 @@
 package gcore
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
+	"net/http"
+	"net/url"
+	"path"
 	"strings"
 	"time"
 
-	"github.com/hashicorp/go-cty/cty"
+	dnssdk "github.com/G-Core/gcore-dns-sdk-go"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
 	DNSZoneResource = "gcore_dns_zone"
 
-	DNSZoneSchemaName   = "name"
-	DNSZoneSchemaDNSSEC = "dnssec"
+	DNSZoneSchemaName          = "name"
+	DNSZoneSchemaDNSSEC        = "dnssec"
+	DNSZoneSchemaDNSSECDS      = "dnssec_ds"
+	DNSZoneSchemaContact       = "contact"
+	DNSZoneSchemaPrimaryServer = "primary_server"
+	DNSZoneSchemaExpiry        = "expiry"
+	DNSZoneSchemaRefresh       = "refresh"
+	DNSZoneSchemaRetry         = "retry"
+	DNSZoneSchemaNxTTL         = "nx_ttl"
+	DNSZoneSchemaSerial        = "serial"
+	DNSZoneSchemaMeta          = "meta"
 )
 
+// dnsZoneSettings is the SOA and meta part of the zone, the DNS SDK zone has only the name and the records.
+type dnsZoneSettings struct {
+	Name          string                 `json:"name"`
+	Contact       string                 `json:"contact"`
+	PrimaryServer string                 `json:"primary_server"`
+	Expiry        int                    `json:"expiry"`
+	Refresh       int                    `json:"refresh"`
+	Retry         int                    `json:"retry"`
+	NxTTL         int                    `json:"nx_ttl"`
+	Serial        int                    `json:"serial,omitempty"`
+	Meta          map[string]interface{} `json:"meta"`
+}
+
 func resourceDNSZone() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			DNSZoneSchemaName: {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
-				ValidateDiagFunc: func(i interface{}, path cty.Path) diag.Diagnostics {
-					zoneName := i.(string)
-					if strings.TrimSpace(zoneName) == "" || len(zoneName) > 255 {
-						return diag.Errorf("dns name can't be empty, it also should be less than 256 symbols")
-					}
-					return nil
-				},
-				Description: "A name of DNS Zone resource.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateDomain,
+				Description:      "A name of DNS Zone resource.",
 			},
 			DNSZoneSchemaDNSSEC: {
 				Type:     schema.TypeBool,
@@ -43,6 +66,66 @@ func resourceDNSZone() *schema.Resource {
 					"Set it to true to enable DNSSEC for the zone or false to disable it." +
 					"By default, DNSSEC is set to false wich means it is disabled.",
 			},
+			DNSZoneSchemaDNSSECDS: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "DS record of the zone to publish at the parent zone, set when DNSSEC is enabled.",
+			},
+			DNSZoneSchemaContact: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Email address of the zone administrator, the RNAME field of the SOA record.",
+			},
+			DNSZoneSchemaPrimaryServer: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Primary name server of the zone, the MNAME field of the SOA record.",
+			},
+			DNSZoneSchemaExpiry: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Number of seconds after which secondary name servers stop answering for the zone if the primary does not respond.",
+			},
+			DNSZoneSchemaRefresh: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Number of seconds after which secondary name servers query the primary for the SOA record.",
+			},
+			DNSZoneSchemaRetry: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Number of seconds after which secondary name servers retry to request the serial number from the primary.",
+			},
+			DNSZoneSchemaNxTTL: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Time to live of the negative responses (NXDOMAIN).",
+			},
+			DNSZoneSchemaSerial: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Serial number of the zone, the API increments it on every change.",
+			},
+			DNSZoneSchemaMeta: {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Computed: true,
+				Description: "Zone level meta. The values that are not strings are shown JSON encoded, they are kept as is " +
+					"unless the key is changed in the configuration. Only the configured keys are changed, the other keys of the zone are kept.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(5 * time.Minute),
@@ -93,6 +176,12 @@ func resourceDNSZoneCreate(ctx context.Context, d *schema.ResourceData, m interf
 		}
 	}
 
+	if dnsZoneSettingsConfigured(d) {
+		if err := updateDNSZoneSettings(ctx, config, d, name); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	d.SetId(name)
 	return resourceDNSZoneRead(ctx, d, m)
 }
@@ -109,10 +198,19 @@ func resourceDNSZoneUpdate(ctx context.Context, d *schema.ResourceData, m interf
 	config := m.(*Config)
 	client := config.DNSClient
 
-	enableDnssec := d.Get(DNSZoneSchemaDNSSEC).(bool)
-	_, err := client.ToggleDnssec(ctx, name, enableDnssec)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("enable dnssec: %v", err))
+	if d.HasChange(DNSZoneSchemaDNSSEC) {
+		enableDnssec := d.Get(DNSZoneSchemaDNSSEC).(bool)
+		_, err := client.ToggleDnssec(ctx, name, enableDnssec)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("enable dnssec: %v", err))
+		}
+	}
+
+	if d.HasChanges(DNSZoneSchemaContact, DNSZoneSchemaPrimaryServer, DNSZoneSchemaExpiry,
+		DNSZoneSchemaRefresh, DNSZoneSchemaRetry, DNSZoneSchemaNxTTL, DNSZoneSchemaMeta) {
+		if err := updateDNSZoneSettings(ctx, config, d, name); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	return resourceDNSZoneRead(ctx, d, m)
@@ -126,21 +224,36 @@ func resourceDNSZoneRead(ctx context.Context, d *schema.ResourceData, m interfac
 	config := m.(*Config)
 	client := config.DNSClient
 
-	result, err := client.Zone(ctx, zoneName)
+	result, err := getDNSZoneSettings(ctx, config, zoneName)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("get zone: %w", err))
 	}
 
+	var ds string
 	enableDnssec := d.Get(DNSZoneSchemaDNSSEC).(bool)
 	if enableDnssec {
-		_, errDnssecDS := client.DNSSecDS(ctx, zoneName)
+		dnssecDS, errDnssecDS := client.DNSSecDS(ctx, zoneName)
 		if errDnssecDS != nil {
 			return diag.FromErr(fmt.Errorf("verify dnssec created: %w", errDnssecDS))
 		}
+		ds = dnssecDS.Ds
 	}
 
 	d.SetId(result.Name)
 	_ = d.Set(DNSZoneSchemaName, result.Name)
+	_ = d.Set(DNSZoneSchemaDNSSECDS, ds)
+	_ = d.Set(DNSZoneSchemaContact, result.Contact)
+	_ = d.Set(DNSZoneSchemaPrimaryServer, result.PrimaryServer)
+	_ = d.Set(DNSZoneSchemaExpiry, result.Expiry)
+	_ = d.Set(DNSZoneSchemaRefresh, result.Refresh)
+	_ = d.Set(DNSZoneSchemaRetry, result.Retry)
+	_ = d.Set(DNSZoneSchemaNxTTL, result.NxTTL)
+	_ = d.Set(DNSZoneSchemaSerial, result.Serial)
+	meta := make(map[string]string, len(result.Meta))
+	for k, v := range result.Meta {
+		meta[k] = dnsZoneMetaString(v)
+	}
+	_ = d.Set(DNSZoneSchemaMeta, meta)
 
 	return nil
 }
@@ -165,6 +278,176 @@ func resourceDNSZoneDelete(ctx context.Context, d *schema.ResourceData, m interf
 	return nil
 }
 
+// findDNSZone looks the zone up in the zones list. Unlike the zone details request,
+// the list doesn't return the zone records, so the request stays fast for zones with many RRSets.
+func findDNSZone(ctx context.Context, client *dnssdk.Client, name string) (dnssdk.Zone, error) {
+	name = strings.Trim(name, ".")
+	res, err := client.ZonesWithParam(ctx, dnssdk.ZonesParam{
+		Name:       []string{name},
+		ExactMatch: true,
+		Limit:      1,
+	})
+	if err != nil {
+		return dnssdk.Zone{}, err
+	}
+	if res.Error != "" {
+		return dnssdk.Zone{}, fmt.Errorf("request: %s", res.Error)
+	}
+	for _, zone := range res.Zones {
+		if strings.EqualFold(zone.Name, name) {
+			return zone, nil
+		}
+	}
+	return dnssdk.Zone{}, fmt.Errorf("zone %s not found", name)
+}
+
+// getDNSZoneSettings reads the zone SOA settings and meta from the zones list,
+// which, unlike the zone details request, doesn't return the zone records.
+func getDNSZoneSettings(ctx context.Context, config *Config, name string) (dnsZoneSettings, error) {
+	name = strings.Trim(name, ".")
+	query := url.Values{"name": {name}, "exact_match": {"true"}, "limit": {"1"}}
+
+	var res struct {
+		Zones []dnsZoneSettings `json:"zones"`
+	}
+	if err := dnsRequest(ctx, config, http.MethodGet, "/v2/zones?"+query.Encode(), nil, &res); err != nil {
+		return dnsZoneSettings{}, err
+	}
+	for _, zone := range res.Zones {
+		if strings.EqualFold(zone.Name, name) {
+			return zone, nil
+		}
+	}
+	return dnsZoneSettings{}, fmt.Errorf("zone %s not found", name)
+}
+
+// updateDNSZoneSettings replaces the zone settings, the values missing in the configuration are kept.
+func updateDNSZoneSettings(ctx context.Context, config *Config, d *schema.ResourceData, name string) error {
+	settings, err := getDNSZoneSettings(ctx, config, name)
+	if err != nil {
+		return fmt.Errorf("get zone: %w", err)
+	}
+
+	if v, ok := d.GetOk(DNSZoneSchemaContact); ok {
+		settings.Contact = v.(string)
+	}
+	if v, ok := d.GetOk(DNSZoneSchemaPrimaryServer); ok {
+		settings.PrimaryServer = v.(string)
+	}
+	if v, ok := d.GetOk(DNSZoneSchemaExpiry); ok {
+		settings.Expiry = v.(int)
+	}
+	if v, ok := d.GetOk(DNSZoneSchemaRefresh); ok {
+		settings.Refresh = v.(int)
+	}
+	if v, ok := d.GetOk(DNSZoneSchemaRetry); ok {
+		settings.Retry = v.(int)
+	}
+	if v, ok := d.GetOk(DNSZoneSchemaNxTTL); ok {
+		settings.NxTTL = v.(int)
+	}
+	oldMeta, newMeta := d.GetChange(DNSZoneSchemaMeta)
+	settings.Meta = mergeDNSZoneMeta(settings.Meta, oldMeta.(map[string]interface{}), newMeta.(map[string]interface{}))
+	// the API sets the serial itself
+	settings.Serial = 0
+
+	uri := path.Join("/v2/zones", strings.Trim(name, "."))
+	if err := dnsRequest(ctx, config, http.MethodPut, uri, settings, nil); err != nil {
+		return fmt.Errorf("update zone: %w", err)
+	}
+	return nil
+}
+
+// mergeDNSZoneMeta applies the changes of the configured meta to the meta of the zone. The keys removed
+// from the configuration are deleted, the other keys of the zone keep their values, so the values that are
+// not strings are not turned into their JSON encoded form read into the state.
+func mergeDNSZoneMeta(current, old, new map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(current)+len(new))
+	for k, v := range current {
+		merged[k] = v
+	}
+	for k := range old {
+		if _, ok := new[k]; !ok {
+			delete(merged, k)
+		}
+	}
+	for k, v := range new {
+		if existing, ok := merged[k]; ok && dnsZoneMetaString(existing) == v.(string) {
+			continue
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
+// dnsZoneMetaString returns the meta value as it is read into the state, the values that are not strings are JSON encoded.
+func dnsZoneMetaString(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+func dnsZoneSettingsConfigured(d *schema.ResourceData) bool {
+	for _, k := range []string{DNSZoneSchemaContact, DNSZoneSchemaPrimaryServer, DNSZoneSchemaExpiry,
+		DNSZoneSchemaRefresh, DNSZoneSchemaRetry, DNSZoneSchemaNxTTL, DNSZoneSchemaMeta} {
+		if _, ok := d.GetOk(k); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// dnsRequest sends a request to the DNS API for the endpoints the DNS SDK doesn't cover.
+func dnsRequest(ctx context.Context, config *Config, method, uri string, body, dest interface{}) error {
+	client := config.DNSClient
+
+	var payload io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encode body: %w", err)
+		}
+		payload = bytes.NewReader(b)
+	}
+
+	endpoint, err := client.BaseURL.Parse(strings.TrimSuffix(client.BaseURL.Path, "/") + uri)
+	if err != nil {
+		return fmt.Errorf("parse endpoint: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint.String(), payload)
+	if err != nil {
+		return fmt.Errorf("new request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if config.DNSAuthHeader != nil {
+		req.Header.Set("Authorization", config.DNSAuthHeader())
+	}
+	if client.UserAgent != "" {
+		req.Header.Set("User-Agent", client.UserAgent)
+	}
+
+	resp, err := client.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		all, _ := io.ReadAll(resp.Body)
+		apiErr := dnssdk.APIError{StatusCode: resp.StatusCode}
+		if err := json.Unmarshal(all, &apiErr); err != nil {
+			apiErr.Message = string(all)
+		}
+		return apiErr
+	}
+	if dest == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(dest)
+}
+
 func dnsZoneResourceID(d *schema.ResourceData) string {
 	resourceID := d.Id()
 	if resourceID == "" {
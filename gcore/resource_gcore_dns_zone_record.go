@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
@@ -46,6 +47,9 @@ const (
 	DNSZoneRecordSchemaMetaBackup     = "backup"
 	DNSZoneRecordSchemaMetaFallback   = "fallback"
 
+	// DNSZoneRecordSchemaMetaServe is not a meta of the API, false is sent as weight 0
+	DNSZoneRecordSchemaMetaServe = "serve"
+
 	// DNSZoneRRSetSchemaMeta failover meta is inside rrset, not inside resource record
 	DNSZoneRRSetSchemaMeta = "meta"
 
@@ -67,6 +71,8 @@ const (
 	DNSZoneRRSetSchemaMetaFailoverURL            = "url"
 )
 
+var dnsRecordTypes = []string{"A", "AAAA", "MX", "CNAME", "TXT", "CAA", "NS", "SRV", "HTTPS", "SVCB"}
+
 var dnsZoneRecordSchemaMetaList = []string{
 	DNSZoneRecordSchemaMetaAsn,
 	DNSZoneRecordSchemaMetaIP,
@@ -85,30 +91,18 @@ func resourceDNSZoneRecord() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			DNSZoneRecordSchemaZone: {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
-				ValidateDiagFunc: func(i interface{}, path cty.Path) diag.Diagnostics {
-					val := i.(string)
-					if strings.TrimSpace(val) == "" || len(val) > 255 {
-						return diag.Errorf("dns record zone can't be empty, it also should be less than 256 symbols")
-					}
-					return nil
-				},
-				Description: "A zone of DNS Zone Record resource.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateDomain,
+				Description:      "A zone of DNS Zone Record resource.",
 			},
 			DNSZoneRecordSchemaDomain: {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
-				ValidateDiagFunc: func(i interface{}, path cty.Path) diag.Diagnostics {
-					val := i.(string)
-					if strings.TrimSpace(val) == "" || len(val) > 255 {
-						return diag.Errorf("dns record domain can't be empty, it also should be less than 256 symbols")
-					}
-					return nil
-				},
-				Description: "A domain of DNS Zone Record resource.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateDomain,
+				Description:      "A domain of DNS Zone Record resource.",
 			},
 			DNSZoneRecordSchemaType: {
 				Type:     schema.TypeString,
@@ -116,13 +110,12 @@ func resourceDNSZoneRecord() *schema.Resource {
 				ForceNew: true,
 				ValidateDiagFunc: func(i interface{}, path cty.Path) diag.Diagnostics {
 					val := strings.TrimSpace(i.(string))
-					types := []string{"A", "AAAA", "MX", "CNAME", "TXT", "CAA", "NS", "SRV", "HTTPS", "SVCB"}
-					for _, t := range types {
+					for _, t := range dnsRecordTypes {
 						if strings.EqualFold(t, val) {
 							return nil
 						}
 					}
-					return diag.Errorf("dns record type should be one of %v", types)
+					return diag.Errorf("dns record type should be one of %v", dnsRecordTypes)
 
 				},
 				Description: "A type of DNS Zone Record resource.",
@@ -271,9 +264,17 @@ func resourceDNSZoneRecord() *schema.Resource {
 										Description: "Computed UUID of failover healtcheck property",
 									},
 									DNSZoneRecordSchemaMetaWeight: {
-										Type:        schema.TypeInt,
-										Optional:    true,
-										Description: "A weight for this record",
+										Type:         schema.TypeInt,
+										Optional:     true,
+										ValidateFunc: validation.IntAtLeast(0),
+										Description:  "A weight for this record used by the weighted_shuffle filter. 0 or not set means the default weight of the API, use `serve = false` to drain the record.",
+									},
+									DNSZoneRecordSchemaMetaServe: {
+										Type:     schema.TypeBool,
+										Optional: true,
+										Default:  true,
+										Description: "Whether the weighted_shuffle filter selects the record. false drains the record: it is sent with weight 0, " +
+											"so it stays in the RRSet but is not returned in the answers. It can't be set together with a positive weight.",
 									},
 									DNSZoneRecordSchemaMetaFallback: {
 										Type:        schema.TypeBool,
@@ -421,14 +422,14 @@ func resourceDNSZoneRecord() *schema.Resource {
 		Description:   "Represent DNS Zone Record resource. https://dns.gcore.com/zones",
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-				parts := strings.Split(d.Id(), ":")
-				if len(parts) != 3 {
-					return nil, fmt.Errorf("format must be as zone:domain:type")
+				zone, domain, rType, err := parseDNSZoneRecordImportID(d.Id())
+				if err != nil {
+					return nil, err
 				}
-				_ = d.Set(DNSZoneRecordSchemaZone, parts[0])
-				d.SetId(parts[0])
-				_ = d.Set(DNSZoneRecordSchemaDomain, parts[1])
-				_ = d.Set(DNSZoneRecordSchemaType, parts[2])
+				_ = d.Set(DNSZoneRecordSchemaZone, zone)
+				d.SetId(zone)
+				_ = d.Set(DNSZoneRecordSchemaDomain, domain)
+				_ = d.Set(DNSZoneRecordSchemaType, rType)
 
 				return []*schema.ResourceData{d}, nil
 			},
@@ -453,7 +454,7 @@ func resourceDNSZoneRecordCreate(ctx context.Context, d *schema.ResourceData, m
 	config := m.(*Config)
 	client := config.DNSClient
 
-	_, err = client.Zone(ctx, zone)
+	_, err = findDNSZone(ctx, client, zone)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("find zone: %w", err))
 	}
@@ -540,6 +541,13 @@ func resourceDNSZoneRecordRead(ctx context.Context, d *schema.ResourceData, m in
 	for _, metaKey := range dnsZoneRecordSchemaMetaList {
 		rrMetaValidKeys[metaKey] = true
 	}
+	// the records configured with the meta of the default values only, e.g. weight = 0, keep the meta block
+	// though the API doesn't return it, otherwise it is planned to be added again
+	withMeta := map[string]bool{}
+	for _, resource := range d.Get(DNSZoneRecordSchemaResourceRecord).(*schema.Set).List() {
+		data := resource.(map[string]interface{})
+		withMeta[data[DNSZoneRecordSchemaContent].(string)] = data[DNSZoneRecordSchemaMeta].(*schema.Set).Len() > 0
+	}
 	for _, rec := range result.Records {
 		r := map[string]any{}
 		r[DNSZoneRecordSchemaEnabled] = rec.Enabled
@@ -574,7 +582,8 @@ func resourceDNSZoneRecordRead(ctx context.Context, d *schema.ResourceData, m in
 			}
 			meta[key] = val
 		}
-		if len(meta) > 0 {
+		normalizeDNSZoneRecordWeight(meta)
+		if len(meta) > 1 || meta[DNSZoneRecordSchemaMetaServe] == false || withMeta[rec.ContentToString()] {
 			r[DNSZoneRecordSchemaMeta] = []map[string]interface{}{meta}
 		} else {
 			r[DNSZoneRecordSchemaMeta] = nil
@@ -638,6 +647,58 @@ func resourceDNSZoneRecordDelete(ctx context.Context, d *schema.ResourceData, m
 	return nil
 }
 
+// parseDNSZoneRecordImportID parses the import ID in zone/domain/type or zone:domain:type format.
+// The domain may have the trailing dot, be relative to the zone or be '@' for the zone apex.
+func parseDNSZoneRecordImportID(id string) (zone, domain, rType string, err error) {
+	sep := ":"
+	if strings.Contains(id, "/") {
+		sep = "/"
+	}
+	parts := strings.Split(id, sep)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("format must be as zone/domain/type or zone:domain:type")
+	}
+
+	zone = strings.TrimSuffix(strings.TrimSpace(parts[0]), ".")
+	domain = strings.TrimSuffix(strings.TrimSpace(parts[1]), ".")
+	switch {
+	case domain == "@":
+		domain = zone
+	case !strings.EqualFold(domain, zone) && !strings.HasSuffix(strings.ToLower(domain), "."+strings.ToLower(zone)):
+		domain = domain + "." + zone
+	}
+	return zone, domain, strings.TrimSpace(parts[2]), nil
+}
+
+// normalizeDNSZoneRecordWeight converts the weight returned by the API into the weight and serve of the schema:
+// weight 0 is the drained record and the missing weight is the default one, so both are read as unset weight.
+// The serve is always set, its zero value differs from the default one.
+func normalizeDNSZoneRecordWeight(meta map[string]interface{}) {
+	meta[DNSZoneRecordSchemaMetaServe] = true
+	val, ok := meta[DNSZoneRecordSchemaMetaWeight]
+	if !ok {
+		return
+	}
+	var weight int
+	switch v := val.(type) {
+	case float64:
+		weight = int(v)
+	case int:
+		weight = v
+	case json.Number:
+		i, _ := v.Int64()
+		weight = int(i)
+	}
+	if weight > 0 {
+		meta[DNSZoneRecordSchemaMetaWeight] = weight
+		return
+	}
+	delete(meta, DNSZoneRecordSchemaMetaWeight)
+	if weight == 0 {
+		meta[DNSZoneRecordSchemaMetaServe] = false
+	}
+}
+
 func fillRRSet(d *schema.ResourceData, rType string, rrSet *dnssdk.RRSet) error {
 	// set filters
 	for _, resource := range d.Get(DNSZoneRecordSchemaFilter).([]any) {
@@ -778,8 +839,14 @@ func fillRRSet(d *schema.ResourceData, rType string, rrSet *dnssdk.RRSet) error
 				rr.AddMeta(validWrap(dnssdk.NewResourceMetaFallback()))
 			}
 
-			valInt, ok := meta[DNSZoneRecordSchemaMetaWeight].(int)
-			if ok && valInt > 0 {
+			valInt, _ := meta[DNSZoneRecordSchemaMetaWeight].(int)
+			serve, ok := meta[DNSZoneRecordSchemaMetaServe].(bool)
+			switch {
+			case ok && !serve && valInt > 0:
+				metaErrs = append(metaErrs, fmt.Errorf("weight %d can't be set for the drained record with serve = false", valInt))
+			case ok && !serve:
+				rr.AddMeta(validWrap(dnssdk.NewResourceMetaWeight(0)))
+			case valInt > 0:
 				rr.AddMeta(validWrap(dnssdk.NewResourceMetaWeight(valInt)))
 			}
 		}
@@ -9,7 +9,9 @@ import (
 	"testing"
 	"time"
 
+	dnssdk "github.com/G-Core/gcore-dns-sdk-go"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func TestAccDnsZoneRecord(t *testing.T) {
@@ -354,3 +356,59 @@ resource "%s" "%s" {
 		},
 	})
 }
+
+func TestNormalizeDNSZoneRecordWeight(t *testing.T) {
+	tests := []struct {
+		name   string
+		meta   map[string]interface{}
+		weight interface{}
+		serve  bool
+	}{
+		{"missing weight", map[string]interface{}{}, nil, true},
+		{"weight", map[string]interface{}{"weight": 5.0}, 5, true},
+		{"zero weight", map[string]interface{}{"weight": 0.0}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalizeDNSZoneRecordWeight(tt.meta)
+			if tt.meta["weight"] != tt.weight || tt.meta["serve"] != tt.serve {
+				t.Errorf("normalizeDNSZoneRecordWeight() = %v, want weight %v and serve %v", tt.meta, tt.weight, tt.serve)
+			}
+		})
+	}
+}
+
+func TestFillRRSetWeight(t *testing.T) {
+	tests := []struct {
+		name    string
+		meta    map[string]interface{}
+		weight  interface{}
+		wantErr bool
+	}{
+		{"unset weight", map[string]interface{}{"weight": 0}, nil, false},
+		{"weight", map[string]interface{}{"weight": 3}, 3, false},
+		{"drained", map[string]interface{}{"serve": false}, 0, false},
+		{"drained with weight", map[string]interface{}{"serve": false, "weight": 3}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceDNSZoneRecord().Schema, map[string]interface{}{
+				"zone":   "example.com",
+				"domain": "www.example.com",
+				"type":   "A",
+				"resource_record": []interface{}{map[string]interface{}{
+					"content": "192.0.2.1",
+					"meta":    []interface{}{tt.meta},
+				}},
+			})
+			var rrSet dnssdk.RRSet
+			err := fillRRSet(d, "A", &rrSet)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("fillRRSet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && rrSet.Records[0].Meta["weight"] != tt.weight {
+				t.Errorf("weight = %v, want %v", rrSet.Records[0].Meta["weight"], tt.weight)
+			}
+		})
+	}
+}
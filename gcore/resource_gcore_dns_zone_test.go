@@ -4,20 +4,27 @@
 package gcore
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
-	"strconv"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
 	"testing"
 	"time"
 
+	dnssdk "github.com/G-Core/gcore-dns-sdk-go"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func TestAccDnsZone(t *testing.T) {
 
 	random := time.Now().Nanosecond()
-	name := fmt.Sprintf("terraformtestkey%d", random)
+	name := fmt.Sprintf("%skey%d", testAccNamePrefix, random)
 	zone := name + ".com"
-	zoneRenamed := strconv.Itoa(random) + "." + zone
+	zoneRenamed := name + "-renamed.com"
 	resourceName := fmt.Sprintf("%s.%s", DNSZoneResource, name)
 
 	template := func(zoneName string, enableDNSSec bool) string {
@@ -79,3 +86,120 @@ resource "%s" "%s" {
 	})
 
 }
+
+func TestFindDNSZone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/zones" || r.URL.Query().Get("exact_match") != "true" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("name") {
+		case "example.com":
+			fmt.Fprint(w, `{"zones":[{"name":"example.com"}],"total_amount":1}`)
+		default:
+			fmt.Fprint(w, `{"zones":[],"total_amount":0}`)
+		}
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	client := dnssdk.NewClient(dnssdk.PermanentAPIKeyAuth("token"), func(client *dnssdk.Client) {
+		client.BaseURL = baseURL
+	})
+
+	zone, err := findDNSZone(context.Background(), client, "example.com.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if zone.Name != "example.com" {
+		t.Errorf("findDNSZone() = %s, want %s", zone.Name, "example.com")
+	}
+
+	if _, err := findDNSZone(context.Background(), client, "missing.com"); err == nil {
+		t.Errorf("findDNSZone() expected not found error")
+	}
+}
+
+func TestDNSZoneSettings(t *testing.T) {
+	var updated map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "APIKey token" {
+			t.Errorf("unexpected authorization %q", r.Header.Get("Authorization"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/zones":
+			fmt.Fprint(w, `{"zones":[{"name":"example.com","contact":"admin@example.com","primary_server":"ns1.gcorelabs.net",`+
+				`"expiry":604800,"refresh":3600,"retry":3600,"nx_ttl":300,"serial":42,"meta":{"team":"web"}}],"total_amount":1}`)
+		case r.Method == http.MethodPut && r.URL.Path == "/v2/zones/example.com":
+			if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
+				t.Error(err)
+			}
+			fmt.Fprint(w, `{}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	config := &Config{
+		DNSClient: dnssdk.NewClient(dnssdk.PermanentAPIKeyAuth("token"), func(client *dnssdk.Client) {
+			client.BaseURL = baseURL
+		}),
+		DNSAuthHeader: func() string { return "APIKey token" },
+	}
+
+	settings, err := getDNSZoneSettings(context.Background(), config, "example.com.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.Contact != "admin@example.com" || settings.NxTTL != 300 || settings.Serial != 42 {
+		t.Errorf("getDNSZoneSettings() = %+v", settings)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceDNSZone().Schema, map[string]interface{}{
+		DNSZoneSchemaName:    "example.com",
+		DNSZoneSchemaContact: "hostmaster@example.com",
+		DNSZoneSchemaNxTTL:   60,
+	})
+	if err := updateDNSZoneSettings(context.Background(), config, d, "example.com"); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"name":           "example.com",
+		"contact":        "hostmaster@example.com",
+		"primary_server": "ns1.gcorelabs.net",
+		"expiry":         float64(604800),
+		"refresh":        float64(3600),
+		"retry":          float64(3600),
+		"nx_ttl":         float64(60),
+		"meta":           map[string]interface{}{"team": "web"},
+	}
+	if !reflect.DeepEqual(updated, want) {
+		t.Errorf("updateDNSZoneSettings() sent %v, want %v", updated, want)
+	}
+}
+
+func TestMergeDNSZoneMeta(t *testing.T) {
+	current := map[string]interface{}{
+		"team":    "web",
+		"weight":  float64(10),
+		"enabled": true,
+		"geo":     map[string]interface{}{"eu": "de"},
+		"old":     "x",
+	}
+	old := map[string]interface{}{"team": "web", "weight": "10", "enabled": "true", "old": "x"}
+	new := map[string]interface{}{"team": "api", "weight": "10", "enabled": "false"}
+
+	want := map[string]interface{}{
+		"team":    "api",
+		"weight":  float64(10),
+		"enabled": "false",
+		"geo":     map[string]interface{}{"eu": "de"},
+	}
+	if got := mergeDNSZoneMeta(current, old, new); !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeDNSZoneMeta() = %v, want %v", got, want)
+	}
+}
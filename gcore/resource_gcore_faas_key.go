@@ -114,6 +114,15 @@ func resourceFaaSKey() *schema.Resource {
 				Sensitive:   true,
 				Description: "API key secret",
 			},
+			"rotation_triggers": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary map of values that, when changed, recreates the key with a new secret.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
@@ -263,6 +272,7 @@ func resourceFaaSKeyUpdate(ctx context.Context, d *schema.ResourceData, m interf
 			}
 		}
 		opts.Functions = functions
+		needUpdate = true
 	}
 
 	if needUpdate {
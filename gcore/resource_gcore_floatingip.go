@@ -7,6 +7,7 @@ import (
 	"net"
 	"time"
 
+	"github.com/AlekSi/pointer"
 	"github.com/G-Core/gcorelabscloud-go/gcore/utils"
 	"github.com/G-Core/gcorelabscloud-go/gcore/utils/metadata/v1/metadata"
 
@@ -119,6 +120,11 @@ func resourceFloatingIP() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"creator_task_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the task that created the floating IP.",
+			},
 			"last_updated": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
@@ -237,6 +243,7 @@ func resourceFloatingIPRead(ctx context.Context, d *schema.ResourceData, m inter
 		d.Set("fixed_ip_address", "")
 	}
 
+	d.Set("creator_task_id", pointer.GetString(floatingIP.CreatorTaskID))
 	d.Set("project_id", floatingIP.ProjectID)
 	d.Set("region_id", floatingIP.RegionID)
 	d.Set("status", floatingIP.Status)
@@ -2,21 +2,29 @@ package gcore
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
 	"log"
+	"math/big"
+	"net"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/AlekSi/pointer"
 	gcorecloud "github.com/G-Core/gcorelabscloud-go"
 	"github.com/G-Core/gcorelabscloud-go/gcore/floatingip/v1/floatingips"
+	imageTypes "github.com/G-Core/gcorelabscloud-go/gcore/image/v1/images/types"
 	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/instances"
 	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/types"
 	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
 	"github.com/G-Core/gcorelabscloud-go/gcore/volume/v1/volumes"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -28,6 +36,12 @@ const (
 
 	InstanceVMStateActive  = "active"
 	InstanceVMStateStopped = "stopped"
+
+	instanceLicenseSPLA        = "spla"
+	instanceLicenseBYOL        = "byol"
+	instanceLicenseMetadataKey = "license_type"
+	instanceWindowsUsername    = "Admin"
+	instanceWinRMPort          = 5986
 )
 
 func resourceInstance() *schema.Resource {
@@ -37,6 +51,15 @@ func resourceInstance() *schema.Resource {
 		UpdateContext: resourceInstanceUpdate,
 		DeleteContext: resourceInstanceDelete,
 		Description:   "Represent instance",
+		CustomizeDiff: customdiff.All(
+			customdiff.ValidateChange("name", validateNameConvention),
+			validateInstanceBootIndex,
+			validateInstanceOSType,
+			validateInstancePreservePort,
+		),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(time.Duration(InstanceCreatingTimeout) * time.Second),
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				projectID, regionID, InstanceID, err := ImportStringParser(d.Id())
@@ -132,7 +155,7 @@ func resourceInstance() *schema.Resource {
 						},
 						"boot_index": {
 							Type:        schema.TypeInt,
-							Description: "If boot_index==0 volumes can not detached",
+							Description: "Boot order of the volume. Exactly one volume must have boot_index 0, it is the boot volume that can not be detached or replaced without recreating the instance. Other bootable volumes must have unique positive values, -1 marks a volume that is not booted from",
 							Optional:    true,
 						},
 						"type_name": {
@@ -208,8 +231,14 @@ func resourceInstance() *schema.Resource {
 						"port_id": {
 							Type:        schema.TypeString,
 							Computed:    true,
-							Description: "required if type is  'reserved_fixed_ip'",
+							Description: "required if type is  'reserved_fixed_ip', ID of the pre-created port, e.g. of gcore_reservedfixedip",
+							Optional:    true,
+						},
+						"preserve_port_on_destroy": {
+							Type:        schema.TypeBool,
 							Optional:    true,
+							Default:     false,
+							Description: "Detach the port before the instance is deleted, so the port keeps its IP address and security groups for the replacing instance. Only for type 'reserved_fixed_ip'.",
 						},
 						"security_groups": {
 							Type:        schema.TypeList,
@@ -229,6 +258,18 @@ func resourceInstance() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"ssh_authorized_keys": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Description: "SSH public keys authorized for the default user in addition to the `keypair_name` key, e.g. the break-glass keys. " +
+					"The provider injects them with a cloud-config part combined with the user data, so they apply on the first boot only " +
+					"and the instance is recreated when they change. Not supported for Windows.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(sshPublicKeyRegexp, "must be an SSH public key, e.g. 'ssh-ed25519 AAAA... user@host'"),
+				},
+			},
 			"server_group": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
@@ -252,12 +293,43 @@ func resourceInstance() *schema.Resource {
 				},
 			},
 			"password": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Password of the user, for Windows instances it is the password of the Admin user. It is generated for Windows instances if it is not set.",
 			},
 			"username": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of the user created with the password, it can't be set for Windows instances.",
+			},
+			"os_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  fmt.Sprintf("OS type of the instance, '%s' or '%s'. By default it is taken from the image of the boot volume.", imageTypes.OsLinux, imageTypes.OsWindows),
+				ValidateFunc: validation.StringInSlice(imageTypes.OsLinux.StringList(), false),
+			},
+			"license_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  fmt.Sprintf("Windows license type, '%s' or '%s'. It is written to the instance metadata as '%s' for the activation at the first boot, so the instance is recreated when it changes.", instanceLicenseSPLA, instanceLicenseBYOL, instanceLicenseMetadataKey),
+				ValidateFunc: validation.StringInSlice([]string{instanceLicenseSPLA, instanceLicenseBYOL}, false),
+			},
+			"wait_for_winrm": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Wait after creation of a Windows instance until its WinRM port accepts connections.",
+			},
+			"winrm_port": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      instanceWinRMPort,
+				Description:  "WinRM port waited for if wait_for_winrm is set.",
+				ValidateFunc: validation.IsPortNumber,
 			},
 			"metadata": &schema.Schema{
 				Type:          schema.TypeList,
@@ -306,12 +378,49 @@ func resourceInstance() *schema.Resource {
 				Optional:      true,
 				Description:   "**Deprecated**",
 				Deprecated:    "Use user_data instead",
-				ConflictsWith: []string{"user_data"},
+				ConflictsWith: []string{"user_data", "user_data_part"},
 			},
 			"user_data": &schema.Schema{
 				Type:          schema.TypeString,
 				Optional:      true,
-				ConflictsWith: []string{"userdata"},
+				ConflictsWith: []string{"userdata", "user_data_part"},
+			},
+			"user_data_part": &schema.Schema{
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"userdata", "user_data"},
+				Description: "Parts of the cloud-init user data, the provider combines them into a MIME multi-part user data in the given order. Alternative for `user_data`. " +
+					"The user data applies on the first boot only, so the instance is recreated when the parts change.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"content_type": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							Description:  "MIME type of the part, e.g. 'text/cloud-config' or 'text/x-shellscript'.",
+							ValidateFunc: validation.StringInSlice(cloudInitContentTypes, false),
+						},
+						"content": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "Content of the part, not encoded.",
+						},
+						"filename": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "Filename of the part, 'part-NNN' by default.",
+						},
+						"merge_type": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "How cloud-init merges the part with the previous ones, e.g. 'list(append)+dict(recurse_array)+str()'.",
+						},
+					},
+				},
 			},
 			"allow_app_ports": &schema.Schema{
 				Type:     schema.TypeBool,
@@ -361,6 +470,22 @@ func resourceInstance() *schema.Resource {
 					},
 				},
 			},
+			"wait_for_quota": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Retry instance creation while it fails because of the exceeded quota instead of failing immediately.",
+			},
+			"creator_task_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the task that created the instance.",
+			},
+			"task_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the task running on the instance, empty when no task is running.",
+			},
 			"last_updated": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
@@ -372,6 +497,8 @@ func resourceInstance() *schema.Resource {
 
 func resourceInstanceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Println("[DEBUG] Start Instance creating")
+	// the WinRM wait gets the time left of the create timeout
+	deadline := time.Now().Add(d.Timeout(schema.TimeoutCreate))
 	var diags diag.Diagnostics
 	config := m.(*Config)
 	provider := config.Provider
@@ -387,19 +514,81 @@ func resourceInstanceCreate(ctx context.Context, d *schema.ResourceData, m inter
 
 	createOpts := instances.CreateOpts{SecurityGroups: []gcorecloud.ItemID{}}
 
+	osType := d.Get("os_type").(string)
+	if osType == "" {
+		volumesClient, err := CreateClient(provider, d, volumesPoint, versionPointV1)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		imagesClient, err := CreateClient(provider, d, imagesPoint, versionPointV1)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		osType, err = resolveInstanceOSType(volumesClient, imagesClient, d.Get("volume").(*schema.Set).List())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	d.Set("os_type", osType)
+
 	createOpts.Flavor = d.Get("flavor_id").(string)
 	createOpts.Password = d.Get("password").(string)
 	createOpts.Username = d.Get("username").(string)
+	if osType == imageTypes.OsWindows.String() {
+		if createOpts.Username != "" {
+			return diag.Errorf("username can't be set for Windows instance, the password is set for the %s user", instanceWindowsUsername)
+		}
+		if createOpts.Password == "" {
+			createOpts.Password, err = generateWindowsPassword()
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		d.Set("password", createOpts.Password)
+	}
 	createOpts.Keypair = d.Get("keypair_name").(string)
 	createOpts.ServerGroupID = d.Get("server_group").(string)
 
+	var parts []cloudInitPart
 	if userData, ok := d.GetOk("userdata"); ok {
 		createOpts.UserData = userData.(string)
 	} else if userData, ok := d.GetOk("user_data"); ok {
 		createOpts.UserData = userData.(string)
+	} else if rawParts, ok := d.GetOk("user_data_part"); ok {
+		parts = make([]cloudInitPart, 0, len(rawParts.([]interface{})))
+		for _, raw := range rawParts.([]interface{}) {
+			p := raw.(map[string]interface{})
+			parts = append(parts, cloudInitPart{
+				ContentType: p["content_type"].(string),
+				Content:     p["content"].(string),
+				Filename:    p["filename"].(string),
+				MergeType:   p["merge_type"].(string),
+			})
+		}
+		createOpts.UserData, err = buildCloudInitMultipart(parts)
+		if err != nil {
+			return diag.Errorf("build user data: %s", err)
+		}
+	}
+	if rawKeys := d.Get("ssh_authorized_keys").([]interface{}); len(rawKeys) > 0 {
+		if osType == imageTypes.OsWindows.String() {
+			return diag.Errorf("ssh_authorized_keys can't be set for Windows instance")
+		}
+		keys := make([]string, len(rawKeys))
+		for i, k := range rawKeys {
+			keys[i] = k.(string)
+		}
+		userData := createOpts.UserData
+		if parts != nil {
+			userData = ""
+		}
+		createOpts.UserData, err = cloudInitWithSSHKeys(userData, parts, keys)
+		if err != nil {
+			return diag.Errorf("build user data: %s", err)
+		}
 	}
 
-	name := d.Get("name").(string)
+	name := config.fullResourceName(d.Get("name").(string))
 	if len(name) > 0 {
 		createOpts.Names = []string{name}
 	}
@@ -451,6 +640,15 @@ func resourceInstanceCreate(ctx context.Context, d *schema.ResourceData, m inter
 		md := extractMetadataMap(metadataRaw.(map[string]interface{}))
 		createOpts.Metadata = &md
 	}
+	if license, ok := d.GetOk("license_type"); ok {
+		if createOpts.Metadata == nil {
+			createOpts.Metadata = &instances.MetadataSetOpts{}
+		}
+		createOpts.Metadata.Metadata = append(createOpts.Metadata.Metadata, instances.MetadataOpts{
+			Key:   instanceLicenseMetadataKey,
+			Value: license.(string),
+		})
+	}
 
 	configuration := d.Get("configuration")
 	if len(configuration.([]interface{})) > 0 {
@@ -462,33 +660,54 @@ func resourceInstanceCreate(ctx context.Context, d *schema.ResourceData, m inter
 	}
 
 	log.Printf("[DEBUG] Interface create options: %+v", createOpts)
-	results, err := instances.Create(clientv2, createOpts).Extract()
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	taskID := results.Tasks[0]
-	log.Printf("[DEBUG] Task id (%s)", taskID)
-	InstanceID, err := tasks.WaitTaskAndReturnResult(clientv1, taskID, true, InstanceCreatingTimeout, func(task tasks.TaskID) (interface{}, error) {
-		taskInfo, err := tasks.Get(clientv1, string(task)).Extract()
-		if err != nil {
-			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
-		}
-		Instance, err := instances.ExtractInstanceIDFromTask(taskInfo)
+	var InstanceID interface{}
+	waitForQuota := d.Get("wait_for_quota").(bool)
+	timeout := d.Timeout(schema.TimeoutCreate)
+	err = retryOnQuotaExceeded(ctx, waitForQuota, timeout, func() error {
+		results, err := instances.Create(clientv2, createOpts).Extract()
 		if err != nil {
-			return nil, fmt.Errorf("cannot retrieve Instance ID from task info: %w", err)
+			return err
 		}
-		return Instance, nil
-	},
-	)
+
+		taskID := results.Tasks[0]
+		log.Printf("[DEBUG] Task id (%s)", taskID)
+		InstanceID, err = tasks.WaitTaskAndReturnResult(clientv1, taskID, true, int(timeout.Seconds()), func(task tasks.TaskID) (interface{}, error) {
+			taskInfo, err := tasks.Get(clientv1, string(task)).Extract()
+			if err != nil {
+				return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
+			}
+			Instance, err := instances.ExtractInstanceIDFromTask(taskInfo)
+			if err != nil {
+				return nil, fmt.Errorf("cannot retrieve Instance ID from task info: %w", err)
+			}
+			return Instance, nil
+		},
+		)
+		return err
+	})
 	log.Printf("[DEBUG] Instance id (%s)", InstanceID)
 	if err != nil {
-		return diag.FromErr(err)
+		return quotaExceededDiagnostics(provider, clientv1.RegionID, err)
 	}
 
 	d.SetId(InstanceID.(string))
 	resourceInstanceRead(ctx, d, m)
 
+	if osType == imageTypes.OsWindows.String() && d.Get("wait_for_winrm").(bool) {
+		address := instanceAccessAddress(d.Get("addresses").([]interface{}))
+		if address == "" {
+			return diag.Errorf("instance %s has no address to wait for WinRM", InstanceID)
+		}
+		endpoint := net.JoinHostPort(address, strconv.Itoa(d.Get("winrm_port").(int)))
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return diag.Errorf("wait for WinRM of instance %s: create timeout exceeded", InstanceID)
+		}
+		if err := waitForTCPEndpoint(ctx, endpoint, remaining); err != nil {
+			return diag.Errorf("wait for WinRM of instance %s: %s", InstanceID, err)
+		}
+	}
+
 	log.Printf("[DEBUG] Finish Instance creating (%s)", InstanceID)
 	return diags
 }
@@ -518,7 +737,9 @@ func resourceInstanceRead(ctx context.Context, d *schema.ResourceData, m interfa
 		}
 	}
 
-	d.Set("name", instance.Name)
+	setResourceName(d, config, instance.Name)
+	d.Set("creator_task_id", pointer.GetString(instance.CreatorTaskID))
+	d.Set("task_id", pointer.GetString(instance.TaskID))
 	d.Set("flavor_id", instance.Flavor.FlavorID)
 	d.Set("status", instance.Status)
 	d.Set("vm_state", instance.VMState)
@@ -570,6 +791,7 @@ func resourceInstanceRead(ctx context.Context, d *schema.ResourceData, m interfa
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	preservedPorts := instancePreservedPorts(d.Get("interface").([]interface{}))
 
 	var cleanInterfaces []interface{}
 	for ifOrder, iface := range ifs {
@@ -603,6 +825,7 @@ func resourceInstanceRead(ctx context.Context, d *schema.ResourceData, m interfa
 			i["network_id"] = iface.NetworkID
 			i["subnet_id"] = subnetID
 			i["port_id"] = iface.PortID
+			_, i["preserve_port_on_destroy"] = preservedPorts[iface.PortID]
 			i["order"] = orderedIOpts.Order
 			if len(iface.FloatingIPDetails) > 0 {
 				i["fip_source"] = types.ExistingFloatingIP
@@ -697,7 +920,7 @@ func resourceInstanceUpdate(ctx context.Context, d *schema.ResourceData, m inter
 		nameTemplate := d.Get("name_template").(string)
 		if len(nameTemplate) == 0 && len(nameTemplates) == 0 {
 			opts := instances.RenameInstanceOpts{
-				Name: d.Get("name").(string),
+				Name: config.fullResourceName(d.Get("name").(string)),
 			}
 			if _, err := instances.RenameInstance(client, instanceID, opts).Extract(); err != nil {
 				return diag.FromErr(err)
@@ -975,6 +1198,12 @@ func resourceInstanceDelete(ctx context.Context, d *schema.ResourceData, m inter
 		return diag.FromErr(err)
 	}
 
+	for portID, ipAddress := range instancePreservedPorts(d.Get("interface").([]interface{})) {
+		if err := detachInstancePort(client, instanceID, portID, ipAddress); err != nil {
+			return diag.Errorf("cannot detach preserved port %s. Error: %s", portID, err)
+		}
+	}
+
 	var delOpts instances.DeleteOpts
 	results, err := instances.Delete(client, instanceID, delOpts).Extract()
 	if err != nil {
@@ -1046,3 +1275,217 @@ func prepareSecurityGroups(ports []instances.InstancePorts) []interface{} {
 	}
 	return secGroups
 }
+
+// validateInstanceOSType checks that the Windows specific attributes are used only with the Windows instance.
+// When os_type is not set, the OS type of a new instance is resolved from the image of the boot volume at the plan.
+func validateInstanceOSType(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("os_type") {
+		return nil
+	}
+	osType := d.Get("os_type").(string)
+	if osType == "" && d.Id() == "" {
+		var err error
+		if osType, err = planInstanceOSType(d, meta); err != nil {
+			log.Printf("[WARN] Skipping the OS type check of the instance: %s", err)
+			return nil
+		}
+		if osType == "" {
+			return nil
+		}
+		if err := d.SetNew("os_type", osType); err != nil {
+			return err
+		}
+	}
+	switch osType {
+	case imageTypes.OsWindows.String():
+		if d.Get("username").(string) != "" {
+			return fmt.Errorf("username can't be set for Windows instance, the password is set for the %s user", instanceWindowsUsername)
+		}
+	case imageTypes.OsLinux.String():
+		if d.Get("license_type").(string) != "" {
+			return fmt.Errorf("license_type can be set only for Windows instance")
+		}
+		if d.Get("wait_for_winrm").(bool) {
+			return fmt.Errorf("wait_for_winrm can be set only for Windows instance")
+		}
+	}
+	return nil
+}
+
+// planInstanceOSType resolves the OS type of the new instance, it is empty when the boot volume is not known yet.
+func planInstanceOSType(d *schema.ResourceDiff, meta interface{}) (string, error) {
+	for _, key := range []string{"volume", "project_id", "project_name", "region_id", "region_name"} {
+		if !d.NewValueKnown(key) {
+			return "", nil
+		}
+	}
+	config, ok := meta.(*Config)
+	if !ok || config.Provider == nil {
+		return "", nil
+	}
+	volumesClient, err := createDiffClient(config.Provider, d, volumesPoint, versionPointV1)
+	if err != nil {
+		return "", err
+	}
+	imagesClient, err := createDiffClient(config.Provider, d, imagesPoint, versionPointV1)
+	if err != nil {
+		return "", err
+	}
+	return resolveInstanceOSType(volumesClient, imagesClient, d.Get("volume").(*schema.Set).List())
+}
+
+// validateInstancePreservePort checks that only the pre-created ports are preserved,
+// the ports the instance creates for the other interface types are deleted on detaching anyway.
+func validateInstancePreservePort(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	for i, raw := range d.Get("interface").([]interface{}) {
+		if raw == nil {
+			continue
+		}
+		iface := raw.(map[string]interface{})
+		if iface["preserve_port_on_destroy"].(bool) && iface["type"].(string) != types.ReservedFixedIpType.String() {
+			return fmt.Errorf("interface.%d: preserve_port_on_destroy can be set only for the '%s' type", i, types.ReservedFixedIpType)
+		}
+	}
+	return nil
+}
+
+// instancePreservedPorts returns the IP addresses of the ports detached before the instance is deleted by the port IDs.
+func instancePreservedPorts(interfaces []interface{}) map[string]string {
+	ports := make(map[string]string)
+	for _, raw := range interfaces {
+		if raw == nil {
+			continue
+		}
+		iface := raw.(map[string]interface{})
+		if preserve, _ := iface["preserve_port_on_destroy"].(bool); preserve && iface["port_id"].(string) != "" {
+			ports[iface["port_id"].(string)] = iface["ip_address"].(string)
+		}
+	}
+	return ports
+}
+
+func detachInstancePort(client *gcorecloud.ServiceClient, instanceID, portID, ipAddress string) error {
+	log.Printf("[DEBUG] Detach preserved port %s of instance %s", portID, instanceID)
+	results, err := instances.DetachInterface(client, instanceID, instances.InterfaceOpts{PortID: portID, IpAddress: ipAddress}).Extract()
+	if err != nil {
+		return err
+	}
+	return tasks.WaitForStatus(client, string(results.Tasks[0]), tasks.TaskStateFinished, InstanceCreatingTimeout, true)
+}
+
+// instanceImageOSType is the OS type of the image, the images of gcorelabscloud-go don't have the os_type field.
+type instanceImageOSType struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	OSType string `json:"os_type"`
+}
+
+// resolveInstanceOSType returns the OS type of the image of the boot volume: the image_id of the boot volume block,
+// or the image the existing boot volume is created from, found by its ID or, without the ID, by its name.
+// It is linux when there is no boot volume or the volume has no image.
+func resolveInstanceOSType(volumesClient, imagesClient *gcorecloud.ServiceClient, volumesList []interface{}) (string, error) {
+	bootVolume := instanceBootVolume(volumesList)
+	if bootVolume == nil {
+		return imageTypes.OsLinux.String(), nil
+	}
+
+	image := instanceImageOSType{ID: bootVolume["image_id"].(string)}
+	if image.ID == "" {
+		volumeID := bootVolume["volume_id"].(string)
+		if volumeID == "" {
+			return imageTypes.OsLinux.String(), nil
+		}
+		volume, err := volumes.Get(volumesClient, volumeID).Extract()
+		if err != nil {
+			return "", fmt.Errorf("get boot volume %s: %w", volumeID, err)
+		}
+		image.ID, image.Name = volume.VolumeImageMetadata.ImageID, volume.VolumeImageMetadata.ImageName
+	}
+
+	switch {
+	case image.ID != "":
+		if _, err := imagesClient.Get(imagesClient.ServiceURL(image.ID), &image, nil); err != nil {
+			return "", fmt.Errorf("get image %s of boot volume: %w", image.ID, err)
+		}
+	case image.Name != "":
+		var list struct {
+			Results []instanceImageOSType `json:"results"`
+		}
+		if _, err := imagesClient.Get(imagesClient.ServiceURL(), &list, nil); err != nil {
+			return "", fmt.Errorf("list images to find image %s of boot volume: %w", image.Name, err)
+		}
+		for _, i := range list.Results {
+			if i.Name == image.Name {
+				image = i
+				break
+			}
+		}
+	}
+	if image.OSType == imageTypes.OsWindows.String() {
+		return image.OSType, nil
+	}
+	return imageTypes.OsLinux.String(), nil
+}
+
+// generateWindowsPassword returns a random password satisfying the Windows complexity requirements.
+func generateWindowsPassword() (string, error) {
+	classes := []string{
+		"abcdefghijkmnopqrstuvwxyz",
+		"ABCDEFGHJKLMNPQRSTUVWXYZ",
+		"23456789",
+		"!#%*+-=?@^_",
+	}
+	const length = 24
+	password := make([]byte, length)
+	for i := range password {
+		// every class is used at least once, the rest characters are taken from all of them
+		class := strings.Join(classes, "")
+		if i < len(classes) {
+			class = classes[i]
+		}
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(class))))
+		if err != nil {
+			return "", fmt.Errorf("generate password: %w", err)
+		}
+		password[i] = class[n.Int64()]
+	}
+	for i := len(password) - 1; i > 0; i-- {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", fmt.Errorf("generate password: %w", err)
+		}
+		j := n.Int64()
+		password[i], password[j] = password[j], password[i]
+	}
+	return string(password), nil
+}
+
+// instanceAccessAddress returns the floating address of the instance if any, the first fixed address otherwise.
+func instanceAccessAddress(addresses []interface{}) string {
+	var fixed string
+	for _, a := range addresses {
+		for _, n := range a.(map[string]interface{})["net"].([]interface{}) {
+			addr := n.(map[string]interface{})
+			if addr["type"].(string) == "floating" {
+				return addr["addr"].(string)
+			}
+			if fixed == "" {
+				fixed = addr["addr"].(string)
+			}
+		}
+	}
+	return fixed
+}
+
+// waitForTCPEndpoint waits until the endpoint accepts TCP connections.
+func waitForTCPEndpoint(ctx context.Context, endpoint string, timeout time.Duration) error {
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		conn, err := net.DialTimeout("tcp", endpoint, 10*time.Second)
+		if err != nil {
+			log.Printf("[DEBUG] %s is not reachable yet: %s", endpoint, err)
+			return retry.RetryableError(err)
+		}
+		conn.Close()
+		return nil
+	})
+}
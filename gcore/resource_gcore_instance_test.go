@@ -164,7 +164,7 @@ func TestAccInstance(t *testing.T) {
 	}
 
 	createFixt := instances.CreateOpts{
-		Names:          []string{"create_instance"},
+		Names:          []string{testAccNamePrefix + "instance"},
 		NameTemplates:  []string{},
 		Flavor:         "g1-standard-2-4",
 		Password:       "password",
@@ -10,13 +10,17 @@ import (
 	"time"
 
 	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/instances"
 	"github.com/G-Core/gcorelabscloud-go/gcore/k8s/v2/clusters"
 	"github.com/G-Core/gcorelabscloud-go/gcore/k8s/v2/pools"
+	"github.com/G-Core/gcorelabscloud-go/gcore/securitygroup/v1/securitygroups"
 	"github.com/G-Core/gcorelabscloud-go/gcore/servergroup/v1/servergroups"
 	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
+	"github.com/G-Core/gcorelabscloud-go/gcore/utils/metadata/v1/metadata"
 	"github.com/G-Core/gcorelabscloud-go/gcore/volume/v1/volumes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -292,11 +296,32 @@ func resourceK8sV2() *schema.Resource {
 							Optional:    true,
 							Computed:    true,
 						},
+						"metadata_map": {
+							Type:        schema.TypeMap,
+							Description: "Metadata applied to the cluster pool node instances. Overrides the cluster metadata with the same keys.",
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"security_group_ids": {
+							Type:        schema.TypeSet,
+							Description: "IDs of additional security groups attached to the ports of the cluster pool nodes. The groups are attached by name, so their names must be unique in the project. Read back from the ports of the pool nodes, a group detached from any node shows up as a diff.",
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
 						"status": {
 							Type:        schema.TypeString,
 							Description: "Cluster pool status.",
 							Computed:    true,
 						},
+						"active_node_count": {
+							Type:        schema.TypeInt,
+							Description: "Number of the cluster pool nodes whose instances are active. The kubernetes node readiness is not checked. Read only with the k8s_active_node_count provider feature.",
+							Computed:    true,
+						},
 						"servergroup_name": {
 							Type:        schema.TypeString,
 							Description: "Server group name",
@@ -315,6 +340,22 @@ func resourceK8sV2() *schema.Resource {
 					},
 				},
 			},
+			"logging": k8sV2LoggingSchema(),
+			"metadata_map": {
+				Type: schema.TypeMap,
+				Description: "Metadata applied to all cluster node instances, e.g. cost allocation tags. It is applied when the cluster is created and when the metadata or the pools change. " +
+					"The node metadata is not read back, so the nodes added later by autoscaling don't get it until the next change, and the changes made outside of terraform are not detected.",
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"wait_for_nodes_active": {
+				Type:        schema.TypeBool,
+				Description: "Wait after the cluster and its pools are created or resized until every pool has node_count (at least min_node_count) active node instances. The kubernetes node readiness is not checked.",
+				Optional:    true,
+				Default:     false,
+			},
 			"status": {
 				Type:        schema.TypeString,
 				Description: "Cluster status.",
@@ -369,13 +410,16 @@ func resourceK8sV2Create(ctx context.Context, d *schema.ResourceData, m interfac
 		return diag.FromErr(err)
 	}
 
-	opts := clusters.CreateOpts{
+	opts := k8sV2CreateOpts{CreateOpts: clusters.CreateOpts{
 		Name:         d.Get("name").(string),
 		FixedNetwork: d.Get("fixed_network").(string),
 		FixedSubnet:  d.Get("fixed_subnet").(string),
 		KeyPair:      d.Get("keypair").(string),
 		Version:      d.Get("version").(string),
 		IsIPV6:       d.Get("is_ipv6").(bool),
+	}}
+	if _, ok := d.GetOk("logging"); ok {
+		opts.Logging = k8sV2LoggingFromSchema(d)
 	}
 
 	if cniI, ok := d.GetOk("cni"); ok {
@@ -485,6 +529,19 @@ func resourceK8sV2Create(ctx context.Context, d *schema.ResourceData, m interfac
 	}
 
 	d.SetId(clusterName.(string))
+
+	if err := resourceK8sV2ApplyNodesMetadata(provider, d, client, opts.Name, nil, d.Get("pool").([]interface{})); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := resourceK8sV2ApplyNodesSecurityGroups(provider, d, client, opts.Name, nil, d.Get("pool").([]interface{})); err != nil {
+		return diag.FromErr(err)
+	}
+	if d.Get("wait_for_nodes_active").(bool) {
+		if err := resourceK8sV2WaitForNodesActive(ctx, client, opts.Name, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	resourceK8sV2Read(ctx, d, m)
 
 	log.Printf("[DEBUG] Finish k8s cluster creating (%s)", clusterName)
@@ -503,7 +560,12 @@ func resourceK8sV2Read(ctx context.Context, d *schema.ResourceData, m interface{
 	}
 
 	clusterName := d.Get("name").(string)
-	cluster, err := clusters.Get(client, clusterName).Extract()
+	result := clusters.Get(client, clusterName)
+	cluster, err := result.Extract()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	logging, err := k8sV2GetLogging(result)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -549,6 +611,9 @@ func resourceK8sV2Read(ctx context.Context, d *schema.ResourceData, m interface{
 		}
 		d.Set("cni", []interface{}{v})
 	}
+	if err := k8sV2SetLoggingState(d, logging); err != nil {
+		return diag.FromErr(err)
+	}
 
 	poolMap := map[string]pools.ClusterPool{}
 	for _, pool := range cluster.Pools {
@@ -558,11 +623,35 @@ func resourceK8sV2Read(ctx context.Context, d *schema.ResourceData, m interface{
 	// Returned pool order needs to match TF state or users will see broken diff,
 	// so we first process all pools stored in the state file, and then append any remaining pools.
 	var poolData []interface{}
+	var instancesClient *gcorecloud.ServiceClient
 	for _, rawPool := range d.Get("pool").([]interface{}) {
 		pool := rawPool.(map[string]interface{})
 		poolName := pool["name"].(string)
 		if p, ok := poolMap[poolName]; ok {
-			poolData = append(poolData, resourceK8sV2PoolDataFromPool(p))
+			data := resourceK8sV2PoolDataFromPool(p).(map[string]interface{})
+			// node metadata is not returned by the pool API, keep the value from the state
+			data["metadata_map"] = pool["metadata_map"]
+			if ids := resourceK8sV2PoolSecurityGroupIDs(pool); len(ids) > 0 {
+				if instancesClient == nil {
+					instancesClient, err = CreateClient(provider, d, InstancePoint, versionPointV1)
+					if err != nil {
+						return diag.FromErr(err)
+					}
+				}
+				attached, err := resourceK8sV2AttachedSecurityGroups(instancesClient, client, clusterName, poolName, ids)
+				if err != nil {
+					return diag.FromErr(err)
+				}
+				data["security_group_ids"] = attached
+			}
+			if config.Features.K8sNodeCount {
+				activeNodes, err := resourceK8sV2ActiveNodeCount(client, clusterName, poolName)
+				if err != nil {
+					return diag.FromErr(err)
+				}
+				data["active_node_count"] = activeNodes
+			}
+			poolData = append(poolData, data)
 			delete(poolMap, poolName)
 		} else {
 			// prevent breaking diff when a pool from state file is missing
@@ -617,6 +706,12 @@ func resourceK8sV2Update(ctx context.Context, d *schema.ResourceData, m interfac
 		}
 	}
 
+	if d.HasChange("logging") {
+		if err := k8sV2UpdateLogging(client, tasksClient, clusterName, k8sV2LoggingFromSchema(d)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	if d.HasChange("pool") {
 		// 1 pool   => Allow in-place updates and add/delete, but return error on replace.
 		//             Users must create a new pool with different name in such case.
@@ -670,6 +765,25 @@ func resourceK8sV2Update(ctx context.Context, d *schema.ResourceData, m interfac
 		}
 	}
 
+	if d.HasChange("metadata_map") || d.HasChange("pool") {
+		o, n := d.GetChange("pool")
+		if err := resourceK8sV2ApplyNodesMetadata(provider, d, client, clusterName, o.([]interface{}), n.([]interface{})); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("pool") {
+		o, n := d.GetChange("pool")
+		if err := resourceK8sV2ApplyNodesSecurityGroups(provider, d, client, clusterName, o.([]interface{}), n.([]interface{})); err != nil {
+			return diag.FromErr(err)
+		}
+		if d.Get("wait_for_nodes_active").(bool) {
+			if err := resourceK8sV2WaitForNodesActive(ctx, client, clusterName, d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
 	diags := resourceK8sV2Read(ctx, d, m)
 	log.Printf("[DEBUG] Finish k8s cluster updating (%s)", clusterName)
 	return diags
@@ -919,6 +1033,281 @@ func resourceK8sV2PoolDataFromPool(pool pools.ClusterPool) interface{} {
 	}
 }
 
+// resourceK8sV2ActiveNodeCount returns the number of the pool nodes whose instances are active.
+func resourceK8sV2ActiveNodeCount(client *gcorecloud.ServiceClient, clusterName, poolName string) (int, error) {
+	nodes, err := pools.ListInstancesAll(client, clusterName, poolName)
+	if err != nil {
+		return 0, fmt.Errorf("list cluster pool %s instances: %w", poolName, err)
+	}
+	var active int
+	for _, node := range nodes {
+		if strings.EqualFold(node.VMState, InstanceVMStateActive) {
+			active++
+		}
+	}
+	return active, nil
+}
+
+// resourceK8sV2WaitForNodesActive waits until every pool of the cluster has the expected number of active node instances,
+// so the workloads deployed right after the cluster don't start on an empty cluster.
+// An active instance may still be joining the cluster, the kubernetes node readiness is not exposed by the API.
+func resourceK8sV2WaitForNodesActive(ctx context.Context, client *gcorecloud.ServiceClient, clusterName string, timeout time.Duration) error {
+	log.Printf("[DEBUG] Waiting for k8s cluster %s nodes to be active", clusterName)
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		cluster, err := clusters.Get(client, clusterName).Extract()
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+		for _, pool := range cluster.Pools {
+			expected := pool.NodeCount
+			if expected < pool.MinNodeCount {
+				expected = pool.MinNodeCount
+			}
+			active, err := resourceK8sV2ActiveNodeCount(client, clusterName, pool.Name)
+			if err != nil {
+				return retry.NonRetryableError(err)
+			}
+			if active < expected {
+				return retry.RetryableError(fmt.Errorf("cluster pool %s has %d of %d nodes active", pool.Name, active, expected))
+			}
+		}
+		return nil
+	})
+}
+
+// resourceK8sV2ApplyNodesMetadata sets cluster and pool metadata on the pool node instances
+// and removes the keys that were dropped from the configuration. It runs on create and on the changes
+// of the metadata or the pools only, the nodes added by autoscaling in between are not tagged.
+func resourceK8sV2ApplyNodesMetadata(provider *gcorecloud.ProviderClient, d *schema.ResourceData, client *gcorecloud.ServiceClient, clusterName string, old, new []interface{}) error {
+	oldClusterMeta, newClusterMeta := d.GetChange("metadata_map")
+
+	var instancesClient *gcorecloud.ServiceClient
+	for _, p := range new {
+		pool, ok := p.(map[string]interface{})
+		if !ok || len(pool) == 0 {
+			continue
+		}
+		newMeta := resourceK8sV2NodeMetadata(newClusterMeta, pool["metadata_map"])
+		oldMeta := map[string]string{}
+		if found := resourceK8sV2FindClusterPool(old, pool); found != nil && !resourceK8sV2ClusterPoolNeedsReplace(old, pool) {
+			oldMeta = resourceK8sV2NodeMetadata(oldClusterMeta, found.(map[string]interface{})["metadata_map"])
+		}
+		if len(newMeta) == 0 && len(oldMeta) == 0 {
+			continue
+		}
+
+		if instancesClient == nil {
+			var err error
+			instancesClient, err = CreateClient(provider, d, InstancePoint, versionPointV1)
+			if err != nil {
+				return err
+			}
+		}
+
+		poolName := pool["name"].(string)
+		nodes, err := pools.ListInstancesAll(client, clusterName, poolName)
+		if err != nil {
+			return fmt.Errorf("list cluster pool %s instances: %w", poolName, err)
+		}
+		for _, node := range nodes {
+			log.Printf("[DEBUG] Setting metadata of cluster pool (%s) node (%s)", poolName, node.ID)
+			for k := range oldMeta {
+				if _, ok := newMeta[k]; ok {
+					continue
+				}
+				err := metadata.MetadataDelete(instancesClient, node.ID, k).Err
+				if err != nil {
+					if _, ok := err.(gcorecloud.ErrDefault404); !ok {
+						return fmt.Errorf("delete metadata %s of node %s: %w", k, node.ID, err)
+					}
+				}
+			}
+			if len(newMeta) > 0 {
+				if err := metadata.MetadataCreateOrUpdate(instancesClient, node.ID, newMeta).Err; err != nil {
+					return fmt.Errorf("update metadata of node %s: %w", node.ID, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// resourceK8sV2ApplyNodesSecurityGroups attaches the pool security groups to the pool node instances
+// and detaches the groups that were dropped from the configuration.
+func resourceK8sV2ApplyNodesSecurityGroups(provider *gcorecloud.ProviderClient, d *schema.ResourceData, client *gcorecloud.ServiceClient, clusterName string, old, new []interface{}) error {
+	var instancesClient *gcorecloud.ServiceClient
+	var groups []securitygroups.SecurityGroup
+	for _, p := range new {
+		pool, ok := p.(map[string]interface{})
+		if !ok || len(pool) == 0 {
+			continue
+		}
+		newIDs := resourceK8sV2PoolSecurityGroupIDs(pool)
+		var oldIDs []string
+		if found := resourceK8sV2FindClusterPool(old, pool); found != nil && !resourceK8sV2ClusterPoolNeedsReplace(old, pool) {
+			oldIDs = resourceK8sV2PoolSecurityGroupIDs(found.(map[string]interface{}))
+		}
+		if len(newIDs) == 0 && len(oldIDs) == 0 {
+			continue
+		}
+
+		if instancesClient == nil {
+			var err error
+			instancesClient, err = CreateClient(provider, d, InstancePoint, versionPointV1)
+			if err != nil {
+				return err
+			}
+			sgClient, err := CreateClient(provider, d, securityGroupPoint, versionPointV1)
+			if err != nil {
+				return err
+			}
+			groups, err = securitygroups.ListAll(sgClient, securitygroups.ListOpts{})
+			if err != nil {
+				return fmt.Errorf("list security groups: %w", err)
+			}
+		}
+
+		newNames, err := resourceK8sV2SecurityGroupNames(groups, newIDs)
+		if err != nil {
+			return err
+		}
+		oldNames, err := resourceK8sV2SecurityGroupNames(groups, oldIDs)
+		if err != nil {
+			return err
+		}
+
+		poolName := pool["name"].(string)
+		nodes, err := pools.ListInstancesAll(client, clusterName, poolName)
+		if err != nil {
+			return fmt.Errorf("list cluster pool %s instances: %w", poolName, err)
+		}
+		for _, node := range nodes {
+			attached := map[string]bool{}
+			for _, sg := range node.SecurityGroups {
+				attached[sg.Name] = true
+			}
+			for id, name := range oldNames {
+				if _, ok := newNames[id]; ok || !attached[name] {
+					continue
+				}
+				log.Printf("[DEBUG] Detaching security group %s from cluster pool (%s) node (%s)", name, poolName, node.ID)
+				opts := instances.SecurityGroupOpts{Name: name}
+				if err := instances.UnAssignSecurityGroup(instancesClient, node.ID, opts).ExtractErr(); err != nil {
+					return fmt.Errorf("detach security group %s from node %s: %w", name, node.ID, err)
+				}
+			}
+			for _, name := range newNames {
+				if attached[name] {
+					continue
+				}
+				log.Printf("[DEBUG] Attaching security group %s to cluster pool (%s) node (%s)", name, poolName, node.ID)
+				opts := instances.SecurityGroupOpts{Name: name}
+				if err := instances.AssignSecurityGroup(instancesClient, node.ID, opts).ExtractErr(); err != nil {
+					return fmt.Errorf("attach security group %s to node %s: %w", name, node.ID, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// resourceK8sV2AttachedSecurityGroups returns the configured pool security groups that are attached to the ports
+// of every pool node, so groups detached outside of terraform show up as a diff. Only the configured groups are
+// looked up, the groups attached to the nodes by the cluster itself are not part of the state.
+func resourceK8sV2AttachedSecurityGroups(instancesClient, client *gcorecloud.ServiceClient, clusterName, poolName string, ids []string) ([]string, error) {
+	nodes, err := pools.ListInstancesAll(client, clusterName, poolName)
+	if err != nil {
+		return nil, fmt.Errorf("list cluster pool %s instances: %w", poolName, err)
+	}
+
+	nodePorts := make([][]instances.InstancePorts, 0, len(nodes))
+	for _, node := range nodes {
+		ports, err := instances.ListPortsAll(instancesClient, node.ID)
+		if err != nil {
+			return nil, fmt.Errorf("list instance %s ports: %w", node.ID, err)
+		}
+		nodePorts = append(nodePorts, ports)
+	}
+	return resourceK8sV2PortsSecurityGroupIDs(nodePorts, ids), nil
+}
+
+// resourceK8sV2PortsSecurityGroupIDs returns the IDs attached to a port of every node, in the configured order.
+func resourceK8sV2PortsSecurityGroupIDs(nodePorts [][]instances.InstancePorts, ids []string) []string {
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		attachedToAll := true
+		for _, ports := range nodePorts {
+			var found bool
+			for _, port := range ports {
+				for _, sg := range port.SecurityGroups {
+					if sg.ID == id {
+						found = true
+					}
+				}
+			}
+			if !found {
+				attachedToAll = false
+				break
+			}
+		}
+		if attachedToAll {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// resourceK8sV2SecurityGroupNames resolves security group IDs to names, the instance API attaches groups by name.
+// Names shared by several groups are rejected, since the API cannot tell which group to attach.
+// Groups that no longer exist are skipped.
+func resourceK8sV2SecurityGroupNames(groups []securitygroups.SecurityGroup, ids []string) (map[string]string, error) {
+	byID := make(map[string]string, len(groups))
+	count := make(map[string]int, len(groups))
+	for _, sg := range groups {
+		byID[sg.ID] = sg.Name
+		count[sg.Name]++
+	}
+
+	names := make(map[string]string, len(ids))
+	for _, id := range ids {
+		name, ok := byID[id]
+		if !ok {
+			log.Printf("[DEBUG] Security group %s not found", id)
+			continue
+		}
+		if count[name] > 1 {
+			return nil, fmt.Errorf("security group %s name %q is not unique, rename it to attach it to the cluster pool nodes", id, name)
+		}
+		names[id] = name
+	}
+	return names, nil
+}
+
+func resourceK8sV2PoolSecurityGroupIDs(pool map[string]interface{}) []string {
+	var ids []string
+	if s, ok := pool["security_group_ids"].(*schema.Set); ok {
+		for _, id := range s.List() {
+			ids = append(ids, id.(string))
+		}
+	}
+	return ids
+}
+
+// resourceK8sV2NodeMetadata merges cluster metadata with pool metadata, pool values take precedence.
+func resourceK8sV2NodeMetadata(clusterMeta, poolMeta interface{}) map[string]string {
+	result := map[string]string{}
+	for _, raw := range []interface{}{clusterMeta, poolMeta} {
+		meta, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for k, v := range meta {
+			result[k] = v.(string)
+		}
+	}
+	return result
+}
+
 func resourceK8sV2FilteredPoolLabels(labels map[string]string) map[string]string {
 	result := map[string]string{}
 	for k, v := range labels {
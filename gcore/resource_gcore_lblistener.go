@@ -6,6 +6,7 @@ import (
 	"log"
 	"time"
 
+	"github.com/AlekSi/pointer"
 	gcorecloud "github.com/G-Core/gcorelabscloud-go"
 	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/listeners"
 	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/loadbalancers"
@@ -13,8 +14,10 @@ import (
 	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
@@ -31,6 +34,7 @@ func resourceLbListener() *schema.Resource {
 		UpdateContext: resourceLBListenerUpdate,
 		DeleteContext: resourceLBListenerDelete,
 		Description:   "Represent load balancer listener. Can not be created without load balancer. A listener is a process that checks for connection requests, using the protocol and port that you configure",
+		CustomizeDiff: customdiff.ValidateChange("name", validateNameConvention),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(LBListenerResourceTimeoutMinutes * time.Minute),
 			Delete: schema.DefaultTimeout(LBListenerResourceTimeoutMinutes * time.Minute),
@@ -96,10 +100,11 @@ func resourceLbListener() *schema.Resource {
 				},
 			},
 			"loadbalancer_id": &schema.Schema{
-				Type:        schema.TypeString,
-				Description: "ID of the target load balancer to attach newly created listener.",
-				Required:    true,
-				ForceNew:    true,
+				Type:             schema.TypeString,
+				Description:      "ID of the target load balancer to attach newly created listener.",
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateUUID,
 			},
 			"name": &schema.Schema{
 				Type:        schema.TypeString,
@@ -121,10 +126,11 @@ func resourceLbListener() *schema.Resource {
 				},
 			},
 			"protocol_port": &schema.Schema{
-				Type:        schema.TypeInt,
-				Description: "Port number to listen, between 1 and 65535.",
-				Required:    true,
-				ForceNew:    true,
+				Type:             schema.TypeInt,
+				Description:      "Port number to listen, between 1 and 65535.",
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validatePortNumber,
 			},
 			"insert_x_forwarded": &schema.Schema{
 				Type:        schema.TypeBool,
@@ -149,12 +155,12 @@ func resourceLbListener() *schema.Resource {
 			},
 			"secret_id": &schema.Schema{
 				Type:        schema.TypeString,
-				Description: "Secret ID to use with 'TERMINATED_HTTPS' protocol.",
+				Description: "Secret ID to use with 'TERMINATED_HTTPS' protocol. Changing it replaces the certificate of the listener in place without downtime.",
 				Optional:    true,
 			},
 			"sni_secret_id": &schema.Schema{
 				Type:        schema.TypeList,
-				Description: "List of additional Secret IDs to use with 'TERMINATED_HTTPS' protocol.",
+				Description: "List of additional Secret IDs to use with 'TERMINATED_HTTPS' protocol, they are replaced in place too.",
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
 			},
@@ -164,6 +170,28 @@ func resourceLbListener() *schema.Resource {
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
 			},
+			"creator_task_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the task that created the listener.",
+			},
+			"task_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the task running on the listener, empty when no task is running.",
+			},
+			"conflict_retry_interval": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Interval in seconds between the retries of the requests rejected because the load balancer is busy with another change (409 Conflict). Defaults to TF_CONFLICT_RETRY_INTERVAL_SECONDS environment variable or 10.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"conflict_retry_amount": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Number of the retries of the requests rejected because the load balancer is busy with another change (409 Conflict). Defaults to the timeout of the operation divided by the retry interval.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
 			"last_updated": &schema.Schema{
 				Type:        schema.TypeString,
 				Description: "Datetime when load balancer was updated at the last time.",
@@ -235,7 +263,7 @@ func resourceLBListenerCreate(ctx context.Context, d *schema.ResourceData, m int
 	}
 
 	opts := listeners.CreateOpts{
-		Name:             d.Get("name").(string),
+		Name:             config.fullResourceName(d.Get("name").(string)),
 		Protocol:         types.ProtocolType(d.Get("protocol").(string)),
 		ProtocolPort:     d.Get("protocol_port").(int),
 		LoadBalancerID:   d.Get("loadbalancer_id").(string),
@@ -286,7 +314,7 @@ func resourceLBListenerCreate(ctx context.Context, d *schema.ResourceData, m int
 		opts.UserList = userList
 	}
 	timeout := int(d.Timeout(schema.TimeoutCreate).Seconds())
-	rc := GetConflictRetryConfig(timeout)
+	rc := GetResourceConflictRetryConfig(d, timeout)
 	results, err := listeners.Create(client, opts, &gcorecloud.RequestOpts{
 		ConflictRetryAmount:   rc.Amount,
 		ConflictRetryInterval: rc.Interval,
@@ -334,7 +362,9 @@ func resourceLBListenerRead(ctx context.Context, d *schema.ResourceData, m inter
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	d.Set("name", lb.Name)
+	setResourceName(d, config, lb.Name)
+	d.Set("creator_task_id", pointer.GetString(lb.CreatorTaskID))
+	d.Set("task_id", pointer.GetString(lb.TaskID))
 	d.Set("protocol", lb.Protocol.String())
 	d.Set("protocol_port", lb.ProtocolPort)
 	d.Set("pool_count", lb.PoolCount)
@@ -371,7 +401,7 @@ func resourceLBListenerUpdate(ctx context.Context, d *schema.ResourceData, m int
 	unsetOpts := listeners.UnsetOpts{}
 
 	if d.HasChange("name") {
-		updateOpts.Name = d.Get("name").(string)
+		updateOpts.Name = config.fullResourceName(d.Get("name").(string))
 		changed = true
 	}
 
@@ -446,14 +476,22 @@ func resourceLBListenerUpdate(ctx context.Context, d *schema.ResourceData, m int
 	}
 
 	if changed {
-		rc := GetConflictRetryConfig(int(d.Timeout(schema.TimeoutUpdate).Seconds()))
-		_, err = listeners.Update(clientV2, d.Id(), updateOpts, &gcorecloud.RequestOpts{
+		rc := GetResourceConflictRetryConfig(d, int(d.Timeout(schema.TimeoutUpdate).Seconds()))
+		results, err := listeners.Update(clientV2, d.Id(), updateOpts, &gcorecloud.RequestOpts{
 			ConflictRetryAmount:   rc.Amount,
 			ConflictRetryInterval: rc.Interval,
 		}).Extract()
 		if err != nil {
 			return diag.FromErr(err)
 		}
+		// the certificates are replaced in place, wait for it so the previous secret can be deleted right after
+		if len(results.Tasks) > 0 {
+			taskID := string(results.Tasks[0])
+			log.Printf("[DEBUG] Task id (%s)", taskID)
+			if err := tasks.WaitForStatus(clientV1, taskID, tasks.TaskStateFinished, int(d.Timeout(schema.TimeoutUpdate).Seconds()), true); err != nil {
+				return diag.FromErr(err)
+			}
+		}
 
 		if toUnset {
 			stopWaitConf := retry.StateChangeConf{
@@ -496,7 +534,7 @@ func resourceLBListenerDelete(ctx context.Context, d *schema.ResourceData, m int
 
 	id := d.Id()
 	timeout := int(d.Timeout(schema.TimeoutDelete).Seconds())
-	rc := GetConflictRetryConfig(timeout)
+	rc := GetResourceConflictRetryConfig(d, timeout)
 	results, err := listeners.Delete(client, id, &gcorecloud.RequestOpts{
 		ConflictRetryAmount:   rc.Amount,
 		ConflictRetryInterval: rc.Interval,
@@ -507,21 +545,30 @@ func resourceLBListenerDelete(ctx context.Context, d *schema.ResourceData, m int
 
 	taskID := results.Tasks[0]
 	_, err = tasks.WaitTaskAndReturnResult(client, taskID, true, timeout, func(task tasks.TaskID) (interface{}, error) {
-		_, err := listeners.Get(client, id).Extract()
-		if err == nil {
-			return nil, fmt.Errorf("cannot delete LBListener with ID: %s", id)
-		}
-		switch err.(type) {
-		case gcorecloud.ErrDefault404:
-			return nil, nil
-		default:
-			return nil, err
-		}
+		return nil, nil
 	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	err = waitForDeleted(ctx, d.Timeout(schema.TimeoutDelete), func() error {
+		_, err := listeners.Get(client, id).Extract()
+		return err
+	})
+	if err != nil {
+		return diag.Errorf("Error waiting for LBListener (%s) deletion: %s", id, err)
+	}
+
+	if lbID := d.Get("loadbalancer_id").(string); lbID != "" {
+		lbClient, err := CreateClient(provider, d, LoadBalancersPoint, versionPointV1)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := waitForLoadBalancerActive(ctx, lbClient, lbID, d.Timeout(schema.TimeoutDelete)); err != nil {
+			return diag.Errorf("Error waiting for loadbalancer (%s): %s", lbID, err)
+		}
+	}
+
 	d.SetId("")
 	log.Printf("[DEBUG] Finish of LBListener deleting")
 	return diags
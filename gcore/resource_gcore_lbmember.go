@@ -9,11 +9,14 @@ import (
 
 	gcorecloud "github.com/G-Core/gcorelabscloud-go"
 	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/lbpools"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/types"
 	"github.com/hashicorp/go-cty/cty"
 
 	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
@@ -28,7 +31,8 @@ func resourceLBMember() *schema.Resource {
 		ReadContext:   resourceLBMemberRead,
 		UpdateContext: resourceLBMemberUpdate,
 		DeleteContext: resourceLBMemberDelete,
-		Description:   "Represent load balancer member",
+		CustomizeDiff: validateLBMemberProtocol,
+		Description:   "Represent load balancer member. Members of the same pool created at the same time are added with a single pool update.",
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(LBMemberResourceTimeoutMinutes * time.Minute),
 			Delete: schema.DefaultTimeout(LBMemberResourceTimeoutMinutes * time.Minute),
@@ -94,10 +98,11 @@ func resourceLBMember() *schema.Resource {
 				},
 			},
 			"pool_id": &schema.Schema{
-				Type:        schema.TypeString,
-				Description: "ID of the target load balancer pool to attach newly created member.",
-				Required:    true,
-				ForceNew:    true,
+				Type:             schema.TypeString,
+				Description:      "ID of the target load balancer pool to attach newly created member.",
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateUUID,
 			},
 			"address": &schema.Schema{
 				Type:        schema.TypeString,
@@ -114,9 +119,10 @@ func resourceLBMember() *schema.Resource {
 				},
 			},
 			"protocol_port": &schema.Schema{
-				Type:        schema.TypeInt,
-				Description: "Port to communicate with real server.",
-				Required:    true,
+				Type:             schema.TypeInt,
+				Description:      "Port to communicate with real server.",
+				Required:         true,
+				ValidateDiagFunc: validatePortNumber,
 			},
 			"weight": &schema.Schema{
 				Type:        schema.TypeInt,
@@ -132,15 +138,50 @@ func resourceLBMember() *schema.Resource {
 				},
 			},
 			"subnet_id": &schema.Schema{
-				Type:        schema.TypeString,
-				Description: "ID of the subnet in which real server placed.",
-				Optional:    true,
-				Computed:    true,
+				Type:             schema.TypeString,
+				Description:      "ID of the subnet in which real server placed.",
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validateUUID,
 			},
 			"instance_id": &schema.Schema{
-				Type:        schema.TypeString,
-				Description: "ID of the gcore_instance.",
-				Optional:    true,
+				Type:             schema.TypeString,
+				Description:      "ID of the gcore_instance.",
+				Optional:         true,
+				ValidateDiagFunc: validateUUID,
+			},
+			"monitor_address": &schema.Schema{
+				Type:         schema.TypeString,
+				Description:  "IP address used by the health monitor to check the member, the member address is used by default.",
+				Optional:     true,
+				ValidateFunc: validation.IsIPAddress,
+			},
+			"monitor_port": &schema.Schema{
+				Type:             schema.TypeInt,
+				Description:      "Port used by the health monitor to check the member, the member port is used by default.",
+				Optional:         true,
+				ValidateDiagFunc: validatePortNumber,
+			},
+			"wait_for_operating_status": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Wait on create and update until the member is ONLINE (or NO_MONITOR when the pool has no health monitor) " +
+					"within the resource timeout, so the dependent resources are applied when the backend passes the health checks.",
+			},
+			"adopt_existing": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Adopt the member with the same address and port if it already exists in the pool instead of failing with a conflict. " +
+					"Useful to bring manually created members under terraform management.",
+			},
+			"ignore_protocol_mismatch": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Skip the plan time check of protocol_port against the protocol of the pool, " +
+					"e.g. the port 443 behind an HTTP pool or a PROXY pool. Set it when the member is configured for such traffic.",
 			},
 			"operating_status": &schema.Schema{
 				Type:        schema.TypeString,
@@ -168,40 +209,54 @@ func resourceLBMemberCreate(ctx context.Context, d *schema.ResourceData, m inter
 	}
 
 	opts := lbpools.CreatePoolMemberOpts{
-		Address:      net.ParseIP(d.Get("address").(string)),
-		ProtocolPort: d.Get("protocol_port").(int),
-		Weight:       d.Get("weight").(int),
-		SubnetID:     d.Get("subnet_id").(string),
-		InstanceID:   d.Get("instance_id").(string),
-	}
-	timeout := int(d.Timeout(schema.TimeoutCreate).Seconds())
-	rc := GetConflictRetryConfig(timeout)
-	results, err := lbpools.CreateMember(client, d.Get("pool_id").(string), opts, &gcorecloud.RequestOpts{
-		ConflictRetryAmount:   rc.Amount,
-		ConflictRetryInterval: rc.Interval,
-	}).Extract()
-	if err != nil {
-		return diag.FromErr(err)
+		Address:        net.ParseIP(d.Get("address").(string)),
+		ProtocolPort:   d.Get("protocol_port").(int),
+		Weight:         d.Get("weight").(int),
+		SubnetID:       d.Get("subnet_id").(string),
+		InstanceID:     d.Get("instance_id").(string),
+		MonitorAddress: lbMemberMonitorAddress(d),
+		MonitorPort:    lbMemberMonitorPort(d),
 	}
 
-	taskID := results.Tasks[0]
-	pmID, err := tasks.WaitTaskAndReturnResult(client, taskID, true, timeout, func(task tasks.TaskID) (interface{}, error) {
-		taskInfo, err := tasks.Get(client, string(task)).Extract()
+	if d.Get("adopt_existing").(bool) {
+		pool, err := getLBPool(client, d.Get("pool_id").(string))
 		if err != nil {
-			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
+			return diag.FromErr(err)
 		}
-		pmID, err := lbpools.ExtractPoolMemberIDFromTask(taskInfo)
-		if err != nil {
-			return nil, fmt.Errorf("cannot retrieve LBMember ID from task info: %w", err)
+		if pm := findLBPoolMember(pool.Members, opts.Address, opts.ProtocolPort); pm != nil {
+			log.Printf("[DEBUG] Adopting existing LBMember (%s)", pm.ID)
+			d.SetId(pm.ID)
+			if pm.Weight != opts.Weight || (opts.InstanceID != "" && pm.InstanceID != opts.InstanceID) ||
+				!pm.MonitorAddress.Equal(opts.MonitorAddress) || !equalIntPtr(pm.MonitorPort, opts.MonitorPort) {
+				return resourceLBMemberUpdate(ctx, d, m)
+			}
+			if d.Get("wait_for_operating_status").(bool) {
+				if err := waitForLBMemberOnline(ctx, client, d.Get("pool_id").(string), pm.ID, d.Timeout(schema.TimeoutCreate)); err != nil {
+					return diag.Errorf("Error waiting for LBMember (%s) to become ONLINE: %s", pm.ID, err)
+				}
+			}
+			return resourceLBMemberRead(ctx, d, m)
 		}
-		return pmID, nil
-	})
+	}
 
+	poolID := d.Get("pool_id").(string)
+	timeout := int(d.Timeout(schema.TimeoutCreate).Seconds())
+	// the batch is created with the shared client, so the cancellation of the operation of one member doesn't fail the others
+	batchClient := *client
+	batchClient.ProviderClient = config.sharedProviderClient()
+	pmID, err := lbMemberBatches.create(lbMemberBatchKey(client, poolID), config.ProviderKey, opts, timeout, lbMemberBatchWindow, func(members []lbpools.CreatePoolMemberOpts, timeout int) []lbMemberResult {
+		return createLBMembers(&batchClient, poolID, members, timeout)
+	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	d.SetId(pmID.(string))
+	d.SetId(pmID)
+	if d.Get("wait_for_operating_status").(bool) {
+		if err := waitForLBMemberOnline(ctx, client, d.Get("pool_id").(string), d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.Errorf("Error waiting for LBMember (%s) to become ONLINE: %s", d.Id(), err)
+		}
+	}
 	resourceLBMemberRead(ctx, d, m)
 
 	log.Printf("[DEBUG] Finish LBMember creating (%s)", pmID)
@@ -219,7 +274,7 @@ func resourceLBMemberRead(ctx context.Context, d *schema.ResourceData, m interfa
 		return diag.FromErr(err)
 	}
 
-	pool, err := lbpools.Get(client, d.Get("pool_id").(string)).Extract()
+	pool, err := getLBPool(client, d.Get("pool_id").(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -233,6 +288,16 @@ func resourceLBMemberRead(ctx context.Context, d *schema.ResourceData, m interfa
 			d.Set("subnet_id", pm.SubnetID)
 			d.Set("instance_id", pm.InstanceID)
 			d.Set("operating_status", pm.OperatingStatus)
+			if pm.MonitorAddress != nil {
+				d.Set("monitor_address", pm.MonitorAddress.String())
+			} else {
+				d.Set("monitor_address", "")
+			}
+			if pm.MonitorPort != nil {
+				d.Set("monitor_port", *pm.MonitorPort)
+			} else {
+				d.Set("monitor_port", 0)
+			}
 		}
 	}
 
@@ -245,6 +310,11 @@ func resourceLBMemberRead(ctx context.Context, d *schema.ResourceData, m interfa
 
 func resourceLBMemberUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	log.Println("[DEBUG] Start LBMember updating")
+	// the flags of the provider only, e.g. ignore_protocol_mismatch, are not worth a pool update
+	if !d.HasChanges("address", "protocol_port", "weight", "subnet_id", "instance_id", "monitor_address", "monitor_port") {
+		log.Println("[DEBUG] Finish LBMember updating, no member changes")
+		return resourceLBMemberRead(ctx, d, m)
+	}
 	config := m.(*Config)
 	provider := config.Provider
 
@@ -253,7 +323,11 @@ func resourceLBMemberUpdate(ctx context.Context, d *schema.ResourceData, m inter
 		return diag.FromErr(err)
 	}
 
-	pool, err := lbpools.Get(client, d.Get("pool_id").(string)).Extract()
+	// the pool update replaces the whole member list, it must not run together with a batch of new members
+	unlock := lbMemberBatches.lockPool(lbMemberBatchKey(client, d.Get("pool_id").(string)))
+	defer unlock()
+
+	pool, err := getLBPool(client, d.Get("pool_id").(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -261,24 +335,19 @@ func resourceLBMemberUpdate(ctx context.Context, d *schema.ResourceData, m inter
 	members := make([]lbpools.CreatePoolMemberOpts, len(pool.Members))
 	for i, pm := range pool.Members {
 		if pm.ID != d.Id() {
-			members[i] = lbpools.CreatePoolMemberOpts{
-				Address:      *pm.Address,
-				ProtocolPort: pm.ProtocolPort,
-				Weight:       pm.Weight,
-				SubnetID:     pm.SubnetID,
-				InstanceID:   pm.InstanceID,
-				ID:           pm.ID,
-			}
+			members[i] = lbPoolMemberOpts(pm)
 			continue
 		}
 
 		members[i] = lbpools.CreatePoolMemberOpts{
-			Address:      net.ParseIP(d.Get("address").(string)),
-			ProtocolPort: d.Get("protocol_port").(int),
-			Weight:       d.Get("weight").(int),
-			SubnetID:     d.Get("subnet_id").(string),
-			InstanceID:   d.Get("instance_id").(string),
-			ID:           d.Id(),
+			Address:        net.ParseIP(d.Get("address").(string)),
+			ProtocolPort:   d.Get("protocol_port").(int),
+			Weight:         d.Get("weight").(int),
+			SubnetID:       d.Get("subnet_id").(string),
+			InstanceID:     d.Get("instance_id").(string),
+			MonitorAddress: lbMemberMonitorAddress(d),
+			MonitorPort:    lbMemberMonitorPort(d),
+			ID:             d.Id(),
 		}
 	}
 
@@ -308,6 +377,13 @@ func resourceLBMemberUpdate(ctx context.Context, d *schema.ResourceData, m inter
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	unlock()
+
+	if d.Get("wait_for_operating_status").(bool) {
+		if err := waitForLBMemberOnline(ctx, client, pool.ID, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.Errorf("Error waiting for LBMember (%s) to become ONLINE: %s", d.Id(), err)
+		}
+	}
 
 	d.Set("last_updated", time.Now().Format(time.RFC850))
 	log.Println("[DEBUG] Finish LBMember updating")
@@ -327,6 +403,10 @@ func resourceLBMemberDelete(ctx context.Context, d *schema.ResourceData, m inter
 
 	mid := d.Id()
 	pid := d.Get("pool_id").(string)
+	// the member is deleted while no batch of new members replaces the member list of the pool
+	unlock := lbMemberBatches.lockPool(lbMemberBatchKey(client, pid))
+	defer unlock()
+
 	timeout := int(d.Timeout(schema.TimeoutDelete).Seconds())
 	rc := GetConflictRetryConfig(timeout)
 	results, err := lbpools.DeleteMember(client, pid, mid, &gcorecloud.RequestOpts{
@@ -346,24 +426,91 @@ func resourceLBMemberDelete(ctx context.Context, d *schema.ResourceData, m inter
 
 	taskID := results.Tasks[0]
 	_, err = tasks.WaitTaskAndReturnResult(client, taskID, true, timeout, func(task tasks.TaskID) (interface{}, error) {
-		pool, err := lbpools.Get(client, pid).Extract()
+		return nil, nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = waitForDeleted(ctx, d.Timeout(schema.TimeoutDelete), func() error {
+		pool, err := getLBPool(client, pid)
 		if err != nil {
-			return nil, err
+			return err
 		}
-
 		for _, pm := range pool.Members {
 			if pm.ID == mid {
-				return nil, fmt.Errorf("pool member %s still exist", mid)
+				return nil
 			}
 		}
-
-		return nil, nil
+		return gcorecloud.ErrDefault404{}
 	})
 	if err != nil {
-		return diag.FromErr(err)
+		return diag.Errorf("Error waiting for LBMember (%s) deletion: %s", mid, err)
 	}
 
 	d.SetId("")
 	log.Printf("[DEBUG] Finish of LBMember deleting")
 	return diags
 }
+
+// findLBPoolMember returns the pool member with the address and port or nil.
+func findLBPoolMember(members []lbpools.PoolMember, address net.IP, port int) *lbpools.PoolMember {
+	for i, pm := range members {
+		if pm.Address != nil && pm.Address.Equal(address) && pm.ProtocolPort == port {
+			return &members[i]
+		}
+	}
+	return nil
+}
+
+func lbMemberMonitorAddress(d *schema.ResourceData) net.IP {
+	if address := d.Get("monitor_address").(string); address != "" {
+		return net.ParseIP(address)
+	}
+	return nil
+}
+
+func lbMemberMonitorPort(d *schema.ResourceData) *int {
+	if port := d.Get("monitor_port").(int); port != 0 {
+		return &port
+	}
+	return nil
+}
+
+func equalIntPtr(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// waitForLBMemberOnline waits until the member passes the health checks of the pool.
+// NO_MONITOR is final as well, such members are never checked.
+func waitForLBMemberOnline(ctx context.Context, client *gcorecloud.ServiceClient, poolID, memberID string, timeout time.Duration) error {
+	conf := retry.StateChangeConf{
+		Pending: []string{
+			"",
+			types.OperatingStatusOffline.String(),
+			types.OperatingStatusDegraded.String(),
+			types.OperatingStatusOperatingError.String(),
+			types.OperatingStatusDraining.String(),
+		},
+		Target: []string{types.OperatingStatusOnline.String(), types.OperatingStatusNoMonitor.String()},
+		Refresh: func() (interface{}, string, error) {
+			pool, err := getLBPool(client, poolID)
+			if err != nil {
+				return nil, "", err
+			}
+			for _, pm := range pool.Members {
+				if pm.ID == memberID {
+					return pm, pm.OperatingStatus.String(), nil
+				}
+			}
+			return nil, "", fmt.Errorf("member %s not found in pool %s", memberID, poolID)
+		},
+		Timeout:    timeout,
+		MinTimeout: 5 * time.Second,
+	}
+	_, err := conf.WaitForStateContext(ctx)
+	return err
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
+	"strings"
 	"time"
 
 	gcorecloud "github.com/G-Core/gcorelabscloud-go"
@@ -13,7 +15,9 @@ import (
 
 	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
@@ -28,6 +32,7 @@ func resourceLBPool() *schema.Resource {
 		UpdateContext: resourceLBPoolUpdate,
 		DeleteContext: resourceLBPoolDelete,
 		Description:   "Represent load balancer listener pool. A pool is a list of virtual machines to which the listener will redirect incoming traffic",
+		CustomizeDiff: customdiff.ValidateChange("name", validateNameConvention),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(LBPoolsResourceTimeoutMinutes * time.Minute),
 			Delete: schema.DefaultTimeout(LBPoolsResourceTimeoutMinutes * time.Minute),
@@ -45,6 +50,20 @@ func resourceLBPool() *schema.Resource {
 				d.Set("region_id", regionID)
 				d.SetId(lbPoolID)
 
+				// the members are imported as the inline members, the pool read keeps them only when they are set
+				config := meta.(*Config)
+				client, err := CreateClient(config.Provider, d, LBPoolsPoint, versionPointV1)
+				if err != nil {
+					return nil, err
+				}
+				pool, err := getLBPool(client, lbPoolID)
+				if err != nil {
+					return nil, err
+				}
+				if err := d.Set("member", flattenLBPoolMembers(pool.Members)); err != nil {
+					return nil, err
+				}
+
 				return []*schema.ResourceData{d}, nil
 			},
 		},
@@ -99,14 +118,15 @@ func resourceLBPool() *schema.Resource {
 			"lb_algorithm": &schema.Schema{
 				Type:        schema.TypeString,
 				Required:    true,
-				Description: fmt.Sprintf("Available values is '%s', '%s', '%s'", types.LoadBalancerAlgorithmRoundRobin, types.LoadBalancerAlgorithmLeastConnections, types.LoadBalancerAlgorithmSourceIP),
+				Description: lbPoolAlgorithmsDescription,
 				ValidateDiagFunc: func(val interface{}, key cty.Path) diag.Diagnostics {
 					v := val.(string)
-					switch types.LoadBalancerAlgorithm(v) {
-					case types.LoadBalancerAlgorithmRoundRobin, types.LoadBalancerAlgorithmLeastConnections, types.LoadBalancerAlgorithmSourceIP:
-						return diag.Diagnostics{}
+					for _, algorithm := range lbPoolAlgorithms {
+						if v == algorithm {
+							return diag.Diagnostics{}
+						}
 					}
-					return diag.Errorf("wrong type %s, available values is '%s', '%s', '%s'", v, types.LoadBalancerAlgorithmRoundRobin, types.LoadBalancerAlgorithmLeastConnections, types.LoadBalancerAlgorithmSourceIP)
+					return diag.Errorf("wrong type %s, available values are '%s'", v, strings.Join(lbPoolAlgorithms, "', '"))
 				},
 			},
 			"protocol": &schema.Schema{
@@ -123,16 +143,18 @@ func resourceLBPool() *schema.Resource {
 				},
 			},
 			"loadbalancer_id": &schema.Schema{
-				Type:        schema.TypeString,
-				Description: "ID of the target load balancer to attach newly created pool.",
-				Optional:    true,
-				ForceNew:    true,
+				Type:             schema.TypeString,
+				Description:      "ID of the target load balancer to attach newly created pool.",
+				Optional:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateUUID,
 			},
 			"listener_id": &schema.Schema{
-				Type:        schema.TypeString,
-				Description: "ID of the target listener associated with load balancer to attach newly created pool.",
-				Optional:    true,
-				ForceNew:    true,
+				Type:             schema.TypeString,
+				Description:      "ID of the target listener associated with load balancer to attach newly created pool.",
+				Optional:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateUUID,
 			},
 			"health_monitor": &schema.Schema{
 				Type:        schema.TypeList,
@@ -199,6 +221,12 @@ func resourceLBPool() *schema.Resource {
 							Optional:    true,
 							Computed:    true,
 						},
+						"enabled": &schema.Schema{
+							Type:        schema.TypeBool,
+							Description: "Set to false to pause the health checks, e.g. during maintenance. The monitor is removed from the pool and created again with the same settings when it is enabled.",
+							Optional:    true,
+							Default:     true,
+						},
 					},
 				},
 			},
@@ -235,6 +263,92 @@ func resourceLBPool() *schema.Resource {
 					},
 				},
 			},
+			"member": &schema.Schema{
+				Type:        schema.TypeSet,
+				Description: "Members of the pool. All changes of the members are applied with a single pool update. Don't use together with `gcore_lbmember` resources of the same pool.",
+				Optional:    true,
+				Set:         lbPoolMemberHash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "Member ID.",
+							Computed:    true,
+						},
+						"address": &schema.Schema{
+							Type:         schema.TypeString,
+							Description:  "IP address to communicate with real server.",
+							Required:     true,
+							ValidateFunc: validation.IsIPAddress,
+						},
+						"protocol_port": &schema.Schema{
+							Type:             schema.TypeInt,
+							Description:      "Port to communicate with real server.",
+							Required:         true,
+							ValidateDiagFunc: validatePortNumber,
+						},
+						"weight": &schema.Schema{
+							Type:         schema.TypeInt,
+							Description:  "Value between 1 and 256, default 1. The pool update doesn't send the zero weight, use `gcore_lbmember` to drain a member.",
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntBetween(1, maxWeight),
+						},
+						"subnet_id": &schema.Schema{
+							Type:             schema.TypeString,
+							Description:      "ID of the subnet in which real server placed.",
+							Optional:         true,
+							Computed:         true,
+							ValidateDiagFunc: validateUUID,
+						},
+						"instance_id": &schema.Schema{
+							Type:             schema.TypeString,
+							Description:      "ID of the gcore_instance.",
+							Optional:         true,
+							ValidateDiagFunc: validateUUID,
+						},
+						"monitor_address": &schema.Schema{
+							Type:         schema.TypeString,
+							Description:  "IP address used by the health monitor to check the member, the member address is used by default.",
+							Optional:     true,
+							ValidateFunc: validation.IsIPAddress,
+						},
+						"monitor_port": &schema.Schema{
+							Type:             schema.TypeInt,
+							Description:      "Port used by the health monitor to check the member, the member port is used by default.",
+							Optional:         true,
+							ValidateDiagFunc: validatePortNumber,
+						},
+						"operating_status": &schema.Schema{
+							Type:        schema.TypeString,
+							Description: "Operating status of this member.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"creator_task_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the task that created the pool.",
+			},
+			"task_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the task running on the pool, empty when no task is running.",
+			},
+			"conflict_retry_interval": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Interval in seconds between the retries of the requests rejected because the load balancer is busy with another change (409 Conflict). Defaults to TF_CONFLICT_RETRY_INTERVAL_SECONDS environment variable or 10.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"conflict_retry_amount": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Number of the retries of the requests rejected because the load balancer is busy with another change (409 Conflict). Defaults to the timeout of the operation divided by the retry interval.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
 			"last_updated": &schema.Schema{
 				Type:        schema.TypeString,
 				Description: "Datetime when load balancer pool was updated at the last time.",
@@ -258,16 +372,17 @@ func resourceLBPoolCreate(ctx context.Context, d *schema.ResourceData, m interfa
 	healthOpts := extractHealthMonitorMap(d)
 	sessionOpts := extractSessionPersistenceMap(d)
 	opts := lbpools.CreateOpts{
-		Name:               d.Get("name").(string),
+		Name:               config.fullResourceName(d.Get("name").(string)),
 		Protocol:           types.ProtocolType(d.Get("protocol").(string)),
 		LBPoolAlgorithm:    types.LoadBalancerAlgorithm(d.Get("lb_algorithm").(string)),
 		LoadBalancerID:     d.Get("loadbalancer_id").(string),
 		ListenerID:         d.Get("listener_id").(string),
 		HealthMonitor:      healthOpts,
 		SessionPersistence: sessionOpts,
+		Members:            extractLBPoolMembers(d),
 	}
 	timeout := int(d.Timeout(schema.TimeoutCreate).Seconds())
-	rc := GetConflictRetryConfig(timeout)
+	rc := GetResourceConflictRetryConfig(d, timeout)
 	results, err := lbpools.Create(client, opts, &gcorecloud.RequestOpts{
 		ConflictRetryAmount:   rc.Amount,
 		ConflictRetryInterval: rc.Interval,
@@ -311,12 +426,14 @@ func resourceLBPoolRead(ctx context.Context, d *schema.ResourceData, m interface
 		return diag.FromErr(err)
 	}
 
-	lb, err := lbpools.Get(client, d.Id()).Extract()
+	lb, err := getLBPool(client, d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	d.Set("name", lb.Name)
-	d.Set("lb_algorithm", lb.LoadBalancerAlgorithm.String())
+	setResourceName(d, config, lb.Name)
+	d.Set("creator_task_id", lb.CreatorTaskID)
+	d.Set("task_id", lb.TaskID)
+	d.Set("lb_algorithm", lb.LoadBalancerAlgorithm)
 	d.Set("protocol", lb.Protocol.String())
 
 	if len(lb.LoadBalancers) > 0 {
@@ -337,6 +454,7 @@ func resourceLBPoolRead(ctx context.Context, d *schema.ResourceData, m interface
 			"max_retries_down": lb.HealthMonitor.MaxRetriesDown,
 			"url_path":         lb.HealthMonitor.URLPath,
 			"expected_codes":   lb.HealthMonitor.ExpectedCodes,
+			"enabled":          true,
 		}
 		if lb.HealthMonitor.HTTPMethod != nil {
 			healthMonitor["http_method"] = lb.HealthMonitor.HTTPMethod.String()
@@ -360,6 +478,13 @@ func resourceLBPoolRead(ctx context.Context, d *schema.ResourceData, m interface
 		}
 	}
 
+	// the members are read only when they are managed inline, otherwise they belong to gcore_lbmember resources
+	if _, ok := d.GetOk("member"); ok {
+		if err := d.Set("member", flattenLBPoolMembers(lb.Members)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	fields := []string{"project_id", "region_id"}
 	revertState(d, &fields)
 
@@ -378,9 +503,9 @@ func resourceLBPoolUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 	}
 
 	var change bool
-	opts := lbpools.UpdateOpts{Name: d.Get("name").(string)}
+	opts := lbpools.UpdateOpts{Name: config.fullResourceName(d.Get("name").(string))}
 	timeout := int(d.Timeout(schema.TimeoutUpdate).Seconds())
-	rc := GetConflictRetryConfig(timeout)
+	rc := GetResourceConflictRetryConfig(d, timeout)
 
 	if d.HasChange("lb_algorithm") {
 		opts.LBPoolAlgorithm = types.LoadBalancerAlgorithm(d.Get("lb_algorithm").(string))
@@ -393,13 +518,21 @@ func resourceLBPoolUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 	}
 
 	if d.HasChange("health_monitor") {
+		oldMonitor, _ := d.GetChange("health_monitor")
+		monitorExists := lbPoolHealthMonitorEnabled(oldMonitor.([]interface{}))
 		opts.HealthMonitor = extractHealthMonitorMap(d)
 		if opts.HealthMonitor == nil {
-			lbpools.DeleteHealthMonitor(client, d.Id(), &gcorecloud.RequestOpts{
-				ConflictRetryAmount:   rc.Amount,
-				ConflictRetryInterval: rc.Interval,
-			})
+			if monitorExists {
+				lbpools.DeleteHealthMonitor(client, d.Id(), &gcorecloud.RequestOpts{
+					ConflictRetryAmount:   rc.Amount,
+					ConflictRetryInterval: rc.Interval,
+				})
+			}
 		} else {
+			if !monitorExists {
+				// the monitor was paused, it is created again with a new ID
+				opts.HealthMonitor.ID = ""
+			}
 			change = true
 		}
 	}
@@ -430,6 +563,52 @@ func resourceLBPoolUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 		}
 	}
 
+	if d.HasChange("member") {
+		// the member list is replaced while no batch of gcore_lbmember members is added to the pool
+		unlock := lbMemberBatches.lockPool(lbMemberBatchKey(client, d.Id()))
+		defer unlock()
+
+		opts.Members = extractLBPoolMembers(d)
+		pool, err := getLBPool(client, d.Id())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		// the members that exist in the pool but not in the state (after import or gcore_lbmember) are adopted
+		for i, member := range opts.Members {
+			if member.ID != "" {
+				continue
+			}
+			if existing := findLBPoolMember(pool.Members, member.Address, member.ProtocolPort); existing != nil {
+				opts.Members[i].ID = existing.ID
+			}
+		}
+		if len(opts.Members) == 0 {
+			// an empty list of members is omitted by the update request, so the last members are deleted one by one
+			o, _ := d.GetChange("member")
+			for _, raw := range o.(*schema.Set).List() {
+				memberID := raw.(map[string]interface{})["id"].(string)
+				results, err := lbpools.DeleteMember(client, d.Id(), memberID, &gcorecloud.RequestOpts{
+					ConflictRetryAmount:   rc.Amount,
+					ConflictRetryInterval: rc.Interval,
+				}).Extract()
+				if err != nil {
+					if _, ok := err.(gcorecloud.ErrDefault404); ok {
+						continue
+					}
+					return diag.FromErr(err)
+				}
+				_, err = tasks.WaitTaskAndReturnResult(client, results.Tasks[0], true, timeout, func(task tasks.TaskID) (interface{}, error) {
+					return nil, nil
+				})
+				if err != nil {
+					return diag.FromErr(err)
+				}
+			}
+		} else {
+			change = true
+		}
+	}
+
 	if !change {
 		log.Println("[DEBUG] Finish LBPool updating")
 		return resourceLBPoolRead(ctx, d, m)
@@ -472,7 +651,7 @@ func resourceLBPoolDelete(ctx context.Context, d *schema.ResourceData, m interfa
 		return diag.FromErr(err)
 	}
 	timeout := int(d.Timeout(schema.TimeoutDelete).Seconds())
-	rc := GetConflictRetryConfig(timeout)
+	rc := GetResourceConflictRetryConfig(d, timeout)
 	id := d.Id()
 	results, err := lbpools.Delete(client, id, &gcorecloud.RequestOpts{
 		ConflictRetryAmount:   rc.Amount,
@@ -481,6 +660,9 @@ func resourceLBPoolDelete(ctx context.Context, d *schema.ResourceData, m interfa
 	if err != nil {
 		switch err.(type) {
 		case gcorecloud.ErrDefault404:
+			d.SetId("")
+			log.Printf("[DEBUG] Finish of LBPool deleting")
+			return diags
 		default:
 			return diag.FromErr(err)
 		}
@@ -488,22 +670,92 @@ func resourceLBPoolDelete(ctx context.Context, d *schema.ResourceData, m interfa
 
 	taskID := results.Tasks[0]
 	_, err = tasks.WaitTaskAndReturnResult(client, taskID, true, timeout, func(task tasks.TaskID) (interface{}, error) {
-		_, err := lbpools.Get(client, id).Extract()
-		if err == nil {
-			return nil, fmt.Errorf("cannot delete LBPool with ID: %s", id)
-		}
-		switch err.(type) {
-		case gcorecloud.ErrDefault404:
-			return nil, nil
-		default:
-			return nil, err
-		}
+		return nil, nil
 	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	err = waitForDeleted(ctx, d.Timeout(schema.TimeoutDelete), func() error {
+		_, err := getLBPool(client, id)
+		return err
+	})
+	if err != nil {
+		return diag.Errorf("Error waiting for LBPool (%s) deletion: %s", id, err)
+	}
+
+	if lbID := d.Get("loadbalancer_id").(string); lbID != "" {
+		lbClient, err := CreateClient(provider, d, LoadBalancersPoint, versionPointV1)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := waitForLoadBalancerActive(ctx, lbClient, lbID, d.Timeout(schema.TimeoutDelete)); err != nil {
+			return diag.Errorf("Error waiting for loadbalancer (%s): %s", lbID, err)
+		}
+	}
+
 	d.SetId("")
 	log.Printf("[DEBUG] Finish of LBPool deleting")
 	return diags
 }
+
+// lbPoolMemberHash identifies the inline members by the address and the port,
+// so changes of the other fields are updated in place and the member keeps its ID.
+func lbPoolMemberHash(v interface{}) int {
+	m := v.(map[string]interface{})
+	address := m["address"].(string)
+	if ip := net.ParseIP(address); ip != nil {
+		address = ip.String()
+	}
+	return schema.HashString(fmt.Sprintf("%s-%d", address, m["protocol_port"].(int)))
+}
+
+// extractLBPoolMembers returns the inline members of the pool, the members that already exist keep their IDs.
+func extractLBPoolMembers(d *schema.ResourceData) []lbpools.CreatePoolMemberOpts {
+	list := d.Get("member").(*schema.Set).List()
+	members := make([]lbpools.CreatePoolMemberOpts, 0, len(list))
+	for _, raw := range list {
+		m := raw.(map[string]interface{})
+		member := lbpools.CreatePoolMemberOpts{
+			ID:           m["id"].(string),
+			Address:      net.ParseIP(m["address"].(string)),
+			ProtocolPort: m["protocol_port"].(int),
+			Weight:       m["weight"].(int),
+			SubnetID:     m["subnet_id"].(string),
+			InstanceID:   m["instance_id"].(string),
+		}
+		if address := m["monitor_address"].(string); address != "" {
+			member.MonitorAddress = net.ParseIP(address)
+		}
+		if port := m["monitor_port"].(int); port != 0 {
+			member.MonitorPort = &port
+		}
+		members = append(members, member)
+	}
+	return members
+}
+
+func flattenLBPoolMembers(poolMembers []lbpools.PoolMember) []interface{} {
+	members := make([]interface{}, 0, len(poolMembers))
+	for _, mem := range poolMembers {
+		member := map[string]interface{}{
+			"id":               mem.ID,
+			"protocol_port":    mem.ProtocolPort,
+			"weight":           mem.Weight,
+			"subnet_id":        mem.SubnetID,
+			"instance_id":      mem.InstanceID,
+			"operating_status": mem.OperatingStatus.String(),
+		}
+		if mem.Address != nil {
+			member["address"] = mem.Address.String()
+		}
+		if mem.MonitorAddress != nil {
+			member["monitor_address"] = mem.MonitorAddress.String()
+		}
+		if mem.MonitorPort != nil {
+			member["monitor_port"] = *mem.MonitorPort
+		}
+		members = append(members, member)
+	}
+	return members
+}
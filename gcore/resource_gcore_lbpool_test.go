@@ -102,6 +102,114 @@ func TestAccLBPool(t *testing.T) {
 	})
 }
 
+func TestAccLBPoolMembers(t *testing.T) {
+	cfg, err := createTestConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := CreateTestClient(cfg.Provider, LoadBalancersPoint, versionPointV1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientListener, err := CreateTestClient(cfg.Provider, LBListenersPoint, versionPointV1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := loadbalancers.CreateOpts{
+		Name: lbTestName,
+		Listeners: []loadbalancers.CreateListenerOpts{{
+			Name:         lbListenerTestName,
+			ProtocolPort: 80,
+			Protocol:     types.ProtocolTypeHTTP,
+		}},
+	}
+
+	lbID, err := createTestLoadBalancerWithListener(client, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loadbalancers.Delete(client, lbID)
+
+	ls, err := listeners.ListAll(clientListener, listeners.ListOpts{LoadBalancerID: &lbID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener := ls[0]
+
+	create := `
+			  member {
+			    address = "10.10.2.15"
+			    protocol_port = 8080
+			  }`
+
+	update := `
+			  member {
+			    address = "10.10.2.15"
+			    protocol_port = 8080
+			  }
+			  member {
+			    address = "10.10.2.16"
+			    protocol_port = 8080
+			    weight = 5
+			  }`
+
+	fullName := "gcore_lbpool.acctest"
+
+	template := func(members string) string {
+		return fmt.Sprintf(`
+            resource "gcore_lbpool" "acctest" {
+			  %s
+              %s
+			  name = "test"
+			  protocol = "HTTP"
+			  lb_algorithm = "ROUND_ROBIN"
+			  loadbalancer_id = "%s"
+			  listener_id = "%s"
+			  %s
+			}
+		`, projectInfo(), regionInfo(), lbID, listener.ID, members)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccLBPoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: template(create),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists(fullName),
+					resource.TestCheckResourceAttr(fullName, "member.#", "1"),
+				),
+			},
+			{
+				Config: template(update),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists(fullName),
+					resource.TestCheckResourceAttr(fullName, "member.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(fullName, "member.*", map[string]string{
+						"address": "10.10.2.16",
+						"weight":  "5",
+					}),
+				),
+			},
+			{
+				ResourceName: fullName,
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources[fullName]
+					return fmt.Sprintf("%s:%s:%s", rs.Primary.Attributes["project_id"], rs.Primary.Attributes["region_id"], rs.Primary.ID), nil
+				},
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "project_name", "region_name"},
+			},
+		},
+	})
+}
+
 func testAccLBPoolDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 	client, err := CreateTestClient(config.Provider, LBPoolsPoint, versionPointV1)
@@ -2,7 +2,6 @@ package gcore
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -116,7 +115,7 @@ func resourceLoadBalancer() *schema.Resource {
 			"flavor": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
+				Computed: true,
 			},
 			"vip_network_id": &schema.Schema{
 				Type:     schema.TypeString,
@@ -423,9 +422,16 @@ func resourceLoadBalancerUpdate(ctx context.Context, d *schema.ResourceData, m i
 				sniSecretID[i] = s.(string)
 			}
 			opts.SNISecretID = sniSecretID
-			if _, err := listeners.Update(client, listenerID, opts, &gcorecloud.RequestOpts{}).Extract(); err != nil {
+			results, err := listeners.Update(client, listenerID, opts, &gcorecloud.RequestOpts{}).Extract()
+			if err != nil {
 				return diag.FromErr(err)
 			}
+			if len(results.Tasks) > 0 {
+				err = tasks.WaitForStatus(client, string(results.Tasks[0]), tasks.TaskStateFinished, int(d.Timeout(schema.TimeoutUpdate).Seconds()), true)
+				if err != nil {
+					return diag.FromErr(err)
+				}
+			}
 		}
 	}
 
@@ -443,6 +449,12 @@ func resourceLoadBalancerUpdate(ctx context.Context, d *schema.ResourceData, m i
 		}
 	}
 
+	if d.HasChange("flavor") {
+		if err := resizeLoadBalancer(client, d.Id(), d.Get("flavor").(string), int(d.Timeout(schema.TimeoutUpdate).Seconds())); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	log.Println("[DEBUG] Finish LoadBalancer updating")
 	return resourceLoadBalancerRead(ctx, d, m)
 }
@@ -471,19 +483,20 @@ func resourceLoadBalancerDelete(ctx context.Context, d *schema.ResourceData, m i
 
 	taskID := results.Tasks[0]
 	_, err = tasks.WaitTaskAndReturnResult(client, taskID, true, timeout, func(task tasks.TaskID) (interface{}, error) {
-		_, err := loadbalancers.Get(client, id, nil).Extract()
-		var errDefault404 gcorecloud.ErrDefault404
-		switch {
-		case errors.As(err, &errDefault404):
-			return nil, nil
-		default:
-			return nil, err
-		}
+		return nil, nil
 	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	err = waitForDeleted(ctx, d.Timeout(schema.TimeoutDelete), func() error {
+		_, err := loadbalancers.Get(client, id, nil).Extract()
+		return err
+	})
+	if err != nil {
+		return diag.Errorf("Error waiting for LoadBalancer (%s) deletion: %s", id, err)
+	}
+
 	d.SetId("")
 	log.Printf("[DEBUG] Finish of LoadBalancer deleting")
 	return diags
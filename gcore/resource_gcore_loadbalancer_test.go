@@ -51,6 +51,7 @@ func TestAccLoadBalancer(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckResourceExists(fullName),
 					resource.TestCheckResourceAttr(fullName, "name", create.Name),
+					resource.TestCheckResourceAttr(fullName, "provisioning_status", "ACTIVE"),
 					testAccCheckMetadata(fullName, true, map[string]string{
 						"key1": "val1",
 						"key2": "val2",
@@ -73,6 +74,19 @@ func TestAccLoadBalancer(t *testing.T) {
 					}),
 				),
 			},
+			{
+				Config: ripTemplate(&update) + fmt.Sprintf(`
+			data "gcore_loadbalancerv2" "acctest" {
+			  %s
+              %s
+			  name = gcore_loadbalancerv2.acctest.name
+			}
+		`, projectInfo(), regionInfo()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.gcore_loadbalancerv2.acctest", "metadata_map.%", "1"),
+					resource.TestCheckResourceAttr("data.gcore_loadbalancerv2.acctest", "metadata_map.key3", "val3"),
+				),
+			},
 		},
 	})
 }
@@ -6,6 +6,7 @@ import (
 	"log"
 	"time"
 
+	"github.com/AlekSi/pointer"
 	gcorecloud "github.com/G-Core/gcorelabscloud-go"
 	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/loadbalancers"
 	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/types"
@@ -14,7 +15,9 @@ import (
 	"github.com/G-Core/gcorelabscloud-go/gcore/utils/metadata/v1/metadata"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceLoadBalancerV2() *schema.Resource {
@@ -24,6 +27,10 @@ func resourceLoadBalancerV2() *schema.Resource {
 		UpdateContext: resourceLoadBalancerV2Update,
 		DeleteContext: resourceLoadBalancerDelete,
 		Description:   "Represent load balancer without nested listener",
+		CustomizeDiff: customdiff.All(
+			customdiff.ValidateChange("name", validateNameConvention),
+			validateLoadBalancerInternal,
+		),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(LoadBalancerResourceTimeoutMinutes * time.Minute),
 			Delete: schema.DefaultTimeout(LoadBalancerResourceTimeoutMinutes * time.Minute),
@@ -32,9 +39,15 @@ func resourceLoadBalancerV2() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 				projectID, regionID, lbID, err := ImportStringParser(d.Id())
-
 				if err != nil {
-					return nil, err
+					// the ID of the deprecated gcore_loadbalancer resource has the nested listener ID at the end,
+					// accept it too so the same ID can be used when migrating to this resource
+					var listenerID string
+					projectID, regionID, lbID, listenerID, err = ImportStringParserExtended(d.Id())
+					if err != nil {
+						return nil, err
+					}
+					log.Printf("[DEBUG] Ignoring the listener %s of the gcore_loadbalancer import ID, import it with gcore_lblistener", listenerID)
 				}
 				d.Set("project_id", projectID)
 				d.Set("region_id", regionID)
@@ -111,6 +124,14 @@ func resourceLoadBalancerV2() *schema.Resource {
 				Optional:    true,
 				ForceNew:    true,
 			},
+			"internal": &schema.Schema{
+				Type: schema.TypeBool,
+				Description: "Create the load balancer with the VIP in the private network only, without a public IP. " +
+					"It requires `vip_network_id` or `vip_port_id`, the network or the port must not be external.",
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
 			"vip_address": &schema.Schema{
 				Type:        schema.TypeString,
 				Description: "Load balancer IP address. IP address will be changed when load balancer will be recreated if `vip_port_id` is not specified.",
@@ -137,6 +158,77 @@ func resourceLoadBalancerV2() *schema.Resource {
 					return diag.Errorf("wrong type %s, available values are '%s', '%s', '%s'", v, types.IPv4IPFamilyType, types.IPv6IPFamilyType, types.DualStackIPFamilyType)
 				},
 			},
+			"provisioning_status": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Provisioning status of the load balancer, ACTIVE when no changes are in progress.",
+				Computed:    true,
+			},
+			"operating_status": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Operating status of the load balancer, ONLINE when the load balancer and its members are healthy.",
+				Computed:    true,
+			},
+			"logging": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Description: "Sending of the load balancer access logs to the logging service (LaaS).",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": &schema.Schema{
+							Type:        schema.TypeBool,
+							Required:    true,
+							Description: "Enable or disable forwarding of the access logs.",
+						},
+						"destination_region_id": &schema.Schema{
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Computed:    true,
+							Description: "ID of the region of the logging service.",
+						},
+						"topic_name": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "Name of the logging topic.",
+						},
+						"retention_policy": &schema.Schema{
+							Type:        schema.TypeList,
+							Optional:    true,
+							Computed:    true,
+							MaxItems:    1,
+							Description: "Retention policy of the logging topic.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"period": &schema.Schema{
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntAtLeast(1),
+										Description:  "Number of days to keep the logs.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"wait_for_quota": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Retry load balancer creation while it fails because of the exceeded quota instead of failing immediately.",
+			},
+			"creator_task_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the task that created the load balancer.",
+			},
+			"task_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the task running on the load balancer, empty when no task is running.",
+			},
 			"last_updated": &schema.Schema{
 				Type:        schema.TypeString,
 				Description: "Datetime when load balancer was updated at the last time.",
@@ -189,8 +281,14 @@ func resourceLoadBalancerV2Create(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
+	if d.Get("internal").(bool) {
+		if err := checkLoadBalancerInternalVIP(config, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	opts := loadbalancers.CreateOpts{
-		Name:         d.Get("name").(string),
+		Name:         config.fullResourceName(d.Get("name").(string)),
 		VipNetworkID: d.Get("vip_network_id").(string),
 		VipSubnetID:  d.Get("vip_subnet_id").(string),
 		VipPortID:    d.Get("vip_port_id").(string),
@@ -209,31 +307,47 @@ func resourceLoadBalancerV2Create(ctx context.Context, d *schema.ResourceData, m
 	if len(lbFlavor) != 0 {
 		opts.Flavor = &lbFlavor
 	}
-	timeout := int(d.Timeout(schema.TimeoutCreate).Seconds())
-	rc := GetConflictRetryConfig(timeout)
-	results, err := loadbalancers.Create(client, opts, &gcorecloud.RequestOpts{
-		ConflictRetryAmount:   rc.Amount,
-		ConflictRetryInterval: rc.Interval,
-	}).Extract()
-	if err != nil {
-		return diag.FromErr(err)
-	}
 
-	taskID := results.Tasks[0]
-	lbID, err := tasks.WaitTaskAndReturnResult(client, taskID, true, timeout, func(task tasks.TaskID) (interface{}, error) {
-		taskInfo, err := tasks.Get(client, string(task)).Extract()
-		if err != nil {
-			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
+	if logging, ok := loadBalancerLoggingFromSchema(d); ok {
+		opts.Logging = &loadbalancers.CreateLoggingOpts{
+			Enabled:             logging.Enabled,
+			TopicName:           logging.TopicName,
+			DestinationRegionID: logging.DestinationRegionID,
+		}
+		if logging.RetentionPolicy != nil {
+			opts.Logging.RetentionPolicy = &loadbalancers.CreateRetentionPolicyOpts{Period: logging.RetentionPolicy.Period}
 		}
-		lbID, err := loadbalancers.ExtractLoadBalancerIDFromTask(taskInfo)
+	}
+	timeout := int(d.Timeout(schema.TimeoutCreate).Seconds())
+	rc := GetConflictRetryConfig(timeout)
+	var lbID interface{}
+	waitForQuota := d.Get("wait_for_quota").(bool)
+	err = retryOnQuotaExceeded(ctx, waitForQuota, d.Timeout(schema.TimeoutCreate), func() error {
+		results, err := loadbalancers.Create(client, opts, &gcorecloud.RequestOpts{
+			ConflictRetryAmount:   rc.Amount,
+			ConflictRetryInterval: rc.Interval,
+		}).Extract()
 		if err != nil {
-			return nil, fmt.Errorf("cannot retrieve LoadBalancer ID from task info: %w", err)
+			return err
 		}
-		return lbID, nil
+
+		taskID := results.Tasks[0]
+		lbID, err = tasks.WaitTaskAndReturnResult(client, taskID, true, timeout, func(task tasks.TaskID) (interface{}, error) {
+			taskInfo, err := tasks.Get(client, string(task)).Extract()
+			if err != nil {
+				return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
+			}
+			lbID, err := loadbalancers.ExtractLoadBalancerIDFromTask(taskInfo)
+			if err != nil {
+				return nil, fmt.Errorf("cannot retrieve LoadBalancer ID from task info: %w", err)
+			}
+			return lbID, nil
+		})
+		return err
 	})
 
 	if err != nil {
-		return diag.FromErr(err)
+		return quotaExceededDiagnostics(provider, client.RegionID, err)
 	}
 
 	d.SetId(lbID.(string))
@@ -258,13 +372,30 @@ func resourceLoadBalancerV2Read(ctx context.Context, d *schema.ResourceData, m i
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	d.Set("creator_task_id", pointer.GetString(lb.CreatorTaskID))
+	d.Set("task_id", pointer.GetString(lb.TaskID))
 	d.Set("project_id", lb.ProjectID)
 	d.Set("region_id", lb.RegionID)
-	d.Set("name", lb.Name)
+	setResourceName(d, config, lb.Name)
 	d.Set("flavor", lb.Flavor.FlavorName)
 	d.Set("vip_port_id", lb.VipPortID)
 	d.Set("vrrp_ips", lb.VrrpIPs)
 	d.Set("vip_ip_family", lb.VipIPFamilyType)
+	d.Set("provisioning_status", lb.ProvisioningStatus.String())
+	if lb.Logging != nil {
+		logging := map[string]interface{}{
+			"enabled":               lb.Logging.Enabled,
+			"destination_region_id": lb.Logging.DestinationRegionID,
+			"topic_name":            lb.Logging.TopicName,
+		}
+		if lb.Logging.RetentionPolicy != nil {
+			logging["retention_policy"] = []interface{}{map[string]interface{}{"period": lb.Logging.RetentionPolicy.Period}}
+		}
+		if err := d.Set("logging", []interface{}{logging}); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	d.Set("operating_status", lb.OperationStatus.String())
 
 	if lb.VipAddress != nil {
 		d.Set("vip_address", lb.VipAddress.String())
@@ -296,6 +427,10 @@ func resourceLoadBalancerV2Read(ctx context.Context, d *schema.ResourceData, m i
 		return diag.FromErr(err)
 	}
 
+	if d.Get("internal").(bool) {
+		diags = append(diags, loadBalancerInternalDiagnostics(lb.ID, lb.FloatingIPs)...)
+	}
+
 	log.Println("[DEBUG] Finish LoadBalancer reading")
 	return diags
 }
@@ -310,9 +445,19 @@ func resourceLoadBalancerV2Update(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
-	if d.HasChange("name") {
+	if d.HasChanges("name", "logging") {
 		opts := loadbalancers.UpdateOpts{
-			Name: d.Get("name").(string),
+			Name: config.fullResourceName(d.Get("name").(string)),
+		}
+		if logging, ok := loadBalancerLoggingFromSchema(d); ok && d.HasChange("logging") {
+			opts.Logging = &loadbalancers.UpdateLoggingOpts{
+				Enabled:             logging.Enabled,
+				TopicName:           logging.TopicName,
+				DestinationRegionID: logging.DestinationRegionID,
+			}
+			if logging.RetentionPolicy != nil {
+				opts.Logging.RetentionPolicy = &loadbalancers.UpdateRetentionPolicyOpts{Period: logging.RetentionPolicy.Period}
+			}
 		}
 		_, err = loadbalancers.Update(client, d.Id(), opts).Extract()
 		if err != nil {
@@ -337,29 +482,7 @@ func resourceLoadBalancerV2Update(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	if d.HasChange("flavor") {
-		flavor := d.Get("flavor").(string)
-		timeout := int(d.Timeout(schema.TimeoutUpdate).Seconds())
-		rc := GetConflictRetryConfig(timeout)
-		results, err := loadbalancers.Resize(client, d.Id(), loadbalancers.ResizeOpts{
-			Flavor: flavor,
-		}, &gcorecloud.RequestOpts{
-			ConflictRetryAmount:   rc.Amount,
-			ConflictRetryInterval: rc.Interval,
-		}).Extract()
-		if err != nil {
-			return diag.FromErr(err)
-		}
-		taskID := results.Tasks[0]
-		log.Printf("[DEBUG] Task id (%s)", taskID)
-		taskState, err := tasks.WaitTaskAndReturnResult(client, taskID, true, timeout, func(task tasks.TaskID) (interface{}, error) {
-			taskInfo, err := tasks.Get(client, string(task)).Extract()
-			if err != nil {
-				return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
-			}
-			return taskInfo.State, nil
-		})
-		log.Printf("[DEBUG] Task state (%s)", taskState)
-		if err != nil {
+		if err := resizeLoadBalancer(client, d.Id(), d.Get("flavor").(string), int(d.Timeout(schema.TimeoutUpdate).Seconds())); err != nil {
 			return diag.FromErr(err)
 		}
 	}
@@ -367,3 +490,47 @@ func resourceLoadBalancerV2Update(ctx context.Context, d *schema.ResourceData, m
 	log.Println("[DEBUG] Finish LoadBalancer updating")
 	return resourceLoadBalancerV2Read(ctx, d, m)
 }
+
+// resizeLoadBalancer changes the flavor of the load balancer in place and waits for the task,
+// the load balancer keeps its VIP and listeners.
+func resizeLoadBalancer(client *gcorecloud.ServiceClient, lbID, flavor string, timeout int) error {
+	rc := GetConflictRetryConfig(timeout)
+	results, err := loadbalancers.Resize(client, lbID, loadbalancers.ResizeOpts{
+		Flavor: flavor,
+	}, &gcorecloud.RequestOpts{
+		ConflictRetryAmount:   rc.Amount,
+		ConflictRetryInterval: rc.Interval,
+	}).Extract()
+	if err != nil {
+		return err
+	}
+	taskID := results.Tasks[0]
+	log.Printf("[DEBUG] Task id (%s)", taskID)
+	taskState, err := tasks.WaitTaskAndReturnResult(client, taskID, true, timeout, func(task tasks.TaskID) (interface{}, error) {
+		taskInfo, err := tasks.Get(client, string(task)).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
+		}
+		return taskInfo.State, nil
+	})
+	log.Printf("[DEBUG] Task state (%s)", taskState)
+	return err
+}
+
+// loadBalancerLoggingFromSchema returns the configured logging block, ok is false when it is not set.
+func loadBalancerLoggingFromSchema(d *schema.ResourceData) (loadbalancers.Logging, bool) {
+	raw := d.Get("logging").([]interface{})
+	if len(raw) == 0 || raw[0] == nil {
+		return loadbalancers.Logging{}, false
+	}
+	l := raw[0].(map[string]interface{})
+	logging := loadbalancers.Logging{
+		Enabled:             l["enabled"].(bool),
+		TopicName:           l["topic_name"].(string),
+		DestinationRegionID: l["destination_region_id"].(int),
+	}
+	if rp := l["retention_policy"].([]interface{}); len(rp) > 0 && rp[0] != nil {
+		logging.RetentionPolicy = &loadbalancers.RetentionPolicy{Period: rp[0].(map[string]interface{})["period"].(int)}
+	}
+	return logging, true
+}
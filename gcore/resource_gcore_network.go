@@ -12,6 +12,7 @@ import (
 	"github.com/G-Core/gcorelabscloud-go/gcore/utils"
 	"github.com/G-Core/gcorelabscloud-go/gcore/utils/metadata/v1/metadata"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -27,6 +28,7 @@ func resourceNetwork() *schema.Resource {
 		UpdateContext: resourceNetworkUpdate,
 		DeleteContext: resourceNetworkDelete,
 		Description:   "Represent network. A network is a software-defined network in a cloud computing infrastructure",
+		CustomizeDiff: customdiff.ValidateChange("name", validateNameConvention),
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				projectID, regionID, NetworkID, err := ImportStringParser(d.Id())
@@ -144,7 +146,7 @@ func resourceNetworkCreate(ctx context.Context, d *schema.ResourceData, m interf
 	}
 
 	createOpts := networks.CreateOpts{
-		Name:         d.Get("name").(string),
+		Name:         config.fullResourceName(d.Get("name").(string)),
 		Type:         d.Get("type").(string),
 		CreateRouter: d.Get("create_router").(bool),
 	}
@@ -209,7 +211,7 @@ func resourceNetworkRead(ctx context.Context, d *schema.ResourceData, m interfac
 		return diag.Errorf("cannot get network with ID: %s. Error: %s", networkID, err)
 	}
 
-	d.Set("name", network.Name)
+	setResourceName(d, config, network.Name)
 	d.Set("mtu", network.MTU)
 	d.Set("type", network.Type)
 	d.Set("region_id", network.RegionID)
@@ -257,7 +259,7 @@ func resourceNetworkUpdate(ctx context.Context, d *schema.ResourceData, m interf
 	}
 
 	if d.HasChange("name") {
-		newName := d.Get("name").(string)
+		newName := config.fullResourceName(d.Get("name").(string))
 		_, err := networks.Update(client, networkID, networks.UpdateOpts{Name: newName}).Extract()
 		if err != nil {
 			return diag.FromErr(err)
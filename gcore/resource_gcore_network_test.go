@@ -25,7 +25,7 @@ func TestAccNetwork(t *testing.T) {
 	}
 
 	paramsCreate := Params{
-		Name: "create_test",
+		Name: testAccNamePrefix + "network",
 		Mtu:  1450,
 		Type: "vxlan",
 		MetadataMap: `{
@@ -35,7 +35,7 @@ func TestAccNetwork(t *testing.T) {
 	}
 
 	paramsUpdate := Params{
-		Name: "update_test",
+		Name: testAccNamePrefix + "network2",
 		MetadataMap: `{
 				key3 = "val3"
 			  }`,
@@ -7,6 +7,7 @@ import (
 	"net"
 	"time"
 
+	"github.com/AlekSi/pointer"
 	gcorecloud "github.com/G-Core/gcorelabscloud-go"
 	"github.com/G-Core/gcorelabscloud-go/gcore/port/v1/ports"
 	"github.com/G-Core/gcorelabscloud-go/gcore/reservedfixedip/v1/reservedfixedips"
@@ -149,6 +150,21 @@ func resourceReservedFixedIP() *schema.Resource {
 				Computed:    true,
 				Optional:    true,
 			},
+			"device_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "ID of the resource the reserved fixed IP is attached to, e.g. the instance or the load balancer. Empty if the IP is not attached.",
+				Computed:    true,
+			},
+			"device_type": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Type of the resource the reserved fixed IP is attached to, e.g. 'instance' or 'loadbalancer'. Empty if the IP is not attached.",
+				Computed:    true,
+			},
+			"reservation_status": &schema.Schema{
+				Type:        schema.TypeString,
+				Description: "Status of the reservation, e.g. 'available' or 'attached'.",
+				Computed:    true,
+			},
 			"allowed_address_pairs": {
 				Type:        schema.TypeList,
 				Optional:    true,
@@ -168,6 +184,16 @@ func resourceReservedFixedIP() *schema.Resource {
 					},
 				},
 			},
+			"creator_task_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the task that created the reserved fixed IP.",
+			},
+			"task_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the task running on the reserved fixed IP, empty when no task is running.",
+			},
 			"last_updated": &schema.Schema{
 				Type:        schema.TypeString,
 				Description: "Datetime when reserved fixed ip was updated at the last time.",
@@ -302,6 +328,8 @@ func resourceReservedFixedIPRead(ctx context.Context, d *schema.ResourceData, m
 		}
 	}
 
+	d.Set("creator_task_id", reservedFixedIP.CreatorTaskID)
+	d.Set("task_id", pointer.GetString(reservedFixedIP.TaskID))
 	d.Set("project_id", reservedFixedIP.ProjectID)
 	d.Set("region_id", reservedFixedIP.RegionID)
 	d.Set("status", reservedFixedIP.Status)
@@ -310,6 +338,9 @@ func resourceReservedFixedIPRead(ctx context.Context, d *schema.ResourceData, m
 	d.Set("network_id", reservedFixedIP.NetworkID)
 	d.Set("is_vip", reservedFixedIP.IsVip)
 	d.Set("port_id", reservedFixedIP.PortID)
+	d.Set("device_id", pointer.GetString(reservedFixedIP.Reservation.ResourceID))
+	d.Set("device_type", pointer.GetString(reservedFixedIP.Reservation.ResourceType))
+	d.Set("reservation_status", reservedFixedIP.Reservation.Status)
 
 	allowedPairs := make([]map[string]interface{}, len(reservedFixedIP.AllowedAddressPairs))
 	for i, p := range reservedFixedIP.AllowedAddressPairs {
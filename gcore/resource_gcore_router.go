@@ -11,6 +11,7 @@ import (
 	"github.com/G-Core/gcorelabscloud-go/gcore/subnet/v1/subnets"
 	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -25,6 +26,7 @@ func resourceRouter() *schema.Resource {
 		UpdateContext: resourceRouterUpdate,
 		DeleteContext: resourceRouterDelete,
 		Description:   "Represent router. Router enables you to dynamically exchange routes between networks",
+		CustomizeDiff: customdiff.ValidateChange("name", validateNameConvention),
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				projectID, regionID, routerID, err := ImportStringParser(d.Id())
@@ -174,6 +176,16 @@ func resourceRouter() *schema.Resource {
 					},
 				},
 			},
+			"creator_task_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the task that created the router.",
+			},
+			"task_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the task running on the router, empty when no task is running.",
+			},
 			"last_updated": &schema.Schema{
 				Type:     schema.TypeString,
 				Computed: true,
@@ -195,7 +207,7 @@ func resourceRouterCreate(ctx context.Context, d *schema.ResourceData, m interfa
 
 	createOpts := routers.CreateOpts{}
 
-	createOpts.Name = d.Get("name").(string)
+	createOpts.Name = config.fullResourceName(d.Get("name").(string))
 
 	egi := d.Get("external_gateway_info")
 	if len(egi.([]interface{})) > 0 {
@@ -274,7 +286,7 @@ func resourceRouterRead(ctx context.Context, d *schema.ResourceData, m interface
 		return diag.Errorf("cannot get router with ID: %s. Error: %s", routerID, err)
 	}
 
-	d.Set("name", router.Name)
+	setResourceName(d, config, router.Name)
 
 	if len(router.ExternalGatewayInfo.ExternalFixedIPs) > 0 {
 		egi := make(map[string]interface{}, 4)
@@ -328,6 +340,8 @@ func resourceRouterRead(ctx context.Context, d *schema.ResourceData, m interface
 		rmap["nexthop"] = r.NextHop.String()
 		rs[i] = rmap
 	}
+	d.Set("creator_task_id", router.CreatorTaskID)
+	d.Set("task_id", router.TaskID)
 	d.Set("routes", rs)
 
 	log.Println("[DEBUG] Finish router reading")
@@ -348,7 +362,7 @@ func resourceRouterUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 	updateOpts := routers.UpdateOpts{}
 
 	if d.HasChange("name") {
-		updateOpts.Name = d.Get("name").(string)
+		updateOpts.Name = config.fullResourceName(d.Get("name").(string))
 	}
 
 	// Only one kind of update is supported when external manual gateway is set.
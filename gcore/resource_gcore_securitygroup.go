@@ -12,6 +12,7 @@ import (
 	"github.com/G-Core/gcorelabscloud-go/gcore/securitygroup/v1/types"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -30,6 +31,7 @@ func resourceSecurityGroup() *schema.Resource {
 		UpdateContext: resourceSecurityGroupUpdate,
 		DeleteContext: resourceSecurityGroupDelete,
 		Description:   "Represent SecurityGroups(Firewall)",
+		CustomizeDiff: customdiff.ValidateChange("name", validateNameConvention),
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				projectID, regionID, sgID, err := ImportStringParser(d.Id())
@@ -175,9 +177,10 @@ func resourceSecurityGroup() *schema.Resource {
 							Default:  "",
 						},
 						"remote_ip_prefix": &schema.Schema{
-							Type:     schema.TypeString,
-							Optional: true,
-							Default:  "",
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "",
+							ValidateDiagFunc: validateEmptyOr(validateCIDR),
 						},
 						"updated_at": &schema.Schema{
 							Type:     schema.TypeString,
@@ -190,6 +193,12 @@ func resourceSecurityGroup() *schema.Resource {
 					},
 				},
 			},
+			"delete_default_egress_rules": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Delete the allow-all egress rules the cloud adds to a new security group, unless they are set in `security_group_rules`. Without it the default rules are deleted by the next apply. Configured rules deleted outside of Terraform are always recreated.",
+			},
 			"last_updated": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
@@ -228,7 +237,7 @@ func resourceSecurityGroupCreate(ctx context.Context, d *schema.ResourceData, m
 	rules := convertToSecurityGroupRules(rawRules)
 
 	createSecurityGroupOpts := &securitygroups.CreateSecurityGroupOpts{}
-	createSecurityGroupOpts.Name = d.Get("name").(string)
+	createSecurityGroupOpts.Name = config.fullResourceName(d.Get("name").(string))
 	createSecurityGroupOpts.SecurityGroupRules = rules
 
 	if metadataRaw, ok := d.GetOk("metadata_map"); ok {
@@ -250,6 +259,23 @@ func resourceSecurityGroupCreate(ctx context.Context, d *schema.ResourceData, m
 
 	d.SetId(sg.ID)
 
+	if d.Get("delete_default_egress_rules").(bool) {
+		rulesClient, err := CreateClient(provider, d, securityGroupRulesPoint, versionPointV1)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		configured := d.Get("security_group_rules").(*schema.Set)
+		for i, r := range convertSecurityGroupRules(sg.SecurityGroupRules) {
+			if !isDefaultEgressRule(sg.SecurityGroupRules[i]) || configured.Contains(r) {
+				continue
+			}
+			log.Printf("[DEBUG] Deleting default egress rule %s of SecurityGroup %s", sg.SecurityGroupRules[i].ID, sg.ID)
+			if err := securitygrouprules.Delete(rulesClient, sg.SecurityGroupRules[i].ID).ExtractErr(); err != nil {
+				return diag.Errorf("cannot delete default egress rule %s: %s", sg.SecurityGroupRules[i].ID, err)
+			}
+		}
+	}
+
 	resourceSecurityGroupRead(ctx, d, m)
 	log.Printf("[DEBUG] Finish SecurityGroup creating (%s)", sg.ID)
 	return diags
@@ -273,7 +299,7 @@ func resourceSecurityGroupRead(ctx context.Context, d *schema.ResourceData, m in
 
 	d.Set("project_id", sg.ProjectID)
 	d.Set("region_id", sg.RegionID)
-	d.Set("name", sg.Name)
+	setResourceName(d, config, sg.Name)
 	d.Set("description", sg.Description)
 
 	metadataMap := make(map[string]string)
@@ -417,6 +443,28 @@ func resourceSecurityGroupDelete(ctx context.Context, d *schema.ResourceData, m
 	return diags
 }
 
+// isDefaultEgressRule reports whether the rule is one of the allow-all egress rules
+// the cloud adds to every new security group, one for IPv4 and one for IPv6.
+func isDefaultEgressRule(rule securitygroups.SecurityGroupRule) bool {
+	if rule.Direction != types.RuleDirectionEgress {
+		return false
+	}
+	if rule.Protocol != nil && *rule.Protocol != types.ProtocolAny {
+		return false
+	}
+	if rule.PortRangeMin != nil || rule.PortRangeMax != nil {
+		return false
+	}
+	if rule.RemoteIPPrefix != nil {
+		switch *rule.RemoteIPPrefix {
+		case "", "0.0.0.0/0", "::/0":
+		default:
+			return false
+		}
+	}
+	return rule.RemoteGroupID == nil || *rule.RemoteGroupID == ""
+}
+
 func convertSecurityGroupRules(rules []securitygroups.SecurityGroupRule) []interface{} {
 	result := make([]interface{}, len(rules))
 	for i, sgr := range rules {
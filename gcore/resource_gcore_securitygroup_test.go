@@ -85,6 +85,41 @@ func TestAccSecurityGroup(t *testing.T) {
 	})
 }
 
+func TestAccSecurityGroupDeleteDefaultEgressRules(t *testing.T) {
+	fullName := "gcore_securitygroup.acctest"
+
+	tpl := fmt.Sprintf(`
+			resource "gcore_securitygroup" "acctest" {
+			  %s
+              %s
+			  name = "test"
+			  delete_default_egress_rules = true
+			  security_group_rules {
+			  	direction = "egress"
+			    ethertype = "IPv4"
+				protocol = "tcp"
+				port_range_min = 443
+				port_range_max = 443
+			  }
+			}
+		`, projectInfo(), regionInfo())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccSecurityGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: tpl,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceExists(fullName),
+					resource.TestCheckResourceAttr(fullName, "security_group_rules.#", "1"),
+				),
+			},
+		},
+	})
+}
+
 func testAccSecurityGroupDestroy(s *terraform.State) error {
 	config := testAccProvider.Meta().(*Config)
 	client, err := CreateTestClient(config.Provider, securityGroupPoint, versionPointV1)
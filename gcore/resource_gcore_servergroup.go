@@ -6,6 +6,7 @@ import (
 
 	"github.com/G-Core/gcorelabscloud-go/gcore/servergroup/v1/servergroups"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -19,6 +20,7 @@ func resourceServerGroup() *schema.Resource {
 		ReadContext:   resourceServerGroupRead,
 		DeleteContext: resourceServerGroupDelete,
 		Description:   "Represent server group resource",
+		CustomizeDiff: customdiff.ValidateChange("name", validateNameConvention),
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				projectID, regionID, sgID, err := ImportStringParser(d.Id())
@@ -118,7 +120,7 @@ func resourceServerGroupCreate(ctx context.Context, d *schema.ResourceData, m in
 	}
 
 	opts := servergroups.CreateOpts{
-		Name:   d.Get("name").(string),
+		Name:   config.fullResourceName(d.Get("name").(string)),
 		Policy: servergroups.ServerGroupPolicy(d.Get("policy").(string)),
 	}
 
@@ -149,7 +151,7 @@ func resourceServerGroupRead(ctx context.Context, d *schema.ResourceData, m inte
 		return diag.FromErr(err)
 	}
 
-	d.Set("name", serverGroup.Name)
+	setResourceName(d, config, serverGroup.Name)
 	d.Set("project_id", serverGroup.ProjectID)
 	d.Set("region_id", serverGroup.RegionID)
 	d.Set("policy", serverGroup.Policy.String())
@@ -6,6 +6,7 @@ import (
 	"log"
 	"time"
 
+	"github.com/AlekSi/pointer"
 	gcorecloud "github.com/G-Core/gcorelabscloud-go"
 	"github.com/G-Core/gcorelabscloud-go/gcore/snapshot/v1/snapshots"
 	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
@@ -103,6 +104,16 @@ func resourceSnapshot() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"creator_task_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the task that created the snapshot.",
+			},
+			"task_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the task running on the snapshot, empty when no task is running.",
+			},
 			"last_updated": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
@@ -174,6 +185,8 @@ func resourceSnapshotRead(ctx context.Context, d *schema.ResourceData, m interfa
 		return diag.Errorf("cannot get snapshot with ID: %s. Error: %s", snapshotID, err)
 	}
 
+	d.Set("creator_task_id", pointer.GetString(snapshot.CreatorTaskID))
+	d.Set("task_id", pointer.GetString(snapshot.TaskID))
 	d.Set("name", snapshot.Name)
 	d.Set("description", snapshot.Description)
 	d.Set("status", snapshot.Status)
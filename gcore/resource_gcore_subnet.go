@@ -15,6 +15,7 @@ import (
 	"github.com/G-Core/gcorelabscloud-go/gcore/utils/metadata/v1/metadata"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -28,6 +29,7 @@ func resourceSubnet() *schema.Resource {
 		UpdateContext: resourceSubnetUpdate,
 		DeleteContext: resourceSubnetDelete,
 		Description:   "Represent subnets. Subnetwork is a range of IP addresses in a cloud network. Addresses from this range will be assigned to machines in the cloud.",
+		CustomizeDiff: customdiff.ValidateChange("name", validateNameConvention),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(SubnetResourceTimeoutMinutes * time.Minute),
 			Delete: schema.DefaultTimeout(SubnetResourceTimeoutMinutes * time.Minute),
@@ -98,15 +100,17 @@ func resourceSubnet() *schema.Resource {
 				Computed:    true,
 			},
 			"cidr": &schema.Schema{
-				Type:        schema.TypeString,
-				Description: "Classless Inter-Domain Routing, can be IPv4 or IPv6.",
-				Required:    true,
-				ForceNew:    true,
+				Type:             schema.TypeString,
+				Description:      "Classless Inter-Domain Routing, can be IPv4 or IPv6.",
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateCIDR,
 			},
 			"network_id": &schema.Schema{
-				Type:        schema.TypeString,
-				Description: "ID of the desired network to create subnet in.",
-				Required:    true,
+				Type:             schema.TypeString,
+				Description:      "ID of the desired network to create subnet in.",
+				Required:         true,
+				ValidateDiagFunc: validateUUID,
 			},
 			"connect_to_network_router": &schema.Schema{
 				Type:        schema.TypeBool,
@@ -187,6 +191,16 @@ func resourceSubnet() *schema.Resource {
 					},
 				},
 			},
+			"creator_task_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the task that created the subnet.",
+			},
+			"task_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the task running on the subnet, empty when no task is running.",
+			},
 			"last_updated": &schema.Schema{
 				Type:        schema.TypeString,
 				Description: "Datetime when subnet was updated at the last time.",
@@ -242,7 +256,7 @@ func resourceSubnetCreate(ctx context.Context, d *schema.ResourceData, m interfa
 		}
 	}
 
-	createOpts.Name = d.Get("name").(string)
+	createOpts.Name = config.fullResourceName(d.Get("name").(string))
 	createOpts.EnableDHCP = d.Get("enable_dhcp").(bool)
 	createOpts.NetworkID = d.Get("network_id").(string)
 	createOpts.ConnectToNetworkRouter = d.Get("connect_to_network_router").(bool)
@@ -318,7 +332,9 @@ func resourceSubnetRead(ctx context.Context, d *schema.ResourceData, m interface
 		return diag.Errorf("cannot get subnet with ID: %s. Error: %s", subnetID, err)
 	}
 
-	d.Set("name", subnet.Name)
+	setResourceName(d, config, subnet.Name)
+	d.Set("creator_task_id", subnet.CreatorTaskID)
+	d.Set("task_id", subnet.TaskID)
 	d.Set("enable_dhcp", subnet.EnableDHCP)
 	d.Set("cidr", subnet.CIDR.String())
 	d.Set("network_id", subnet.NetworkID)
@@ -389,7 +405,7 @@ func resourceSubnetUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 	updateOpts := subnets.UpdateOpts{}
 
 	if d.HasChange("name") {
-		updateOpts.Name = d.Get("name").(string)
+		updateOpts.Name = config.fullResourceName(d.Get("name").(string))
 	}
 	updateOpts.EnableDHCP = d.Get("enable_dhcp").(bool)
 
@@ -11,9 +11,11 @@ import (
 	metadatav1 "github.com/G-Core/gcorelabscloud-go/gcore/utils/metadata/v1/metadata"
 
 	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/snapshot/v1/snapshots"
 	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
 	"github.com/G-Core/gcorelabscloud-go/gcore/volume/v1/volumes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -29,6 +31,7 @@ func resourceVolume() *schema.Resource {
 		UpdateContext: resourceVolumeUpdate,
 		DeleteContext: resourceVolumeDelete,
 		Description:   "Represent volume. A volume is a file storage which is similar to SSD and HDD hard disks but located in the cloud",
+		CustomizeDiff: customdiff.ValidateChange("name", validateNameConvention),
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				projectID, regionID, volumeID, err := ImportStringParser(d.Id())
@@ -119,6 +122,80 @@ func resourceVolume() *schema.Resource {
 				ForceNew:    true,
 				Description: "Mandatory if volume is created from a snapshot",
 			},
+			"wait_for_quota": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Retry volume creation while it fails because of the exceeded quota instead of failing immediately.",
+			},
+			"creator_task_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the task that created the volume.",
+			},
+			"snapshots": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Snapshots of the volume. They are read only with the volume_snapshots provider feature, it takes a request per volume on every refresh.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"multiattach": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "The type of the volume allows to attach it to several instances with gcore_volume_attachment.",
+			},
+			"attachments": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Instances the volume is attached to.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"instance_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"instance_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"attachment_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"device": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"attached_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 			"last_updated": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
@@ -171,28 +248,34 @@ func resourceVolumeCreate(ctx context.Context, d *schema.ResourceData, m interfa
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	results, err := volumes.Create(client, opts).Extract()
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	taskID := results.Tasks[0]
-	log.Printf("[DEBUG] Task id (%s)", taskID)
-	VolumeID, err := tasks.WaitTaskAndReturnResult(client, taskID, true, volumeCreatingTimeout, func(task tasks.TaskID) (interface{}, error) {
-		taskInfo, err := tasks.Get(client, string(task)).Extract()
+	opts.Name = config.fullResourceName(opts.Name)
+	var VolumeID interface{}
+	waitForQuota := d.Get("wait_for_quota").(bool)
+	err = retryOnQuotaExceeded(ctx, waitForQuota, time.Duration(volumeCreatingTimeout)*time.Second, func() error {
+		results, err := volumes.Create(client, opts).Extract()
 		if err != nil {
-			return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
+			return err
 		}
-		volumeID, err := volumes.ExtractVolumeIDFromTask(taskInfo)
-		if err != nil {
-			return nil, fmt.Errorf("cannot retrieve volume ID from task info: %w", err)
-		}
-		return volumeID, nil
-	},
-	)
+
+		taskID := results.Tasks[0]
+		log.Printf("[DEBUG] Task id (%s)", taskID)
+		VolumeID, err = tasks.WaitTaskAndReturnResult(client, taskID, true, volumeCreatingTimeout, func(task tasks.TaskID) (interface{}, error) {
+			taskInfo, err := tasks.Get(client, string(task)).Extract()
+			if err != nil {
+				return nil, fmt.Errorf("cannot get task with ID: %s. Error: %w", task, err)
+			}
+			volumeID, err := volumes.ExtractVolumeIDFromTask(taskInfo)
+			if err != nil {
+				return nil, fmt.Errorf("cannot retrieve volume ID from task info: %w", err)
+			}
+			return volumeID, nil
+		},
+		)
+		return err
+	})
 	log.Printf("[DEBUG] Volume id (%s)", VolumeID)
 	if err != nil {
-		return diag.FromErr(err)
+		return quotaExceededDiagnostics(provider, client.RegionID, err)
 	}
 
 	d.SetId(VolumeID.(string))
@@ -236,16 +319,21 @@ func resourceVolumeRead(ctx context.Context, d *schema.ResourceData, m interface
 		return diag.FromErr(err)
 	}
 
-	volume, err := volumes.Get(client, volumeID).Extract()
+	volume, err := getVolumeWithMultiattach(client, volumeID)
 	if err != nil {
 		return diag.Errorf("cannot get volume with ID: %s. Error: %s", volumeID, err)
 	}
 
-	d.Set("name", volume.Name)
+	setResourceName(d, config, volume.Name)
+	d.Set("creator_task_id", volume.CreatorTaskID)
 	d.Set("size", volume.Size)
 	d.Set("type_name", volume.VolumeType)
 	d.Set("region_id", volume.RegionID)
 	d.Set("project_id", volume.ProjectID)
+	d.Set("multiattach", volume.Multiattach != nil && *volume.Multiattach)
+	if err := d.Set("attachments", flattenVolumeAttachments(volume.Attachments)); err != nil {
+		return diag.FromErr(err)
+	}
 
 	metadataMap, metadataReadOnly := PrepareMetadata(volume.Metadata)
 
@@ -257,6 +345,20 @@ func resourceVolumeRead(ctx context.Context, d *schema.ResourceData, m interface
 		return diag.FromErr(err)
 	}
 
+	if config.Features.VolumeSnapshots {
+		snapshotsClient, err := CreateClient(provider, d, snapshotsPoint, versionPointV1)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		volumeSnapshots, err := snapshots.ListAll(snapshotsClient, snapshots.ListOpts{VolumeID: volumeID})
+		if err != nil {
+			return diag.Errorf("cannot list snapshots of volume with ID: %s. Error: %s", volumeID, err)
+		}
+		if err = d.Set("snapshots", flattenVolumeSnapshots(volumeSnapshots)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	fields := []string{"image_id", "snapshot_id"}
 	revertState(d, &fields)
 
@@ -281,7 +383,7 @@ func resourceVolumeUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 	}
 
 	if d.HasChange("name") {
-		name := d.Get("name").(string)
+		name := config.fullResourceName(d.Get("name").(string))
 		_, err := volumes.Update(client, volumeID, volumes.UpdateOpts{Name: name}).Extract()
 		if err != nil {
 			return diag.FromErr(err)
@@ -448,3 +550,17 @@ func ExtendVolume(client *gcorecloud.ServiceClient, volumeID string, newSize int
 	log.Printf("[DEBUG] Finish waiting.")
 	return nil
 }
+
+func flattenVolumeSnapshots(volumeSnapshots []snapshots.Snapshot) []map[string]interface{} {
+	result := make([]map[string]interface{}, len(volumeSnapshots))
+	for i, snapshot := range volumeSnapshots {
+		result[i] = map[string]interface{}{
+			"id":         snapshot.ID,
+			"name":       snapshot.Name,
+			"status":     snapshot.Status,
+			"size":       snapshot.Size,
+			"created_at": snapshot.CreatedAt.Format(time.RFC3339),
+		}
+	}
+	return result
+}
@@ -0,0 +1,316 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/volume/v1/volumes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const volumeAttachmentTimeout = 10 * time.Minute
+
+// volumeAttachmentLocks serializes the attachments of the same volume, the volume accepts one attach or detach at a time
+// while terraform applies the attachments of a multiattach volume in parallel.
+var volumeAttachmentLocks sync.Map
+
+func lockVolumeAttachments(volumeID string) func() {
+	mu, _ := volumeAttachmentLocks.LoadOrStore(volumeID, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
+
+// volumeWithMultiattach is the volume with the multiattach flag of its type, the SDK doesn't read it.
+// The flag is nil if the API doesn't return it.
+type volumeWithMultiattach struct {
+	Multiattach *bool `json:"multiattach"`
+	volumes.Volume
+}
+
+func getVolumeWithMultiattach(client *gcorecloud.ServiceClient, volumeID string) (*volumeWithMultiattach, error) {
+	var volume volumeWithMultiattach
+	if err := volumes.Get(client, volumeID).ExtractInto(&volume); err != nil {
+		return nil, err
+	}
+	return &volume, nil
+}
+
+func findVolumeAttachment(attachments []volumes.Attachment, instanceID string) *volumes.Attachment {
+	for i := range attachments {
+		if attachments[i].ServerID == instanceID {
+			return &attachments[i]
+		}
+	}
+	return nil
+}
+
+// checkVolumeMultiattach checks that the volume can be attached to one more instance.
+func checkVolumeMultiattach(volume *volumeWithMultiattach, instanceID string) error {
+	if volume.Multiattach == nil || *volume.Multiattach {
+		return nil
+	}
+	var others []string
+	for _, attachment := range volume.Attachments {
+		if attachment.ServerID != instanceID {
+			others = append(others, attachment.ServerID)
+		}
+	}
+	if len(others) > 0 {
+		return fmt.Errorf("volume %s of the %s type is not multiattach and is already attached to %s",
+			volume.ID, volume.VolumeType, strings.Join(others, ", "))
+	}
+	return nil
+}
+
+func flattenVolumeAttachments(attachments []volumes.Attachment) []map[string]interface{} {
+	result := make([]map[string]interface{}, len(attachments))
+	for i, attachment := range attachments {
+		result[i] = map[string]interface{}{
+			"instance_id":   attachment.ServerID,
+			"instance_name": attachment.InstanceName,
+			"attachment_id": attachment.AttachmentID,
+			"device":        attachment.Device,
+			"attached_at":   attachment.AttachedAt.Format(time.RFC3339),
+		}
+	}
+	return result
+}
+
+func resourceVolumeAttachment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVolumeAttachmentCreate,
+		ReadContext:   resourceVolumeAttachmentRead,
+		DeleteContext: resourceVolumeAttachmentDelete,
+		Description: "Represent the attachment of the volume to the instance. A volume of a multiattach type can be attached to several instances " +
+			"with several attachments. The volume must not be listed in the volume blocks of the instance. An existing attachment fails the creation, it must be imported.",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(volumeAttachmentTimeout),
+			Delete: schema.DefaultTimeout(volumeAttachmentTimeout),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				projectID, regionID, volumeID, instanceID, err := ImportStringParserExtended(d.Id())
+				if err != nil {
+					return nil, err
+				}
+				d.Set("project_id", projectID)
+				d.Set("region_id", regionID)
+				d.Set("volume_id", volumeID)
+				d.Set("instance_id", instanceID)
+				d.SetId(volumeAttachmentID(volumeID, instanceID))
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+				DiffSuppressFunc: suppressDiffProjectID,
+			},
+			"region_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+				DiffSuppressFunc: suppressDiffRegionID,
+			},
+			"project_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"project_id",
+					"project_name",
+				},
+			},
+			"region_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ExactlyOneOf: []string{
+					"region_id",
+					"region_name",
+				},
+			},
+			"volume_id": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateUUID,
+			},
+			"instance_id": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateUUID,
+			},
+			"attachment_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"device": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Device name of the volume in the instance, e.g. /dev/vdb.",
+			},
+			"attached_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func volumeAttachmentID(volumeID, instanceID string) string {
+	return volumeID + ":" + instanceID
+}
+
+func resourceVolumeAttachmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start volume attachment creating")
+	config := m.(*Config)
+	provider := config.Provider
+
+	client, err := CreateClient(provider, d, volumesPoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	volumeID, instanceID := d.Get("volume_id").(string), d.Get("instance_id").(string)
+	unlock := lockVolumeAttachments(volumeID)
+	defer unlock()
+
+	volume, err := getVolumeWithMultiattach(client, volumeID)
+	if err != nil {
+		return diag.Errorf("cannot get volume with ID: %s. Error: %s", volumeID, err)
+	}
+	// the existing attachment may belong to the volume blocks of the instance or to another configuration,
+	// it is managed by the resource only when it is imported
+	if findVolumeAttachment(volume.Attachments, instanceID) != nil {
+		return diag.Errorf("volume %s is already attached to instance %s, import the attachment to manage it: "+
+			"terraform import <address> <project_id>:<region_id>:%s:%s", volumeID, instanceID, volumeID, instanceID)
+	}
+	if err := checkVolumeMultiattach(volume, instanceID); err != nil {
+		return diag.FromErr(err)
+	}
+	opts := volumes.InstanceOperationOpts{InstanceID: instanceID}
+	if _, err := volumes.Attach(client, volumeID, opts).Extract(); err != nil {
+		return diag.Errorf("cannot attach volume %s to instance %s: %s", volumeID, instanceID, err)
+	}
+	if err := waitForVolumeAttachment(ctx, client, volumeID, instanceID, true, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.Errorf("Error waiting for volume %s to be attached to instance %s: %s", volumeID, instanceID, err)
+	}
+
+	d.SetId(volumeAttachmentID(volumeID, instanceID))
+	log.Printf("[DEBUG] Finish volume attachment creating (%s)", d.Id())
+	return resourceVolumeAttachmentRead(ctx, d, m)
+}
+
+func resourceVolumeAttachmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Start volume attachment reading (%s)", d.Id())
+	config := m.(*Config)
+	provider := config.Provider
+
+	client, err := CreateClient(provider, d, volumesPoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	volumeID, instanceID := d.Get("volume_id").(string), d.Get("instance_id").(string)
+	volume, err := volumes.Get(client, volumeID).Extract()
+	if err != nil {
+		if _, ok := err.(gcorecloud.ErrDefault404); ok {
+			log.Printf("[WARN] Volume %s of the attachment is deleted", volumeID)
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("cannot get volume with ID: %s. Error: %s", volumeID, err)
+	}
+
+	attachment := findVolumeAttachment(volume.Attachments, instanceID)
+	if attachment == nil {
+		log.Printf("[WARN] Volume %s is not attached to instance %s anymore", volumeID, instanceID)
+		d.SetId("")
+		return nil
+	}
+	d.Set("project_id", volume.ProjectID)
+	d.Set("region_id", volume.RegionID)
+	d.Set("attachment_id", attachment.AttachmentID)
+	d.Set("device", attachment.Device)
+	d.Set("attached_at", attachment.AttachedAt.Format(time.RFC3339))
+
+	log.Println("[DEBUG] Finish volume attachment reading")
+	return nil
+}
+
+func resourceVolumeAttachmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Start volume attachment deleting (%s)", d.Id())
+	config := m.(*Config)
+	provider := config.Provider
+
+	client, err := CreateClient(provider, d, volumesPoint, versionPointV1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	volumeID, instanceID := d.Get("volume_id").(string), d.Get("instance_id").(string)
+	unlock := lockVolumeAttachments(volumeID)
+	defer unlock()
+
+	opts := volumes.InstanceOperationOpts{InstanceID: instanceID}
+	if _, err := volumes.Detach(client, volumeID, opts).Extract(); err != nil {
+		if _, ok := err.(gcorecloud.ErrDefault404); !ok {
+			return diag.Errorf("cannot detach volume %s from instance %s: %s", volumeID, instanceID, err)
+		}
+	} else if err := waitForVolumeAttachment(ctx, client, volumeID, instanceID, false, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.Errorf("Error waiting for volume %s to be detached from instance %s: %s", volumeID, instanceID, err)
+	}
+
+	d.SetId("")
+	log.Println("[DEBUG] Finish volume attachment deleting")
+	return nil
+}
+
+// waitForVolumeAttachment waits until the volume is attached to the instance or detached from it.
+func waitForVolumeAttachment(ctx context.Context, client *gcorecloud.ServiceClient, volumeID, instanceID string, attached bool, timeout time.Duration) error {
+	pending, target := "detached", "attached"
+	if !attached {
+		pending, target = target, pending
+	}
+	conf := retry.StateChangeConf{
+		Pending: []string{pending},
+		Target:  []string{target},
+		Refresh: func() (interface{}, string, error) {
+			volume, err := volumes.Get(client, volumeID).Extract()
+			if err != nil {
+				return nil, "", err
+			}
+			if findVolumeAttachment(volume.Attachments, instanceID) != nil {
+				return volume, "attached", nil
+			}
+			return volume, "detached", nil
+		},
+		Timeout:    timeout,
+		MinTimeout: 3 * time.Second,
+	}
+	_, err := conf.WaitForStateContext(ctx)
+	return err
+}
@@ -0,0 +1,89 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+)
+
+func TestVolumeMultiattach(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/volumes/1/2/shared":
+			fmt.Fprint(w, `{"id":"shared","volume_type":"ssd_hiiops","multiattach":true,"attachments":[{"server_id":"a","device":"/dev/vdb"}]}`)
+		case "/v1/volumes/1/2/single":
+			fmt.Fprint(w, `{"id":"single","volume_type":"standard","multiattach":false,"attachments":[{"server_id":"a","device":"/dev/vdb"}]}`)
+		case "/v1/volumes/1/2/unknown":
+			fmt.Fprint(w, `{"id":"unknown","volume_type":"standard","attachments":[{"server_id":"a","device":"/dev/vdb"}]}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := &gcorecloud.ServiceClient{
+		ProviderClient: &gcorecloud.ProviderClient{APIBase: server.URL + "/"},
+		Endpoint:       server.URL + "/v1/volumes/1/2/",
+	}
+
+	tests := []struct {
+		volumeID   string
+		instanceID string
+		wantErr    bool
+	}{
+		{"shared", "b", false},
+		{"single", "b", true},
+		{"single", "a", false},
+		{"unknown", "b", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.volumeID+"/"+tt.instanceID, func(t *testing.T) {
+			volume, err := getVolumeWithMultiattach(client, tt.volumeID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(volume.Attachments) != 1 || findVolumeAttachment(volume.Attachments, "a") == nil {
+				t.Fatalf("attachments = %+v, want the attachment to a", volume.Attachments)
+			}
+			if err := checkVolumeMultiattach(volume, tt.instanceID); (err != nil) != tt.wantErr {
+				t.Errorf("checkVolumeMultiattach() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVolumeAttachmentCreateExisting(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/volumes/1/2/shared" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"id":"shared","volume_type":"ssd_hiiops","multiattach":true,"attachments":[{"server_id":"a","device":"/dev/vdb"}]}`)
+	}))
+	defer server.Close()
+	config := &Config{Provider: &gcorecloud.ProviderClient{APIBase: server.URL + "/"}}
+
+	d := resourceVolumeAttachment().TestResourceData()
+	d.Set("project_id", 1)
+	d.Set("region_id", 2)
+	d.Set("volume_id", "shared")
+	d.Set("instance_id", "a")
+	diags := resourceVolumeAttachmentCreate(context.Background(), d, config)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "terraform import") {
+		t.Fatalf("create of the existing attachment = %v, want the import error", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("the existing attachment is adopted with ID %s", d.Id())
+	}
+}
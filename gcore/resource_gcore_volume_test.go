@@ -28,7 +28,7 @@ func TestAccVolume(t *testing.T) {
 	}
 
 	create := Params{
-		Name: "test",
+		Name: testAccNamePrefix + "volume",
 		Size: 1,
 		Type: "standard",
 		MetadataMap: `{
@@ -38,7 +38,7 @@ func TestAccVolume(t *testing.T) {
 	}
 
 	update := Params{
-		Name: "test2",
+		Name: testAccNamePrefix + "volume2",
 		Size: 2,
 		Type: "ssd_hiiops",
 		MetadataMap: `{
@@ -0,0 +1,194 @@
+package gcore
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type waapAPIDiscoverySettings struct {
+	DescriptionFileLocation          *string `json:"description_file_location,omitempty"`
+	DescriptionFileScanEnabled       *bool   `json:"description_file_scan_enabled,omitempty"`
+	DescriptionFileScanIntervalHours *int    `json:"description_file_scan_interval_hours,omitempty"`
+	TrafficScanEnabled               *bool   `json:"traffic_scan_enabled,omitempty"`
+	TrafficScanIntervalHours         *int    `json:"traffic_scan_interval_hours,omitempty"`
+}
+
+type waapAPIDescriptionUpload struct {
+	FileName string `json:"file_name"`
+	FileData string `json:"file_data"`
+}
+
+func resourceWAAPAPIDiscovery() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceWAAPAPIDiscoveryUpdate,
+		ReadContext:   resourceWAAPAPIDiscoveryRead,
+		UpdateContext: resourceWAAPAPIDiscoveryUpdate,
+		DeleteContext: resourceWAAPAPIDiscoveryDelete,
+		Description: "Represent the API discovery settings of a WAAP domain and the uploaded OpenAPI description of its API. " +
+			"The settings are left as is on destroy.",
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				domainID, err := strconv.Atoi(d.Id())
+				if err != nil {
+					return nil, fmt.Errorf("import ID %q must be the domain ID", d.Id())
+				}
+				d.Set("domain_id", domainID)
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:        schema.TypeInt,
+				Description: "ID of the WAAP domain.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"description_file_location": {
+				Type:         schema.TypeString,
+				Description:  "URL of the OpenAPI description of the API, it is scanned periodically.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"description_file_scan_enabled": {
+				Type:        schema.TypeBool,
+				Description: "Scan the description file for the API paths.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"description_file_scan_interval_hours": {
+				Type:         schema.TypeInt,
+				Description:  "Interval of the description file scan in hours.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"traffic_scan_enabled": {
+				Type:        schema.TypeBool,
+				Description: "Discover the API paths from the traffic of the domain.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"traffic_scan_interval_hours": {
+				Type:         schema.TypeInt,
+				Description:  "Interval of the traffic scan in hours.",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"openapi_spec": {
+				Type:         schema.TypeString,
+				Description:  "OpenAPI description of the API in JSON or YAML, e.g. `file(\"openapi.yaml\")`. It is uploaded on create and on change.",
+				Optional:     true,
+				RequiredWith: []string{"openapi_spec", "openapi_file_name"},
+			},
+			"openapi_file_name": {
+				Type:         schema.TypeString,
+				Description:  "File name of the uploaded OpenAPI description, e.g. `openapi.yaml`.",
+				Optional:     true,
+				RequiredWith: []string{"openapi_spec", "openapi_file_name"},
+			},
+		},
+	}
+}
+
+func resourceWAAPAPIDiscoveryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	domainID := d.Get("domain_id").(int)
+	log.Printf("[DEBUG] Start WAAP API discovery reading (domain_id=%d)", domainID)
+	config := m.(*Config)
+
+	var settings waapAPIDiscoverySettings
+	if err := waapRequest(config, http.MethodGet, fmt.Sprintf("/domains/%d/api-discovery/settings", domainID), nil, &settings); err != nil {
+		if waapNotFound(err) {
+			log.Printf("[WARN] WAAP domain %d is gone, removing its API discovery from the state", domainID)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	if settings.DescriptionFileLocation != nil {
+		d.Set("description_file_location", *settings.DescriptionFileLocation)
+	}
+	if settings.DescriptionFileScanEnabled != nil {
+		d.Set("description_file_scan_enabled", *settings.DescriptionFileScanEnabled)
+	}
+	if settings.DescriptionFileScanIntervalHours != nil {
+		d.Set("description_file_scan_interval_hours", *settings.DescriptionFileScanIntervalHours)
+	}
+	if settings.TrafficScanEnabled != nil {
+		d.Set("traffic_scan_enabled", *settings.TrafficScanEnabled)
+	}
+	if settings.TrafficScanIntervalHours != nil {
+		d.Set("traffic_scan_interval_hours", *settings.TrafficScanIntervalHours)
+	}
+
+	log.Println("[DEBUG] Finish WAAP API discovery reading")
+	return nil
+}
+
+func resourceWAAPAPIDiscoveryUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	domainID := d.Get("domain_id").(int)
+	log.Printf("[DEBUG] Start WAAP API discovery updating (domain_id=%d)", domainID)
+	config := m.(*Config)
+
+	settings := waapAPIDiscoverySettingsFromSchema(d)
+	if err := waapRequest(config, http.MethodPatch, fmt.Sprintf("/domains/%d/api-discovery/settings", domainID), settings, nil); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if spec := d.Get("openapi_spec").(string); spec != "" && (d.IsNewResource() || d.HasChanges("openapi_spec", "openapi_file_name")) {
+		upload := waapAPIDescriptionUpload{
+			FileName: d.Get("openapi_file_name").(string),
+			FileData: base64.StdEncoding.EncodeToString([]byte(spec)),
+		}
+		if err := waapRequest(config, http.MethodPost, fmt.Sprintf("/domains/%d/api-discovery/upload", domainID), upload, nil); err != nil {
+			return diag.FromErr(fmt.Errorf("upload OpenAPI description: %w", err))
+		}
+	}
+
+	d.SetId(strconv.Itoa(domainID))
+	log.Println("[DEBUG] Finish WAAP API discovery updating")
+	return resourceWAAPAPIDiscoveryRead(ctx, d, m)
+}
+
+// resourceWAAPAPIDiscoveryDelete leaves the settings as is, they belong to the domain.
+func resourceWAAPAPIDiscoveryDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] WAAP API discovery of domain %s is removed from the state only", d.Id())
+	d.SetId("")
+	return nil
+}
+
+// waapAPIDiscoverySettingsFromSchema returns the configured settings, the unset ones are kept by the API.
+func waapAPIDiscoverySettingsFromSchema(d *schema.ResourceData) waapAPIDiscoverySettings {
+	var settings waapAPIDiscoverySettings
+	if v, ok := d.GetOk("description_file_location"); ok {
+		location := v.(string)
+		settings.DescriptionFileLocation = &location
+	}
+	if v, ok := d.GetOkExists("description_file_scan_enabled"); ok {
+		enabled := v.(bool)
+		settings.DescriptionFileScanEnabled = &enabled
+	}
+	if v, ok := d.GetOk("description_file_scan_interval_hours"); ok {
+		hours := v.(int)
+		settings.DescriptionFileScanIntervalHours = &hours
+	}
+	if v, ok := d.GetOkExists("traffic_scan_enabled"); ok {
+		enabled := v.(bool)
+		settings.TrafficScanEnabled = &enabled
+	}
+	if v, ok := d.GetOk("traffic_scan_interval_hours"); ok {
+		hours := v.(int)
+		settings.TrafficScanIntervalHours = &hours
+	}
+	return settings
+}
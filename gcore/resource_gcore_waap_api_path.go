@@ -0,0 +1,203 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var (
+	waapAPIPathMethods  = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS", "TRACE"}
+	waapAPIPathSchemes  = []string{"HTTP", "HTTPS"}
+	waapAPIPathStatuses = []string{"CONFIRMED_API", "POTENTIAL_API", "NOT_API", "DELISTED_API"}
+)
+
+type waapAPIPath struct {
+	ID         string   `json:"id,omitempty"`
+	Path       string   `json:"path"`
+	Method     string   `json:"method,omitempty"`
+	HTTPScheme string   `json:"http_scheme,omitempty"`
+	APIVersion string   `json:"api_version,omitempty"`
+	APIGroups  []string `json:"api_groups"`
+	Tags       []string `json:"tags"`
+	Status     string   `json:"status,omitempty"`
+	Source     string   `json:"source,omitempty"`
+}
+
+func resourceWAAPAPIPath() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceWAAPAPIPathCreate,
+		ReadContext:   resourceWAAPAPIPathRead,
+		UpdateContext: resourceWAAPAPIPathUpdate,
+		DeleteContext: resourceWAAPAPIPathDelete,
+		Description:   "Represent an API path definition of a WAAP domain, the requests to the path get the API protection.",
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				domainID, pathID, err := parseWAAPImportID(d.Id())
+				if err != nil {
+					return nil, err
+				}
+				d.Set("domain_id", domainID)
+				d.SetId(pathID)
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:        schema.TypeInt,
+				Description: "ID of the WAAP domain.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"path": {
+				Type:         schema.TypeString,
+				Description:  "Path of the API endpoint, the path parameters are in curly braces, e.g. `/api/v1/users/{user_id}`.",
+				Required:     true,
+				ValidateFunc: apiRequestPathValidate,
+			},
+			"method": {
+				Type:         schema.TypeString,
+				Description:  fmt.Sprintf("HTTP method of the endpoint, one of %v.", waapAPIPathMethods),
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(waapAPIPathMethods, false),
+			},
+			"http_scheme": {
+				Type:         schema.TypeString,
+				Description:  "HTTP scheme of the endpoint, `HTTP` or `HTTPS`.",
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "HTTPS",
+				ValidateFunc: validation.StringInSlice(waapAPIPathSchemes, false),
+			},
+			"api_version": {
+				Type:        schema.TypeString,
+				Description: "Version of the API, e.g. `v1`.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"api_groups": {
+				Type:        schema.TypeSet,
+				Description: "API groups of the endpoint.",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"tags": {
+				Type:        schema.TypeSet,
+				Description: "Tags of the endpoint.",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"status": {
+				Type:         schema.TypeString,
+				Description:  fmt.Sprintf("Status of the endpoint, one of %v.", waapAPIPathStatuses),
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(waapAPIPathStatuses, false),
+			},
+			"source": {
+				Type:        schema.TypeString,
+				Description: "Source the endpoint is defined by, e.g. `USER_DEFINED` or `API_DESCRIPTION_FILE`.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceWAAPAPIPathCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Println("[DEBUG] Start WAAP API path creating")
+	config := m.(*Config)
+	domainID := d.Get("domain_id").(int)
+
+	req := waapAPIPath{
+		Path:       d.Get("path").(string),
+		Method:     d.Get("method").(string),
+		HTTPScheme: d.Get("http_scheme").(string),
+		APIVersion: d.Get("api_version").(string),
+		APIGroups:  waapStrings(d.Get("api_groups").(*schema.Set)),
+		Tags:       waapStrings(d.Get("tags").(*schema.Set)),
+	}
+	var path waapAPIPath
+	if err := waapRequest(config, http.MethodPost, fmt.Sprintf("/domains/%d/api-paths", domainID), req, &path); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(path.ID)
+
+	// the status of a new path is set by the API, the configured one is applied with an update
+	if status, ok := d.GetOk("status"); ok && status.(string) != path.Status {
+		body := map[string]interface{}{"status": status.(string)}
+		if err := waapRequest(config, http.MethodPatch, fmt.Sprintf("/domains/%d/api-paths/%s", domainID, path.ID), body, nil); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Printf("[DEBUG] Finish WAAP API path creating (%s)", d.Id())
+	return resourceWAAPAPIPathRead(ctx, d, m)
+}
+
+func resourceWAAPAPIPathRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Start WAAP API path reading (%s)", d.Id())
+	config := m.(*Config)
+
+	var path waapAPIPath
+	err := waapRequest(config, http.MethodGet, fmt.Sprintf("/domains/%d/api-paths/%s", d.Get("domain_id").(int), d.Id()), nil, &path)
+	if err != nil {
+		if waapNotFound(err) {
+			log.Printf("[WARN] WAAP API path %s is gone, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("path", path.Path)
+	d.Set("method", path.Method)
+	d.Set("http_scheme", path.HTTPScheme)
+	d.Set("api_version", path.APIVersion)
+	d.Set("api_groups", path.APIGroups)
+	d.Set("tags", path.Tags)
+	d.Set("status", path.Status)
+	d.Set("source", path.Source)
+
+	log.Println("[DEBUG] Finish WAAP API path reading")
+	return nil
+}
+
+func resourceWAAPAPIPathUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Start WAAP API path updating (%s)", d.Id())
+	config := m.(*Config)
+
+	body := map[string]interface{}{
+		"path":       d.Get("path").(string),
+		"api_groups": waapStrings(d.Get("api_groups").(*schema.Set)),
+		"tags":       waapStrings(d.Get("tags").(*schema.Set)),
+	}
+	if status, ok := d.GetOk("status"); ok {
+		body["status"] = status.(string)
+	}
+	if err := waapRequest(config, http.MethodPatch, fmt.Sprintf("/domains/%d/api-paths/%s", d.Get("domain_id").(int), d.Id()), body, nil); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Println("[DEBUG] Finish WAAP API path updating")
+	return resourceWAAPAPIPathRead(ctx, d, m)
+}
+
+func resourceWAAPAPIPathDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Start WAAP API path deleting (%s)", d.Id())
+	config := m.(*Config)
+
+	err := waapRequest(config, http.MethodDelete, fmt.Sprintf("/domains/%d/api-paths/%s", d.Get("domain_id").(int), d.Id()), nil, nil)
+	if err != nil && !waapNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	log.Println("[DEBUG] Finish WAAP API path deleting")
+	return nil
+}
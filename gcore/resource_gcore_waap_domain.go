@@ -0,0 +1,162 @@
+package gcore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var waapDomainModes = []string{"active", "monitor"}
+
+// waapDomainStatusModes maps the domain statuses to the protection modes. The other statuses, bypass and locked,
+// are set by the platform rather than by the user, the mode is kept as is then.
+var waapDomainStatusModes = map[string]string{
+	"active":  "active",
+	"monitor": "monitor",
+}
+
+type waapDomain struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+type waapDomainSettings struct {
+	API waapDomainAPISettings `json:"api"`
+}
+
+type waapDomainAPISettings struct {
+	APIURLs []string `json:"api_urls"`
+}
+
+func resourceWAAPDomain() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceWAAPDomainUpdate,
+		ReadContext:   resourceWAAPDomainRead,
+		UpdateContext: resourceWAAPDomainUpdate,
+		DeleteContext: resourceWAAPDomainDelete,
+		Description: "Represent the protection settings of a WAAP domain. The domain is created by enabling WAAP on the CDN resource, " +
+			"the resource manages its settings and leaves the domain as is on destroy.",
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				domainID, err := strconv.Atoi(d.Id())
+				if err != nil {
+					return nil, fmt.Errorf("import ID %q must be the domain ID", d.Id())
+				}
+				d.Set("domain_id", domainID)
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:        schema.TypeInt,
+				Description: "ID of the WAAP domain.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"mode": {
+				Type:         schema.TypeString,
+				Description:  "Protection mode, `active` blocks the attacks, `monitor` only logs them.",
+				Optional:     true,
+				Default:      "active",
+				ValidateFunc: validation.StringInSlice(waapDomainModes, false),
+			},
+			"api_urls": {
+				Type:        schema.TypeList,
+				Description: "Base paths of the API of the domain, e.g. `/api/v1`, the requests under them get the API protection.",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: apiRequestPathValidate,
+				},
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Name of the domain.",
+				Computed:    true,
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Description: "Status of the domain: `active`, `monitor`, `bypass` or `locked`.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceWAAPDomainRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	domainID := d.Get("domain_id").(int)
+	log.Printf("[DEBUG] Start WAAP domain reading (id=%d)", domainID)
+	config := m.(*Config)
+
+	var domain waapDomain
+	if err := waapRequest(config, http.MethodGet, fmt.Sprintf("/domains/%d", domainID), nil, &domain); err != nil {
+		if waapNotFound(err) {
+			log.Printf("[WARN] WAAP domain %d is gone, removing it from the state", domainID)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	var settings waapDomainSettings
+	if err := waapRequest(config, http.MethodGet, fmt.Sprintf("/domains/%d/settings", domainID), nil, &settings); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	d.Set("name", domain.Name)
+	d.Set("status", domain.Status)
+	if mode, ok := waapDomainStatusModes[domain.Status]; ok {
+		d.Set("mode", mode)
+	} else {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "WAAP domain protection mode is unknown",
+			Detail:   fmt.Sprintf("WAAP domain %d has the %q status, the mode %q is kept from the state.", domainID, domain.Status, d.Get("mode").(string)),
+		})
+	}
+	d.Set("api_urls", settings.API.APIURLs)
+
+	log.Println("[DEBUG] Finish WAAP domain reading")
+	return diags
+}
+
+func resourceWAAPDomainUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	domainID := d.Get("domain_id").(int)
+	log.Printf("[DEBUG] Start WAAP domain updating (id=%d)", domainID)
+	config := m.(*Config)
+
+	if d.IsNewResource() || d.HasChange("mode") {
+		body := map[string]interface{}{"status": d.Get("mode").(string)}
+		if err := waapRequest(config, http.MethodPatch, fmt.Sprintf("/domains/%d", domainID), body, nil); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if d.IsNewResource() || d.HasChange("api_urls") {
+		apiURLs := []string{}
+		for _, u := range d.Get("api_urls").([]interface{}) {
+			apiURLs = append(apiURLs, u.(string))
+		}
+		body := waapDomainSettings{API: waapDomainAPISettings{APIURLs: apiURLs}}
+		if err := waapRequest(config, http.MethodPatch, fmt.Sprintf("/domains/%d/settings", domainID), body, nil); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(strconv.Itoa(domainID))
+	log.Println("[DEBUG] Finish WAAP domain updating")
+	return resourceWAAPDomainRead(ctx, d, m)
+}
+
+// resourceWAAPDomainDelete leaves the domain and its settings as is, the domain is removed with the CDN resource.
+func resourceWAAPDomainDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] WAAP domain %s is removed from the state only", d.Id())
+	d.SetId("")
+	return nil
+}
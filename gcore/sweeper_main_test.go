@@ -0,0 +1,16 @@
+//go:build cloud
+// +build cloud
+
+package gcore
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestMain runs the sweepers instead of the tests when the binary is started with -sweep=<region>,
+// the region is the ID or the name of the region, the project is taken from TEST_PROJECT_ID or TEST_PROJECT_NAME.
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
@@ -0,0 +1,285 @@
+package gcore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	gc "github.com/G-Core/gcorelabscloud-go/gcore"
+	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/instances"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/loadbalancers"
+	"github.com/G-Core/gcorelabscloud-go/gcore/network/v1/networks"
+	"github.com/G-Core/gcorelabscloud-go/gcore/task/v1/tasks"
+	"github.com/G-Core/gcorelabscloud-go/gcore/volume/v1/volumes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const (
+	// GCORE_SWEEP_PREFIXES overrides the comma separated name prefixes of the swept resources
+	GCORE_SWEEP_PREFIXES_VAR VarName = "GCORE_SWEEP_PREFIXES"
+
+	sweepTaskTimeout = 1200
+)
+
+// testAccNamePrefix starts the names of the resources created by the acceptance tests, only they are swept by default.
+// The resources named otherwise are swept only when their prefixes are listed in GCORE_SWEEP_PREFIXES.
+const testAccNamePrefix = "tf-acc-"
+
+var sweepDefaultPrefixes = []string{testAccNamePrefix}
+
+func init() {
+	resource.AddTestSweepers("gcore_instance", &resource.Sweeper{
+		Name: "gcore_instance",
+		F:    sweepInstances,
+	})
+	resource.AddTestSweepers("gcore_loadbalancerv2", &resource.Sweeper{
+		Name: "gcore_loadbalancerv2",
+		F:    sweepLoadBalancers,
+	})
+	resource.AddTestSweepers("gcore_volume", &resource.Sweeper{
+		Name:         "gcore_volume",
+		F:            sweepVolumes,
+		Dependencies: []string{"gcore_instance"},
+	})
+	resource.AddTestSweepers("gcore_network", &resource.Sweeper{
+		Name:         "gcore_network",
+		F:            sweepNetworks,
+		Dependencies: []string{"gcore_instance", "gcore_loadbalancerv2"},
+	})
+	resource.AddTestSweepers("gcore_dns_zone", &resource.Sweeper{
+		Name: "gcore_dns_zone",
+		F:    sweepDNSZones,
+	})
+}
+
+func sweepPrefixes() []string {
+	raw := getEnv(GCORE_SWEEP_PREFIXES_VAR)
+	if raw == "" {
+		return sweepDefaultPrefixes
+	}
+	var prefixes []string
+	for _, p := range strings.Split(raw, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			prefixes = append(prefixes, p)
+		}
+	}
+	return prefixes
+}
+
+// sweepable reports whether the resource with the name was created by the acceptance tests.
+func sweepable(name string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(name, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// sweeperClient returns the client of the cloud endpoint in the swept region.
+func sweeperClient(region, endpoint string) (*gcorecloud.ServiceClient, error) {
+	config, err := createTestConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	regionID, err := strconv.Atoi(region)
+	if err != nil {
+		regionID, err = GetRegion(config.Provider, 0, region)
+		if err != nil {
+			return nil, err
+		}
+	}
+	projectID := 0
+	if strProjectID, exists := os.LookupEnv("TEST_PROJECT_ID"); exists {
+		projectID, err = strconv.Atoi(strProjectID)
+	} else {
+		projectID, err = GetProject(config.Provider, 0, os.Getenv("TEST_PROJECT_NAME"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return gc.ClientServiceFromProvider(config.Provider, gcorecloud.EndpointOpts{
+		Name:    endpoint,
+		Region:  regionID,
+		Project: projectID,
+		Version: versionPointV1,
+	})
+}
+
+func sweepWaitTask(client *gcorecloud.ServiceClient, results *tasks.TaskResults) error {
+	if len(results.Tasks) == 0 {
+		return nil
+	}
+	return tasks.WaitForStatus(client, string(results.Tasks[0]), tasks.TaskStateFinished, sweepTaskTimeout, true)
+}
+
+func sweepInstances(region string) error {
+	client, err := sweeperClient(region, InstancePoint)
+	if err != nil {
+		return fmt.Errorf("get client: %w", err)
+	}
+	list, err := instances.ListAll(client, nil)
+	if err != nil {
+		return fmt.Errorf("list instances: %w", err)
+	}
+
+	var errs []error
+	prefixes := sweepPrefixes()
+	for _, instance := range list {
+		if !sweepable(instance.Name, prefixes) {
+			continue
+		}
+		log.Printf("[DEBUG] Sweep instance %s (%s)", instance.Name, instance.ID)
+		results, err := instances.Delete(client, instance.ID, instances.DeleteOpts{}).Extract()
+		if err == nil {
+			err = sweepWaitTask(client, results)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("delete instance %s: %w", instance.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sweepLoadBalancers(region string) error {
+	client, err := sweeperClient(region, LoadBalancersPoint)
+	if err != nil {
+		return fmt.Errorf("get client: %w", err)
+	}
+	list, err := loadbalancers.ListAll(client, nil)
+	if err != nil {
+		return fmt.Errorf("list load balancers: %w", err)
+	}
+
+	var errs []error
+	prefixes := sweepPrefixes()
+	for _, lb := range list {
+		if !sweepable(lb.Name, prefixes) {
+			continue
+		}
+		log.Printf("[DEBUG] Sweep load balancer %s (%s)", lb.Name, lb.ID)
+		results, err := loadbalancers.Delete(client, lb.ID, nil).Extract()
+		if err == nil {
+			err = sweepWaitTask(client, results)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("delete load balancer %s: %w", lb.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sweepVolumes(region string) error {
+	client, err := sweeperClient(region, volumesPoint)
+	if err != nil {
+		return fmt.Errorf("get client: %w", err)
+	}
+	list, err := volumes.ListAll(client, nil)
+	if err != nil {
+		return fmt.Errorf("list volumes: %w", err)
+	}
+
+	var errs []error
+	prefixes := sweepPrefixes()
+	for _, volume := range list {
+		if !sweepable(volume.Name, prefixes) {
+			continue
+		}
+		log.Printf("[DEBUG] Sweep volume %s (%s)", volume.Name, volume.ID)
+		results, err := volumes.Delete(client, volume.ID, volumes.DeleteOpts{}).Extract()
+		if err == nil {
+			err = sweepWaitTask(client, results)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("delete volume %s: %w", volume.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// sweepNetworks deletes the networks together with their subnets.
+func sweepNetworks(region string) error {
+	client, err := sweeperClient(region, networksPoint)
+	if err != nil {
+		return fmt.Errorf("get client: %w", err)
+	}
+	list, err := networks.ListAll(client, nil)
+	if err != nil {
+		return fmt.Errorf("list networks: %w", err)
+	}
+
+	var errs []error
+	prefixes := sweepPrefixes()
+	for _, network := range list {
+		if network.External || !sweepable(network.Name, prefixes) {
+			continue
+		}
+		log.Printf("[DEBUG] Sweep network %s (%s)", network.Name, network.ID)
+		results, err := networks.Delete(client, network.ID).Extract()
+		if err == nil {
+			err = sweepWaitTask(client, results)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("delete network %s: %w", network.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// sweepDNSZones deletes the zones of the account, the region is ignored as DNS zones are global.
+func sweepDNSZones(_ string) error {
+	config, err := createTestConfig()
+	if err != nil {
+		return err
+	}
+	if config.DNSClient == nil {
+		log.Printf("[DEBUG] Skip DNS zones sweeping, %s is not set", GCORE_DNS_URL_VAR)
+		return nil
+	}
+
+	ctx := context.Background()
+	list, err := config.DNSClient.Zones(ctx)
+	if err != nil {
+		return fmt.Errorf("list DNS zones: %w", err)
+	}
+
+	var errs []error
+	prefixes := sweepPrefixes()
+	for _, zone := range list {
+		if !sweepable(zone.Name, prefixes) {
+			continue
+		}
+		log.Printf("[DEBUG] Sweep DNS zone %s", zone.Name)
+		if err := config.DNSClient.DeleteZone(ctx, zone.Name); err != nil {
+			errs = append(errs, fmt.Errorf("delete DNS zone %s: %w", zone.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func TestSweepable(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{name: "tf-acc-lb", want: true},
+		{name: "tf-acc-key42.com", want: true},
+		{name: "test-lb", want: false},
+		{name: "create_subnet", want: false},
+		{name: "production-lb", want: false},
+		{name: "my-tf-acc-vm", want: false},
+	}
+	for _, tt := range tests {
+		if got := sweepable(tt.name, sweepDefaultPrefixes); got != tt.want {
+			t.Errorf("sweepable(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package gcore
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	ProviderOptMaxRetries        = "max_retries"
+	ProviderOptRetryBackoff      = "retry_backoff"
+	ProviderOptRequestsPerSecond = "requests_per_second"
+	ProviderOptUserAgentSuffix   = "user_agent_suffix"
+	ProviderOptRequestIDPrefix   = "request_id_prefix"
+
+	maxRetryBackoff = time.Minute
+	requestIDHeader = "X-Client-Request-Id"
+)
+
+// retryTransport retries the requests rejected by the API rate limit (429) and,
+// for the idempotent methods, the transient gateway errors (502, 503, 504).
+// The delay grows exponentially from backoff unless the API sends Retry-After.
+// All requests sent through the transport share the limiter.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	backoff    time.Duration
+	limiter    *rateLimiter
+}
+
+func newRetryTransport(next http.RoundTripper, maxRetries int, backoff time.Duration, requestsPerSecond int) *retryTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &retryTransport{
+		next:       next,
+		maxRetries: maxRetries,
+		backoff:    backoff,
+		limiter:    newRateLimiter(requestsPerSecond),
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	attemptReq := req
+	for attempt := 0; ; attempt++ {
+		if err := t.limiter.wait(req.Context()); err != nil {
+			return nil, err
+		}
+		resp, err := t.next.RoundTrip(attemptReq)
+		if err != nil || attempt >= t.maxRetries || !retryableResponse(req, resp) {
+			if err == nil && resp.StatusCode == http.StatusServiceUnavailable {
+				markRegionOutage(req)
+			}
+			return resp, err
+		}
+		// a RoundTripper must not modify the request, each retry is sent as a copy with a new body,
+		// so the request with a body can be sent again only when the body can be recreated
+		retryReq := req.Clone(req.Context())
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, nil
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return resp, nil
+			}
+			retryReq.Body = body
+		}
+		attemptReq = retryReq
+
+		delay := retryDelay(resp, t.backoff, attempt)
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		log.Printf("[DEBUG] Retrying %s %s in %s after status %d (attempt %d of %d)", req.Method, req.URL.Path, delay, resp.StatusCode, attempt+1, t.maxRetries)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func retryableResponse(req *http.Request, resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		switch req.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+			return true
+		}
+	}
+	return false
+}
+
+// retryDelay returns the delay requested by Retry-After or the exponential backoff of the attempt.
+func retryDelay(resp *http.Response, backoff time.Duration, attempt int) time.Duration {
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+			return minDuration(time.Duration(seconds)*time.Second, maxRetryBackoff)
+		}
+		if at, err := http.ParseTime(v); err == nil {
+			return minDuration(time.Until(at), maxRetryBackoff)
+		}
+	}
+	delay := backoff
+	for i := 0; i < attempt && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+	return minDuration(delay, maxRetryBackoff)
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < 0 {
+		return 0
+	}
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// rateLimiter spaces the requests evenly, a nil limiter doesn't limit.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newRateLimiter(requestsPerSecond int) *rateLimiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+	return &rateLimiter{interval: time.Second / time.Duration(requestsPerSecond)}
+}
+
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// requestTagger marks the requests of the provider instance, so the API traffic
+// can be attributed to the pipeline in the audit logs.
+type requestTagger struct {
+	userAgentSuffix string
+	requestIDPrefix string
+}
+
+func (t requestTagger) empty() bool {
+	return t.userAgentSuffix == "" && t.requestIDPrefix == ""
+}
+
+// tag appends the suffix to the User-Agent and sets a unique X-Client-Request-Id with the prefix.
+func (t requestTagger) tag(req *http.Request) {
+	if t.userAgentSuffix != "" {
+		userAgent := t.userAgentSuffix
+		if ua := req.Header.Get("User-Agent"); ua != "" {
+			userAgent = ua + " " + t.userAgentSuffix
+		}
+		req.Header.Set("User-Agent", userAgent)
+	}
+	if t.requestIDPrefix != "" {
+		id := make([]byte, 8)
+		rand.Read(id)
+		req.Header.Set(requestIDHeader, t.requestIDPrefix+"-"+hex.EncodeToString(id))
+	}
+}
+
+// taggingTransport tags the requests before sending them, the retries of a request keep its ID.
+type taggingTransport struct {
+	next   http.RoundTripper
+	tagger requestTagger
+}
+
+func newTaggingTransport(next http.RoundTripper, tagger requestTagger) http.RoundTripper {
+	if tagger.empty() {
+		return next
+	}
+	return &taggingTransport{next: next, tagger: tagger}
+}
+
+func (t *taggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// a RoundTripper must not modify the request
+	req = req.Clone(req.Context())
+	t.tagger.tag(req)
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,168 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	var calls int32
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		switch {
+		case r.URL.Path == "/limited" && n == 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case r.URL.Path == "/unavailable":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: newRetryTransport(nil, 2, time.Millisecond, 0)}
+
+	t.Run("rate limited request is retried with the body", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		bodies = nil
+		resp, err := client.Post(srv.URL+"/limited", "application/json", strings.NewReader(`{"name":"a"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || calls != 2 {
+			t.Errorf("status %d after %d calls, want 200 after 2 calls", resp.StatusCode, calls)
+		}
+		if len(bodies) != 2 || bodies[1] != `{"name":"a"}` {
+			t.Errorf("retried request bodies %q", bodies)
+		}
+	})
+
+	t.Run("retries don't modify the request", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		bodies = nil
+		req, err := http.NewRequest(http.MethodPost, srv.URL+"/limited", strings.NewReader(`{"name":"b"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		body := req.Body
+		resp, err := client.Transport.RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if calls != 2 || req.Body != body {
+			t.Errorf("%d calls, request body replaced: %t", calls, req.Body != body)
+		}
+	})
+
+	t.Run("idempotent request is retried on gateway errors", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		resp, err := client.Get(srv.URL + "/unavailable")
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusServiceUnavailable || calls != 3 {
+			t.Errorf("status %d after %d calls, want 503 after 3 calls", resp.StatusCode, calls)
+		}
+	})
+
+	t.Run("post is not retried on gateway errors", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		resp, err := client.Post(srv.URL+"/unavailable", "application/json", strings.NewReader("{}"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if calls != 1 {
+			t.Errorf("%d calls, want 1", calls)
+		}
+	})
+}
+
+func TestRetryDelay(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	for attempt, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second} {
+		if got := retryDelay(resp, time.Second, attempt); got != want {
+			t.Errorf("retryDelay(attempt %d) = %s, want %s", attempt, got, want)
+		}
+	}
+	if got := retryDelay(resp, time.Second, 20); got != maxRetryBackoff {
+		t.Errorf("retryDelay() = %s, want the maximum %s", got, maxRetryBackoff)
+	}
+	resp.Header.Set("Retry-After", "7")
+	if got := retryDelay(resp, time.Second, 0); got != 7*time.Second {
+		t.Errorf("retryDelay() with Retry-After = %s, want 7s", got)
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	if err := newRateLimiter(0).wait(context.Background()); err != nil {
+		t.Fatal("a disabled limiter should not wait")
+	}
+
+	l := newRateLimiter(100)
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := l.wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("5 requests at 100 rps took %s, want at least 40ms", elapsed)
+	}
+}
+
+func TestTaggingTransport(t *testing.T) {
+	var requestIDs []string
+	var userAgent string
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestIDs = append(requestIDs, r.Header.Get(requestIDHeader))
+		userAgent = r.Header.Get("User-Agent")
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer srv.Close()
+
+	tagger := requestTagger{userAgentSuffix: "pipeline/deploy", requestIDPrefix: "ci-42"}
+	client := &http.Client{Transport: newTaggingTransport(newRetryTransport(nil, 1, time.Millisecond, 0), tagger)}
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	req.Header.Set("User-Agent", "terraform/1.5.0")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if userAgent != "terraform/1.5.0 pipeline/deploy" {
+		t.Errorf("User-Agent %q", userAgent)
+	}
+	if len(requestIDs) != 2 || !strings.HasPrefix(requestIDs[0], "ci-42-") || requestIDs[0] != requestIDs[1] {
+		t.Errorf("request IDs %q, want the same prefixed ID for the retry", requestIDs)
+	}
+	if req.Header.Get(requestIDHeader) != "" {
+		t.Error("original request is modified")
+	}
+
+	if _, ok := newTaggingTransport(http.DefaultTransport, requestTagger{}).(*taggingTransport); ok {
+		t.Error("transport without tags is wrapped")
+	}
+}
@@ -1,9 +1,11 @@
 package gcore
 
 import (
+	"context"
 	"crypto/md5"
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -11,12 +13,15 @@ import (
 	"net/url"
 	"os"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	dnssdk "github.com/G-Core/gcore-dns-sdk-go"
 	storageSDK "github.com/G-Core/gcore-storage-sdk-go"
 	gcdn "github.com/G-Core/gcorelabscdn-go"
+	gcdncore "github.com/G-Core/gcorelabscdn-go/gcore"
 	gcorecloud "github.com/G-Core/gcorelabscloud-go"
 	gc "github.com/G-Core/gcorelabscloud-go/gcore"
 	"github.com/G-Core/gcorelabscloud-go/gcore/ddos/v1/ddos"
@@ -24,6 +29,7 @@ import (
 	"github.com/G-Core/gcorelabscloud-go/gcore/instance/v1/types"
 	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/lbpools"
 	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/listeners"
+	"github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/loadbalancers"
 	typesLb "github.com/G-Core/gcorelabscloud-go/gcore/loadbalancer/v1/types"
 	"github.com/G-Core/gcorelabscloud-go/gcore/network/v1/availablenetworks"
 	"github.com/G-Core/gcorelabscloud-go/gcore/network/v1/networks"
@@ -35,6 +41,7 @@ import (
 	"github.com/G-Core/gcorelabscloud-go/gcore/subnet/v1/subnets"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/mitchellh/mapstructure"
 )
@@ -50,10 +57,121 @@ const (
 )
 
 type Config struct {
-	Provider      *gcorecloud.ProviderClient
-	CDNClient     gcdn.ClientService
-	StorageClient *storageSDK.SDK
-	DNSClient     *dnssdk.Client
+	Provider             *gcorecloud.ProviderClient
+	APIEndpoint          string
+	PlatformAPI          string
+	CDNClient            gcdn.ClientService
+	CDNRequester         gcdncore.Requester
+	StorageClient        *storageSDK.SDK
+	DNSClient            *dnssdk.Client
+	DNSAuthHeader        func() string
+	NamePrefix           string
+	NameSuffix           string
+	NameRegex            *regexp.Regexp
+	Features             providerFeatures
+	TolerateRegionOutage bool
+	ApplyMetrics         *applyMetrics
+	// ProviderKey is the providerClientKey of the credentials and endpoints of Provider
+	ProviderKey string
+
+	// sharedProvider is the provider client shared by the operations when Provider is bound to an operation context
+	sharedProvider *gcorecloud.ProviderClient
+}
+
+// withContext returns a copy of the config whose cloud API client sends requests with ctx.
+// The shared provider client is not modified, so concurrent operations keep their own contexts.
+func (c *Config) withContext(ctx context.Context) *Config {
+	if c.Provider == nil {
+		return c
+	}
+	shared := c.Provider
+	// the copy is built from the fields set at configuration only, the tokens are refreshed concurrently
+	// by the other operations, so they are taken under the lock of the shared client
+	provider := gcorecloud.ProviderClient{
+		IdentityBase:     shared.IdentityBase,
+		IdentityEndpoint: shared.IdentityEndpoint,
+		EndpointLocator:  shared.EndpointLocator,
+		HTTPClient:       shared.HTTPClient,
+		UserAgent:        shared.UserAgent,
+		Throwaway:        shared.IsThrowaway(),
+		Context:          ctx,
+		APIToken:         shared.APIToken,
+		APIBase:          shared.APIBase,
+	}
+	provider.UseTokenLock()
+	provider.CopyTokensFrom(shared)
+	if shared.IsDebug() {
+		provider.SetDebug(true)
+	}
+	if shared.ReauthFunc != nil {
+		// reauthentication refreshes the tokens of the shared client, pick them up for the retried request
+		provider.ReauthFunc = func() error {
+			if err := shared.ReauthFunc(); err != nil {
+				return err
+			}
+			provider.CopyTokensFrom(shared)
+			return nil
+		}
+	}
+
+	config := *c
+	config.Provider = &provider
+	config.sharedProvider = shared
+	return &config
+}
+
+// sharedProviderClient returns the provider client shared by the operations, its requests are not bound
+// to the context of an operation, so it suits the work done on behalf of several operations.
+func (c *Config) sharedProviderClient() *gcorecloud.ProviderClient {
+	if c.sharedProvider != nil {
+		return c.sharedProvider
+	}
+	return c.Provider
+}
+
+// fullResourceName returns the name extended with the provider level name prefix and suffix.
+func (c *Config) fullResourceName(name string) string {
+	if name == "" {
+		return name
+	}
+	if !strings.HasPrefix(name, c.NamePrefix) {
+		name = c.NamePrefix + name
+	}
+	if !strings.HasSuffix(name, c.NameSuffix) {
+		name = name + c.NameSuffix
+	}
+	return name
+}
+
+// shortResourceName returns the name without the provider level name prefix and suffix.
+func (c *Config) shortResourceName(name string) string {
+	name = strings.TrimPrefix(name, c.NamePrefix)
+	return strings.TrimSuffix(name, c.NameSuffix)
+}
+
+// setResourceName sets the name got from API keeping the configured form of the name if it matches.
+func setResourceName(d *schema.ResourceData, config *Config, name string) {
+	if current := d.Get("name").(string); current != "" && config.fullResourceName(current) == name {
+		d.Set("name", current)
+		return
+	}
+	d.Set("name", config.shortResourceName(name))
+}
+
+// validateNameConvention checks the new resource name against the provider level name_regex.
+func validateNameConvention(ctx context.Context, oldValue, newValue, meta interface{}) error {
+	config, ok := meta.(*Config)
+	if !ok || config.NameRegex == nil {
+		return nil
+	}
+	name := newValue.(string)
+	if name == "" || name == oldValue.(string) {
+		return nil
+	}
+	if fullName := config.fullResourceName(name); !config.NameRegex.MatchString(fullName) {
+		return fmt.Errorf("name %q doesn't match the naming convention %q", fullName, config.NameRegex.String())
+	}
+	return nil
 }
 
 type Project struct {
@@ -358,10 +476,13 @@ func GetProject(provider *gcorecloud.ProviderClient, projectID int, projectName
 	if err != nil {
 		return 0, err
 	}
-	projects, err := projects.ListAll(client)
+	cached, err := catalog.get(catalogKey(client, ""), func() (interface{}, error) {
+		return projects.ListAll(client)
+	})
 	if err != nil {
 		return 0, err
 	}
+	projects := cached.([]projects.Project)
 	log.Printf("[DEBUG] Projects: %v", projects)
 	projectID, err = findProjectByName(projects, projectName)
 	if err != nil {
@@ -396,10 +517,13 @@ func GetRegion(provider *gcorecloud.ProviderClient, regionID int, regionName str
 		return 0, err
 	}
 
-	rs, err := regions.ListAll(client, nil)
+	cached, err := catalog.get(catalogKey(client, ""), func() (interface{}, error) {
+		return regions.ListAll(client, nil)
+	})
 	if err != nil {
 		return 0, err
 	}
+	rs := cached.([]regions.Region)
 	log.Printf("[DEBUG] Regions: %v", rs)
 	regionID, err = findRegionByName(rs, regionName)
 	if err != nil {
@@ -498,6 +622,24 @@ func CreateClient(provider *gcorecloud.ProviderClient, d *schema.ResourceData, e
 	return client, nil
 }
 
+// createDiffClient creates the client in the project and region of the planned resource for the checks of the plan.
+func createDiffClient(provider *gcorecloud.ProviderClient, d *schema.ResourceDiff, endpoint string, version string) (*gcorecloud.ServiceClient, error) {
+	projectID, err := GetProject(provider, d.Get("project_id").(int), d.Get("project_name").(string))
+	if err != nil {
+		return nil, err
+	}
+	regionID, err := GetRegion(provider, d.Get("region_id").(int), d.Get("region_name").(string))
+	if err != nil {
+		return nil, err
+	}
+	return gc.ClientServiceFromProvider(provider, gcorecloud.EndpointOpts{
+		Name:    endpoint,
+		Region:  regionID,
+		Project: projectID,
+		Version: version,
+	})
+}
+
 func revertState(d *schema.ResourceData, fields *[]string) {
 	if d.Get("last_updated").(string) != "" {
 		for _, field := range *fields {
@@ -547,7 +689,7 @@ func extractSessionPersistenceMap(d *schema.ResourceData) *lbpools.CreateSession
 func extractHealthMonitorMap(d *schema.ResourceData) *lbpools.CreateHealthMonitorOpts {
 	var healthOpts *lbpools.CreateHealthMonitorOpts
 	monitors := d.Get("health_monitor").([]interface{})
-	if len(monitors) > 0 {
+	if lbPoolHealthMonitorEnabled(monitors) {
 		hm := monitors[0].(map[string]interface{})
 		healthOpts = &lbpools.CreateHealthMonitorOpts{
 			Type:       typesLb.HealthMonitorType(hm["type"].(string)),
@@ -584,6 +726,15 @@ func extractHealthMonitorMap(d *schema.ResourceData) *lbpools.CreateHealthMonito
 	return healthOpts
 }
 
+// lbPoolHealthMonitorEnabled checks if the health monitor is configured and not paused.
+func lbPoolHealthMonitorEnabled(monitors []interface{}) bool {
+	if len(monitors) == 0 || monitors[0] == nil {
+		return false
+	}
+	enabled, ok := monitors[0].(map[string]interface{})["enabled"].(bool)
+	return !ok || enabled
+}
+
 func extractUserList(v []interface{}) ([]listeners.CreateUserListOpts, error) {
 	UserList := make([]listeners.CreateUserListOpts, len(v))
 	for i, userList := range v {
@@ -612,6 +763,115 @@ func volumeUniqueID(i interface{}) int {
 	return int(binary.BigEndian.Uint64(h.Sum(nil)))
 }
 
+// instanceVolumeBoot is the boot order of a configured instance volume,
+// volumeID is empty until the volume is created.
+type instanceVolumeBoot struct {
+	volumeID  string
+	bootIndex int
+}
+
+// validateInstanceBootIndex checks the boot order of the instance volumes at plan time.
+// The configured volumes are read from the raw config, volumes with unknown IDs have the same hash in the set.
+func validateInstanceBootIndex(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	raw := d.GetRawConfig()
+	if raw.IsNull() || !raw.IsKnown() {
+		return nil
+	}
+	rawVolumes := raw.GetAttr("volume")
+	if rawVolumes.IsNull() || !rawVolumes.IsKnown() {
+		return nil
+	}
+
+	volumes := make([]instanceVolumeBoot, 0, rawVolumes.LengthInt())
+	for it := rawVolumes.ElementIterator(); it.Next(); {
+		_, v := it.Element()
+		var volume instanceVolumeBoot
+		if id := v.GetAttr("volume_id"); id.IsKnown() && !id.IsNull() {
+			volume.volumeID = id.AsString()
+		}
+		index := v.GetAttr("boot_index")
+		if !index.IsKnown() {
+			return nil
+		}
+		if !index.IsNull() {
+			i, _ := index.AsBigFloat().Int64()
+			volume.bootIndex = int(i)
+		}
+		volumes = append(volumes, volume)
+	}
+
+	var oldBootVolume string
+	if d.Id() != "" {
+		oldVolumes, _ := d.GetChange("volume")
+		oldBootVolume = instanceBootVolumeID(oldVolumes.(*schema.Set).List())
+	}
+	return checkInstanceBootIndex(oldBootVolume, volumes)
+}
+
+// checkInstanceBootIndex checks that exactly one volume is booted from, an unset boot_index is sent as 0,
+// and that the other bootable volumes have unique boot indexes. The boot volume of an existing instance
+// can't be changed, the update only attaches and detaches volumes, so the instance would not boot after it.
+func checkInstanceBootIndex(oldBootVolume string, volumes []instanceVolumeBoot) error {
+	if len(volumes) == 0 {
+		return nil
+	}
+
+	var bootVolumes []instanceVolumeBoot
+	indexes := make(map[int]bool)
+	for _, volume := range volumes {
+		if volume.bootIndex == 0 {
+			bootVolumes = append(bootVolumes, volume)
+			continue
+		}
+		if volume.bootIndex < 0 {
+			continue
+		}
+		if indexes[volume.bootIndex] {
+			return fmt.Errorf("more than one volume has boot_index %d", volume.bootIndex)
+		}
+		indexes[volume.bootIndex] = true
+	}
+	if len(bootVolumes) != 1 {
+		return fmt.Errorf("exactly one volume must have boot_index 0, got %d", len(bootVolumes))
+	}
+
+	if oldBootVolume != "" && bootVolumes[0].volumeID != oldBootVolume {
+		newBootVolume := bootVolumes[0].volumeID
+		if newBootVolume == "" {
+			newBootVolume = "a new volume"
+		}
+		return fmt.Errorf("the boot volume of the instance can't be changed from %s to %s, the instance must be recreated", oldBootVolume, newBootVolume)
+	}
+	return nil
+}
+
+// instanceBootVolumeID returns the ID of the only volume with boot_index 0, or an empty string
+// if there is no such volume, imported instances don't know the boot_index of their volumes.
+func instanceBootVolumeID(volumes []interface{}) string {
+	bootVolume := instanceBootVolume(volumes)
+	if bootVolume == nil {
+		return ""
+	}
+	id, _ := bootVolume["volume_id"].(string)
+	return id
+}
+
+// instanceBootVolume returns the only volume with boot_index 0, or nil if there is no such volume.
+func instanceBootVolume(volumes []interface{}) map[string]interface{} {
+	var bootVolume map[string]interface{}
+	for _, v := range volumes {
+		volume := v.(map[string]interface{})
+		if index, _ := volume["boot_index"].(int); index != 0 {
+			continue
+		}
+		if bootVolume != nil {
+			return nil
+		}
+		bootVolume = volume
+	}
+	return bootVolume
+}
+
 func secGroupUniqueID(i interface{}) int {
 	e := i.(map[string]interface{})
 	h := md5.New()
@@ -635,6 +895,68 @@ func validatePortRange(v interface{}, path cty.Path) diag.Diagnostics {
 	return diag.Errorf("available range %d-%d", minPort, maxPort)
 }
 
+var (
+	uuidRegex        = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	domainLabelRegex = regexp.MustCompile(`^([a-zA-Z0-9_]|[a-zA-Z0-9_][a-zA-Z0-9_-]{0,61}[a-zA-Z0-9])$`)
+)
+
+const maxDomainLength = 255
+
+// validateUUID checks that the value is an ID of a cloud resource.
+func validateUUID(v interface{}, path cty.Path) diag.Diagnostics {
+	val := v.(string)
+	if uuidRegex.MatchString(val) {
+		return nil
+	}
+	return diag.Errorf("must be a valid UUID, got: %s", val)
+}
+
+// validateCIDR checks that the value is an IPv4 or IPv6 network in CIDR notation.
+func validateCIDR(v interface{}, path cty.Path) diag.Diagnostics {
+	val := v.(string)
+	if _, _, err := net.ParseCIDR(val); err != nil {
+		return diag.Errorf("must be a valid CIDR, got: %s", val)
+	}
+	return nil
+}
+
+// validateDomain checks that the value is a domain name. A leading wildcard label and a trailing dot are allowed.
+func validateDomain(v interface{}, path cty.Path) diag.Diagnostics {
+	val := v.(string)
+	if strings.TrimSpace(val) == "" || len(val) > maxDomainLength {
+		return diag.Errorf("domain can't be empty, it also should be less than %d symbols", maxDomainLength+1)
+	}
+	labels := strings.Split(strings.TrimSuffix(val, "."), ".")
+	for i, label := range labels {
+		if i == 0 && label == "*" {
+			continue
+		}
+		if !domainLabelRegex.MatchString(label) {
+			return diag.Errorf("must be a valid domain name, got: %s", val)
+		}
+	}
+	return nil
+}
+
+// validatePortNumber checks that the value is a TCP/UDP port number usable by a listener or a backend.
+func validatePortNumber(v interface{}, path cty.Path) diag.Diagnostics {
+	val := v.(int)
+	if val >= minPort+1 && val <= maxPort {
+		return nil
+	}
+	return diag.Errorf("available range %d-%d, got: %d", minPort+1, maxPort, val)
+}
+
+// validateEmptyOr allows an empty value for optional attributes checked by f.
+func validateEmptyOr(f schema.SchemaValidateDiagFunc) schema.SchemaValidateDiagFunc {
+	return func(v interface{}, path cty.Path) diag.Diagnostics {
+		if v.(string) == "" {
+			return nil
+		}
+		return f(v, path)
+	}
+}
+
 func extractSecurityGroupRuleMap(r interface{}, gid string) securitygroups.CreateRuleOptsBuilder {
 	rule := r.(map[string]interface{})
 	opts := securitygroups.CreateSecurityGroupRuleOpts{
@@ -938,6 +1260,57 @@ func getIntEnvOrDefault(key string, defaultValue int) int {
 	return val
 }
 
+// waitForDeleted polls get with a growing interval until it returns 404, so dependent objects
+// are not deleted while the API still sees the object and answers with a conflict.
+func waitForDeleted(ctx context.Context, timeout time.Duration, get func() error) error {
+	conf := retry.StateChangeConf{
+		Pending: []string{"exists"},
+		Target:  []string{"deleted"},
+		Refresh: func() (interface{}, string, error) {
+			err := get()
+			if err == nil {
+				return struct{}{}, "exists", nil
+			}
+			var errDefault404 gcorecloud.ErrDefault404
+			if errors.As(err, &errDefault404) {
+				return struct{}{}, "deleted", nil
+			}
+			return nil, "", err
+		},
+		Timeout:    timeout,
+		MinTimeout: time.Second,
+	}
+	_, err := conf.WaitForStateContext(ctx)
+	return err
+}
+
+// waitForLoadBalancerActive waits until the load balancer finishes the update caused by a change of its child object.
+// A deleted load balancer is not waited for.
+func waitForLoadBalancerActive(ctx context.Context, client *gcorecloud.ServiceClient, loadbalancerID string, timeout time.Duration) error {
+	conf := retry.StateChangeConf{
+		Pending: []string{
+			typesLb.ProvisioningStatusPendingCreate.String(),
+			typesLb.ProvisioningStatusPendingUpdate.String(),
+		},
+		Target: []string{typesLb.ProvisioningStatusActive.String(), typesLb.ProvisioningStatusDeleted.String()},
+		Refresh: func() (interface{}, string, error) {
+			lb, err := loadbalancers.Get(client, loadbalancerID, nil).Extract()
+			if err != nil {
+				var errDefault404 gcorecloud.ErrDefault404
+				if errors.As(err, &errDefault404) {
+					return struct{}{}, typesLb.ProvisioningStatusDeleted.String(), nil
+				}
+				return nil, "", err
+			}
+			return lb, lb.ProvisioningStatus.String(), nil
+		},
+		Timeout:    timeout,
+		MinTimeout: time.Second,
+	}
+	_, err := conf.WaitForStateContext(ctx)
+	return err
+}
+
 func GetConflictRetryConfig(resourceTimeoutSeconds int) ConflictRetryConfig {
 	interval := getIntEnvOrDefault("TF_CONFLICT_RETRY_INTERVAL_SECONDS", ConflictRetryInterval)
 	var amount int
@@ -952,3 +1325,17 @@ func GetConflictRetryConfig(resourceTimeoutSeconds int) ConflictRetryConfig {
 		Interval: interval,
 	}
 }
+
+// GetResourceConflictRetryConfig returns the conflict retry config of the resource,
+// conflict_retry_interval and conflict_retry_amount of the resource override the config derived from the timeout.
+func GetResourceConflictRetryConfig(d *schema.ResourceData, resourceTimeoutSeconds int) ConflictRetryConfig {
+	rc := GetConflictRetryConfig(resourceTimeoutSeconds)
+	if interval, ok := d.GetOk("conflict_retry_interval"); ok {
+		rc.Interval = interval.(int)
+		rc.Amount = resourceTimeoutSeconds / rc.Interval
+	}
+	if amount, ok := d.GetOk("conflict_retry_amount"); ok {
+		rc.Amount = amount.(int)
+	}
+	return rc
+}
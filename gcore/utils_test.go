@@ -1,6 +1,23 @@
 package gcore
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/G-Core/gcorelabscloud-go/gcore/secret/v1/secrets"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
 
 func TestExtractHosAndPath(t *testing.T) {
 	type args struct {
@@ -57,3 +74,431 @@ func TestExtractHosAndPath(t *testing.T) {
 		})
 	}
 }
+
+func TestResourceNameConvention(t *testing.T) {
+	config := &Config{
+		NamePrefix: "prod-",
+		NameSuffix: "-eu",
+		NameRegex:  regexp.MustCompile(`^prod-[a-z0-9-]+-eu$`),
+	}
+	tests := []struct {
+		name      string
+		short     string
+		full      string
+		wantValid bool
+	}{
+		{
+			name:      "short name",
+			short:     "web",
+			full:      "prod-web-eu",
+			wantValid: true,
+		},
+		{
+			name:      "name with prefix and suffix",
+			short:     "prod-web-eu",
+			full:      "prod-web-eu",
+			wantValid: true,
+		},
+		{
+			name:      "name violates convention",
+			short:     "Web_1",
+			full:      "prod-Web_1-eu",
+			wantValid: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := config.fullResourceName(tt.short); got != tt.full {
+				t.Errorf("fullResourceName() = %v, want %v", got, tt.full)
+			}
+			err := validateNameConvention(context.Background(), "", tt.short, config)
+			if (err == nil) != tt.wantValid {
+				t.Errorf("validateNameConvention() error = %v, wantValid %v", err, tt.wantValid)
+			}
+		})
+	}
+
+	if got := config.shortResourceName("prod-web-eu"); got != "web" {
+		t.Errorf("shortResourceName() = %v, want %v", got, "web")
+	}
+}
+
+func TestConfigWithContext(t *testing.T) {
+	config := &Config{Provider: &gcorecloud.ProviderClient{}, NamePrefix: "prod-"}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	got := config.withContext(ctx)
+	if got.Provider.Context != ctx {
+		t.Errorf("withContext() provider context = %v, want %v", got.Provider.Context, ctx)
+	}
+	if config.Provider.Context != nil {
+		t.Errorf("withContext() changed the shared provider context")
+	}
+	if got.NamePrefix != config.NamePrefix {
+		t.Errorf("withContext() NamePrefix = %v, want %v", got.NamePrefix, config.NamePrefix)
+	}
+}
+
+func TestConfigWithContextConcurrentReauth(t *testing.T) {
+	shared := &gcorecloud.ProviderClient{APIBase: "https://api.example.com/"}
+	shared.UseTokenLock()
+	config := &Config{Provider: shared}
+
+	// the tokens of the shared client are refreshed while the operations copy it
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			shared.CopyTokensFrom(&gcorecloud.ProviderClient{AccessTokenID: fmt.Sprintf("token-%d", i)})
+		}
+	}()
+	for i := 0; i < 20; i++ {
+		got := config.withContext(context.Background())
+		if got.Provider.APIBase != shared.APIBase || got.Provider.Context == nil {
+			t.Fatalf("withContext() provider = %+v", got.Provider)
+		}
+		runtime.Gosched()
+	}
+	close(stop)
+	wg.Wait()
+}
+
+func TestSchemaValidators(t *testing.T) {
+	tests := []struct {
+		name      string
+		validate  schema.SchemaValidateDiagFunc
+		value     interface{}
+		wantValid bool
+	}{
+		{name: "uuid", validate: validateUUID, value: "5b6e8d5d-0ab4-4b4a-a5ac-6bc3c0d41e2f", wantValid: true},
+		{name: "not uuid", validate: validateUUID, value: "lb-1", wantValid: false},
+		{name: "ipv4 cidr", validate: validateCIDR, value: "192.168.10.0/24", wantValid: true},
+		{name: "ipv6 cidr", validate: validateCIDR, value: "fd00::/64", wantValid: true},
+		{name: "ip without mask", validate: validateCIDR, value: "192.168.10.1", wantValid: false},
+		{name: "empty optional cidr", validate: validateEmptyOr(validateCIDR), value: "", wantValid: true},
+		{name: "domain", validate: validateDomain, value: "sub.example.com", wantValid: true},
+		{name: "fqdn", validate: validateDomain, value: "example.com.", wantValid: true},
+		{name: "wildcard domain", validate: validateDomain, value: "*.example.com", wantValid: true},
+		{name: "empty domain", validate: validateDomain, value: " ", wantValid: false},
+		{name: "domain with empty label", validate: validateDomain, value: "sub..example.com", wantValid: false},
+		{name: "domain with wrong symbols", validate: validateDomain, value: "exa mple.com", wantValid: false},
+		{name: "port", validate: validatePortNumber, value: 443, wantValid: true},
+		{name: "zero port", validate: validatePortNumber, value: 0, wantValid: false},
+		{name: "port out of range", validate: validatePortNumber, value: 65536, wantValid: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := tt.validate(tt.value, cty.Path{})
+			if diags.HasError() == tt.wantValid {
+				t.Errorf("validate(%v) = %v, wantValid %v", tt.value, diags, tt.wantValid)
+			}
+		})
+	}
+}
+
+func TestWaitForDeleted(t *testing.T) {
+	var calls int
+	err := waitForDeleted(context.Background(), time.Minute, func() error {
+		calls++
+		if calls < 2 {
+			return nil
+		}
+		return gcorecloud.ErrDefault404{}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("waitForDeleted() checked %d times, want 2", calls)
+	}
+
+	err = waitForDeleted(context.Background(), time.Minute, func() error {
+		return errors.New("unavailable")
+	})
+	if err == nil {
+		t.Errorf("waitForDeleted() expected error")
+	}
+}
+
+func TestGetResourceConflictRetryConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		raw    map[string]interface{}
+		expect ConflictRetryConfig
+	}{
+		{"derived from the timeout", map[string]interface{}{}, ConflictRetryConfig{Amount: 60, Interval: ConflictRetryInterval}},
+		{"interval", map[string]interface{}{"conflict_retry_interval": 30}, ConflictRetryConfig{Amount: 20, Interval: 30}},
+		{"amount", map[string]interface{}{"conflict_retry_amount": 5}, ConflictRetryConfig{Amount: 5, Interval: ConflictRetryInterval}},
+		{"both", map[string]interface{}{"conflict_retry_interval": 2, "conflict_retry_amount": 100}, ConflictRetryConfig{Amount: 100, Interval: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceLBPool().Schema, tt.raw)
+			if got := GetResourceConflictRetryConfig(d, 600); got != tt.expect {
+				t.Errorf("GetResourceConflictRetryConfig() = %+v, want %+v", got, tt.expect)
+			}
+		})
+	}
+}
+
+func TestCheckInstanceBootIndex(t *testing.T) {
+	tests := []struct {
+		name          string
+		oldBootVolume string
+		volumes       []instanceVolumeBoot
+		wantErr       bool
+	}{
+		{"no volumes", "", nil, false},
+		{"single unset boot_index", "", []instanceVolumeBoot{{volumeID: "a"}}, false},
+		{"boot and data volumes", "", []instanceVolumeBoot{{"a", 0}, {"b", 1}, {"c", -1}}, false},
+		{"new volumes", "", []instanceVolumeBoot{{"", 0}, {"", 1}}, false},
+		{"no boot volume", "", []instanceVolumeBoot{{"a", 1}, {"b", 2}}, true},
+		{"two boot volumes", "", []instanceVolumeBoot{{"a", 0}, {"b", 0}}, true},
+		{"duplicated boot_index", "", []instanceVolumeBoot{{"a", 0}, {"b", 1}, {"c", 1}}, true},
+		{"same boot volume", "a", []instanceVolumeBoot{{"a", 0}, {"b", 1}}, false},
+		{"changed boot volume", "a", []instanceVolumeBoot{{"a", 1}, {"b", 0}}, true},
+		{"replaced boot volume", "a", []instanceVolumeBoot{{"", 0}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkInstanceBootIndex(tt.oldBootVolume, tt.volumes)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkInstanceBootIndex() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExtractHealthMonitorMap(t *testing.T) {
+	monitor := map[string]interface{}{"type": "HTTP", "delay": 10, "max_retries": 3, "timeout": 5, "url_path": "/health"}
+	d := schema.TestResourceDataRaw(t, resourceLBPool().Schema, map[string]interface{}{
+		"health_monitor": []interface{}{monitor},
+	})
+	opts := extractHealthMonitorMap(d)
+	if opts == nil || opts.Delay != 10 || opts.URLPath != "/health" {
+		t.Errorf("extractHealthMonitorMap() = %+v", opts)
+	}
+
+	monitor["enabled"] = false
+	d = schema.TestResourceDataRaw(t, resourceLBPool().Schema, map[string]interface{}{
+		"health_monitor": []interface{}{monitor},
+	})
+	if opts := extractHealthMonitorMap(d); opts != nil {
+		t.Errorf("extractHealthMonitorMap() of the paused monitor = %+v, want nil", opts)
+	}
+}
+
+func TestGenerateWindowsPassword(t *testing.T) {
+	classes := []*regexp.Regexp{
+		regexp.MustCompile(`[a-z]`),
+		regexp.MustCompile(`[A-Z]`),
+		regexp.MustCompile(`[0-9]`),
+		regexp.MustCompile(`[^a-zA-Z0-9]`),
+	}
+	for i := 0; i < 20; i++ {
+		password, err := generateWindowsPassword()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(password) != 24 {
+			t.Errorf("password %q has length %d", password, len(password))
+		}
+		for _, class := range classes {
+			if !class.MatchString(password) {
+				t.Errorf("password %q has no characters of %s", password, class)
+			}
+		}
+	}
+}
+
+func TestResolveInstanceOSType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/volumes/1/2/by-id":
+			fmt.Fprint(w, `{"id":"by-id","volume_image_metadata":{"image_id":"windows"}}`)
+		case "/v1/volumes/1/2/by-name":
+			fmt.Fprint(w, `{"id":"by-name","volume_image_metadata":{"image_name":"Windows Server 2022"}}`)
+		case "/v1/volumes/1/2/blank":
+			fmt.Fprint(w, `{"id":"blank"}`)
+		case "/v1/images/1/2/windows":
+			fmt.Fprint(w, `{"id":"windows","name":"Windows Server 2022","os_type":"windows"}`)
+		case "/v1/images/1/2/ubuntu":
+			fmt.Fprint(w, `{"id":"ubuntu","name":"Ubuntu 22.04","os_type":"linux"}`)
+		case "/v1/images/1/2":
+			fmt.Fprint(w, `{"count":2,"results":[{"id":"ubuntu","name":"Ubuntu 22.04","os_type":"linux"},{"id":"windows","name":"Windows Server 2022","os_type":"windows"}]}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	provider := &gcorecloud.ProviderClient{APIBase: server.URL + "/"}
+	volumesClient := &gcorecloud.ServiceClient{ProviderClient: provider, Endpoint: server.URL + "/v1/volumes/1/2/"}
+	imagesClient := &gcorecloud.ServiceClient{ProviderClient: provider, Endpoint: server.URL + "/v1/images/1/2/"}
+
+	volume := func(volumeID, imageID string, bootIndex int) map[string]interface{} {
+		return map[string]interface{}{"volume_id": volumeID, "image_id": imageID, "boot_index": bootIndex}
+	}
+	tests := []struct {
+		name    string
+		volumes []interface{}
+		want    string
+	}{
+		{name: "no volumes", want: "linux"},
+		{name: "image of the block", volumes: []interface{}{volume("", "windows", 0)}, want: "windows"},
+		{name: "linux image of the block", volumes: []interface{}{volume("by-id", "ubuntu", 0)}, want: "linux"},
+		{name: "image ID of the volume", volumes: []interface{}{volume("by-id", "", 0), volume("blank", "", 1)}, want: "windows"},
+		{name: "image name of the volume", volumes: []interface{}{volume("by-name", "", 0)}, want: "windows"},
+		{name: "volume without image", volumes: []interface{}{volume("blank", "", 0)}, want: "linux"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveInstanceOSType(volumesClient, imagesClient, tt.volumes)
+			if err != nil {
+				t.Fatalf("resolveInstanceOSType() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("resolveInstanceOSType() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstanceAccessAddress(t *testing.T) {
+	address := func(addr, typ string) interface{} {
+		return map[string]interface{}{"addr": addr, "type": typ}
+	}
+	addresses := []interface{}{
+		map[string]interface{}{"net": []interface{}{address("10.0.0.2", "fixed")}},
+		map[string]interface{}{"net": []interface{}{address("10.0.1.2", "fixed"), address("203.0.113.5", "floating")}},
+	}
+	if got := instanceAccessAddress(addresses); got != "203.0.113.5" {
+		t.Errorf("instanceAccessAddress() = %q, want floating address", got)
+	}
+	if got := instanceAccessAddress(addresses[:1]); got != "10.0.0.2" {
+		t.Errorf("instanceAccessAddress() = %q, want first fixed address", got)
+	}
+	if got := instanceAccessAddress(nil); got != "" {
+		t.Errorf("instanceAccessAddress() = %q, want empty", got)
+	}
+}
+
+func TestParseDNSZoneRecordImportID(t *testing.T) {
+	tests := []struct {
+		id                  string
+		zone, domain, rType string
+		wantErr             bool
+	}{
+		{id: "example.com:www.example.com:A", zone: "example.com", domain: "www.example.com", rType: "A"},
+		{id: "example.com/www.example.com./cname", zone: "example.com", domain: "www.example.com", rType: "cname"},
+		{id: "example.com/www/TXT", zone: "example.com", domain: "www.example.com", rType: "TXT"},
+		{id: "example.com./@/MX", zone: "example.com", domain: "example.com", rType: "MX"},
+		{id: "example.com/example.com/NS", zone: "example.com", domain: "example.com", rType: "NS"},
+		{id: "example.com/www.example.com", wantErr: true},
+		{id: "example.com//A", wantErr: true},
+	}
+	for _, tt := range tests {
+		zone, domain, rType, err := parseDNSZoneRecordImportID(tt.id)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseDNSZoneRecordImportID(%q) error = %v, wantErr %v", tt.id, err, tt.wantErr)
+			continue
+		}
+		if zone != tt.zone || domain != tt.domain || rType != tt.rType {
+			t.Errorf("parseDNSZoneRecordImportID(%q) = %q, %q, %q, want %q, %q, %q", tt.id, zone, domain, rType, tt.zone, tt.domain, tt.rType)
+		}
+	}
+}
+
+func TestInPlaceUpdatableAttributes(t *testing.T) {
+	// the attributes have update APIs, recreating the objects instead breaks the traffic
+	tests := []struct {
+		resource   string
+		r          *schema.Resource
+		attributes []string
+	}{
+		{resource: "gcore_loadbalancer", r: resourceLoadBalancer(), attributes: []string{"name", "flavor"}},
+		{resource: "gcore_loadbalancerv2", r: resourceLoadBalancerV2(), attributes: []string{"name", "flavor", "logging"}},
+		{resource: "gcore_lblistener", r: resourceLbListener(), attributes: []string{"name", "allowed_cidrs", "connection_limit"}},
+		{resource: "gcore_lbpool", r: resourceLBPool(), attributes: []string{"lb_algorithm", "protocol", "health_monitor", "session_persistence"}},
+		{resource: "gcore_lbmember", r: resourceLBMember(), attributes: []string{"address", "protocol_port", "weight"}},
+		{resource: "gcore_network", r: resourceNetwork(), attributes: []string{"name"}},
+		{resource: "gcore_subnet", r: resourceSubnet(), attributes: []string{"name", "enable_dhcp", "dns_nameservers", "host_routes", "gateway_ip"}},
+		{resource: "gcore_volume", r: resourceVolume(), attributes: []string{"name", "size", "type_name"}},
+	}
+	for _, tt := range tests {
+		if tt.r.UpdateContext == nil {
+			t.Errorf("%s has no update", tt.resource)
+			continue
+		}
+		for _, attr := range tt.attributes {
+			s, ok := tt.r.Schema[attr]
+			if !ok {
+				t.Errorf("%s has no attribute %s", tt.resource, attr)
+				continue
+			}
+			if s.ForceNew {
+				t.Errorf("%s.%s is ForceNew, want in-place update", tt.resource, attr)
+			}
+		}
+	}
+}
+
+func TestInstanceFirstBootAttributes(t *testing.T) {
+	// cloud-init applies the attributes on the first boot only, changing them in place would be a silent no-op
+	r := resourceInstance()
+	for _, attr := range []string{"ssh_authorized_keys", "user_data_part"} {
+		if !r.Schema[attr].ForceNew {
+			t.Errorf("gcore_instance.%s is not ForceNew", attr)
+		}
+	}
+	for attr, s := range r.Schema["user_data_part"].Elem.(*schema.Resource).Schema {
+		if !s.ForceNew {
+			t.Errorf("gcore_instance.user_data_part.%s is not ForceNew", attr)
+		}
+	}
+}
+
+func TestLatestSecret(t *testing.T) {
+	created := func(day int) gcorecloud.JSONRFC3339ZColon {
+		return gcorecloud.JSONRFC3339ZColon{Time: time.Date(2024, 6, day, 0, 0, 0, 0, time.UTC)}
+	}
+	list := []secrets.Secret{
+		{ID: "old", Name: "lb_https-2024-06-01", CreatedAt: created(1)},
+		{ID: "new", Name: "lb_https-2024-06-20", CreatedAt: created(20)},
+		{ID: "other", Name: "api_https", CreatedAt: created(25)},
+		{ID: "mid", Name: "lb_https-2024-06-10", CreatedAt: created(10)},
+	}
+
+	got := latestSecret(list, func(s secrets.Secret) bool { return strings.HasPrefix(s.Name, "lb_https-") })
+	if got == nil || got.ID != "new" {
+		t.Errorf("latestSecret() = %+v, want the secret created last", got)
+	}
+	if got := latestSecret(list, func(s secrets.Secret) bool { return s.Name == "missing" }); got != nil {
+		t.Errorf("latestSecret() = %+v, want nil when nothing matches", got)
+	}
+}
+
+func TestInstancePreservedPorts(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceInstance().Schema, map[string]interface{}{
+		"flavor_id": "g1-standard-1-2",
+		"interface": []interface{}{
+			map[string]interface{}{"type": "subnet", "network_id": "net", "subnet_id": "subnet"},
+			map[string]interface{}{"type": "reserved_fixed_ip", "port_id": "kept", "ip_address": "10.0.0.5", "preserve_port_on_destroy": true},
+			map[string]interface{}{"type": "reserved_fixed_ip", "port_id": "dropped"},
+		},
+	})
+
+	got := instancePreservedPorts(d.Get("interface").([]interface{}))
+	if len(got) != 1 || got["kept"] != "10.0.0.5" {
+		t.Errorf("instancePreservedPorts() = %v, want only the kept port with its IP address", got)
+	}
+}
@@ -0,0 +1,68 @@
+package gcore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// waapPath is the WAAP API path relative to the api_endpoint of the provider,
+// there is no WAAP SDK so the resources send the requests with sendAPIRequest.
+const waapPath = "/waap/v1"
+
+// waapRequest sends the request to the WAAP API with the JSON encoded body, if any,
+// and decodes the response into out, if any.
+func waapRequest(config *Config, method, path string, body, out interface{}) error {
+	var raw string
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		raw = string(b)
+	}
+	_, response, err := sendAPIRequest(config, method, waapPath+path, raw, nil)
+	if err != nil {
+		return err
+	}
+	if out == nil || response == "" {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(response), out); err != nil {
+		return fmt.Errorf("decode response of %s %s: %w", method, path, err)
+	}
+	return nil
+}
+
+// waapNotFound reports whether the WAAP object is gone.
+func waapNotFound(err error) bool {
+	var notFound gcorecloud.ErrDefault404
+	return errors.As(err, &notFound)
+}
+
+// parseWAAPImportID parses the <domain_id>:<id> import ID of the objects of a WAAP domain.
+func parseWAAPImportID(importID string) (int, string, error) {
+	parts := strings.SplitN(importID, ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return 0, "", fmt.Errorf("import ID %q must be in the <domain_id>:<id> format", importID)
+	}
+	domainID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", fmt.Errorf("import ID %q: domain ID: %w", importID, err)
+	}
+	return domainID, parts[1], nil
+}
+
+// waapStrings returns the strings of the set, it is empty rather than nil so the API clears the list.
+func waapStrings(set *schema.Set) []string {
+	result := []string{}
+	for _, v := range set.List() {
+		result = append(result, v.(string))
+	}
+	return result
+}
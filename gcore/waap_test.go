@@ -0,0 +1,160 @@
+//go:build !cloud
+// +build !cloud
+
+package gcore
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gcorecloud "github.com/G-Core/gcorelabscloud-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestParseWAAPImportID(t *testing.T) {
+	domainID, id, err := parseWAAPImportID("42:6a1c2f50-3b0e-4d3e-9f5a-0c2d7e1b9a11")
+	if err != nil || domainID != 42 || id != "6a1c2f50-3b0e-4d3e-9f5a-0c2d7e1b9a11" {
+		t.Errorf("parseWAAPImportID() = %d, %q, %v", domainID, id, err)
+	}
+	for _, importID := range []string{"42", "42:", "domain:id"} {
+		if _, _, err := parseWAAPImportID(importID); err == nil {
+			t.Errorf("parseWAAPImportID(%q) is accepted", importID)
+		}
+	}
+}
+
+func TestWAAPDomainMode(t *testing.T) {
+	status := "monitor"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/waap/v1/domains/42":
+			fmt.Fprintf(w, `{"id":42,"name":"shop.example.com","status":%q}`, status)
+		case "/waap/v1/domains/42/settings":
+			fmt.Fprint(w, `{"api":{"api_urls":[]}}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+	config := &Config{Provider: &gcorecloud.ProviderClient{}, APIEndpoint: server.URL}
+
+	d := schema.TestResourceDataRaw(t, resourceWAAPDomain().Schema, map[string]interface{}{"domain_id": 42})
+	d.SetId("42")
+	if diags := resourceWAAPDomainRead(context.Background(), d, config); len(diags) != 0 || d.Get("mode").(string) != "monitor" {
+		t.Errorf("read = %v, mode %q, want monitor", diags, d.Get("mode"))
+	}
+
+	status = "locked"
+	diags := resourceWAAPDomainRead(context.Background(), d, config)
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("read = %v, want a warning", diags)
+	}
+	if d.Get("mode").(string) != "monitor" || d.Get("status").(string) != "locked" {
+		t.Errorf("state = %v, want the monitor mode and the locked status", d.State().Attributes)
+	}
+}
+
+func TestWAAPAPIPath(t *testing.T) {
+	const pathID = "6a1c2f50-3b0e-4d3e-9f5a-0c2d7e1b9a11"
+	stored := waapAPIPath{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/waap/v1/domains/42/api-paths":
+			if err := json.NewDecoder(r.Body).Decode(&stored); err != nil {
+				t.Error(err)
+			}
+			stored.ID, stored.Status, stored.Source = pathID, "CONFIRMED_API", "USER_DEFINED"
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(stored)
+		case r.Method == http.MethodGet && r.URL.Path == "/waap/v1/domains/42/api-paths/"+pathID:
+			if stored.ID == "" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			json.NewEncoder(w).Encode(stored)
+		case r.Method == http.MethodDelete && r.URL.Path == "/waap/v1/domains/42/api-paths/"+pathID:
+			stored = waapAPIPath{}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+	config := &Config{Provider: &gcorecloud.ProviderClient{}, APIEndpoint: server.URL}
+
+	d := schema.TestResourceDataRaw(t, resourceWAAPAPIPath().Schema, map[string]interface{}{
+		"domain_id": 42,
+		"path":      "/api/v1/users/{user_id}",
+		"method":    "GET",
+		"tags":      []interface{}{"users"},
+	})
+	if diags := resourceWAAPAPIPathCreate(context.Background(), d, config); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if d.Id() != pathID || d.Get("http_scheme").(string) != "HTTPS" || d.Get("source").(string) != "USER_DEFINED" {
+		t.Errorf("state = %s %v", d.Id(), d.State().Attributes)
+	}
+	if stored.APIGroups == nil || len(stored.Tags) != 1 {
+		t.Errorf("request = %+v, want the tags and the empty API groups", stored)
+	}
+
+	if diags := resourceWAAPAPIPathDelete(context.Background(), d, config); diags.HasError() {
+		t.Fatal(diags)
+	}
+	d.SetId(pathID)
+	if diags := resourceWAAPAPIPathRead(context.Background(), d, config); diags.HasError() || d.Id() != "" {
+		t.Errorf("read of the deleted path = %v, id %q, want it removed from the state", diags, d.Id())
+	}
+}
+
+func TestWAAPAPIDiscoveryUpload(t *testing.T) {
+	const spec = "openapi: 3.0.0\n"
+	uploads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/waap/v1/domains/42/api-discovery/settings" && r.Method == http.MethodPatch:
+			var settings map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&settings)
+			if settings["traffic_scan_enabled"] != false || settings["description_file_location"] != nil {
+				t.Errorf("settings = %v, want only traffic_scan_enabled = false", settings)
+			}
+		case r.URL.Path == "/waap/v1/domains/42/api-discovery/settings" && r.Method == http.MethodGet:
+			fmt.Fprint(w, `{"traffic_scan_enabled":false,"traffic_scan_interval_hours":24}`)
+		case r.URL.Path == "/waap/v1/domains/42/api-discovery/upload" && r.Method == http.MethodPost:
+			var upload waapAPIDescriptionUpload
+			json.NewDecoder(r.Body).Decode(&upload)
+			if data, _ := base64.StdEncoding.DecodeString(upload.FileData); string(data) != spec || upload.FileName != "openapi.yaml" {
+				t.Errorf("upload = %+v", upload)
+			}
+			uploads++
+			fmt.Fprint(w, `{"id":"task-id"}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+	config := &Config{Provider: &gcorecloud.ProviderClient{}, APIEndpoint: server.URL}
+
+	d := schema.TestResourceDataRaw(t, resourceWAAPAPIDiscovery().Schema, map[string]interface{}{
+		"domain_id":            42,
+		"traffic_scan_enabled": false,
+		"openapi_spec":         spec,
+		"openapi_file_name":    "openapi.yaml",
+	})
+	if diags := resourceWAAPAPIDiscoveryUpdate(context.Background(), d, config); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if uploads != 1 || d.Id() != "42" || d.Get("traffic_scan_interval_hours").(int) != 24 {
+		t.Errorf("uploads = %d, state = %s %v", uploads, d.Id(), d.State().Attributes)
+	}
+}